@@ -7,10 +7,23 @@ import (
 	"github.com/jerpsp/go-fiber-beginner/config"
 	"github.com/jerpsp/go-fiber-beginner/internal"
 	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/auth"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/author"
 	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/category"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/circulation"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/readinglist"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/recommendation"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/review"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/series"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/stats"
 	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/user"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/work"
+	"github.com/jerpsp/go-fiber-beginner/middleware"
+	"github.com/jerpsp/go-fiber-beginner/pkg/activity"
+	"github.com/jerpsp/go-fiber-beginner/pkg/catalogue"
 	"github.com/jerpsp/go-fiber-beginner/pkg/database"
 	"github.com/jerpsp/go-fiber-beginner/pkg/email"
+	"github.com/jerpsp/go-fiber-beginner/pkg/feed"
 	"github.com/jerpsp/go-fiber-beginner/pkg/storage"
 )
 
@@ -22,23 +35,69 @@ func main() {
 	db := database.NewGormDB(cfg.PostgresDB)
 	redis := database.NewRedisClient(cfg.Redis)
 	fmt.Println("Redis Ping:", redis.Client.Ping(context.Background()))
+	// book views, loans, list additions and reviews are counted per day for the stats rollup
+	activityCounter := activity.New(cfg.Activity, redis)
+	// book changes of every instance reach the event streams of this one through Redis
+	bookFeed := feed.New(cfg.Feed, redis, book.BookFeedName)
+	go bookFeed.Run(context.Background())
 
 	s3Client := storage.NewS3Client(cfg.AWS)
 	s3Repo := storage.NewS3Repo(s3Client)
 	emailRepo := email.NewEmailRepo(cfg.Email)
+	// authors, categories, works, series and ratings are embedded in cached books
+	bookCache := book.NewBookCacheInvalidator(cfg, redis)
 
-	bookRepo := book.NewBookRepository(cfg, db)
-	bookService := book.NewBookService(cfg, bookRepo)
-	bookHandler := book.NewBookHandler(cfg, bookService)
+	authorRepo := author.NewAuthorRepository(cfg, db)
+	authorService := author.NewAuthorService(cfg, authorRepo, s3Repo, bookCache)
+	authorHandler := author.NewAuthorHandler(cfg, authorService)
+
+	categoryRepo := category.NewCategoryRepository(cfg, db)
+	categoryService := category.NewCategoryService(cfg, categoryRepo, bookCache)
+	categoryHandler := category.NewCategoryHandler(cfg, categoryService)
+
+	workRepo := work.NewWorkRepository(cfg, db)
+	workService := work.NewWorkService(cfg, workRepo, bookCache)
+	workHandler := work.NewWorkHandler(cfg, workService)
+
+	seriesRepo := series.NewSeriesRepository(cfg, db)
+	seriesService := series.NewSeriesService(cfg, seriesRepo, bookCache)
+	seriesHandler := series.NewSeriesHandler(cfg, seriesService)
+
+	bookRepo := book.NewPublishingBookRepository(cfg, book.NewCachedBookRepository(cfg, book.NewBookRepository(cfg, db, redis), redis), bookFeed, s3Repo)
+	bookService := book.NewBookService(cfg, bookRepo, authorRepo, s3Repo, catalogue.New(cfg.Catalogue, redis))
+	bookHandler := book.NewBookHandler(cfg, bookService, activityCounter, bookFeed)
+
+	reviewRepo := review.NewReviewRepository(cfg, db)
+	reviewService := review.NewReviewService(cfg, reviewRepo, activityCounter, bookCache)
+	reviewHandler := review.NewReviewHandler(cfg, reviewService)
+
+	readingListRepo := readinglist.NewReadingListRepository(cfg, db)
+	readingListService := readinglist.NewReadingListService(cfg, readingListRepo, activityCounter)
+	readingListHandler := readinglist.NewReadingListHandler(cfg, readingListService)
+
+	tokenRepo := auth.NewAuthRepository(cfg, db, redis)
+	middleware.UseTokenRevocations(tokenRepo)
 
 	userRepo := user.NewUserRepository(cfg, db)
-	userService := user.NewUserService(cfg, userRepo, s3Repo, emailRepo)
+	accountRecords := []user.AccountRecords{review.NewAccountReviews(bookCache), circulation.NewAccountLedger()}
+	userService := user.NewUserService(cfg, userRepo, s3Repo, emailRepo, tokenRepo, accountRecords)
 	userHandler := user.NewUserHandler(cfg, userService)
 
-	tokenRepo := auth.NewAuthRepository(cfg, db, redis)
+	circulationRepo := circulation.NewCirculationRepository(cfg, db)
+	circulationService := circulation.NewCirculationService(cfg, circulationRepo, userRepo, emailRepo, activityCounter)
+	circulationHandler := circulation.NewCirculationHandler(cfg, circulationService)
+
+	recommendationRepo := recommendation.NewRecommendationRepository(cfg, db)
+	recommendationService := recommendation.NewRecommendationService(cfg, recommendationRepo)
+	recommendationHandler := recommendation.NewRecommendationHandler(cfg, recommendationService)
+
+	statsRepo := stats.NewStatsRepository(cfg, db)
+	statsService := stats.NewStatsService(cfg, statsRepo, activityCounter)
+	statsHandler := stats.NewStatsHandler(cfg, statsService)
+
 	authService := auth.NewAuthService(cfg, userRepo, tokenRepo)
 	authHandler := auth.NewAuthHandler(cfg, authService)
 
 	// Start the server with handlers and db
-	internal.StartServer(cfg, bookHandler, userHandler, authHandler)
+	internal.StartServer(cfg, bookHandler, authorHandler, categoryHandler, workHandler, seriesHandler, reviewHandler, readingListHandler, circulationHandler, recommendationHandler, statsHandler, userHandler, authHandler)
 }
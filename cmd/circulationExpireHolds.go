@@ -0,0 +1,51 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/circulation"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/user"
+	"github.com/jerpsp/go-fiber-beginner/pkg/activity"
+	"github.com/jerpsp/go-fiber-beginner/pkg/database"
+	"github.com/jerpsp/go-fiber-beginner/pkg/email"
+	"github.com/spf13/cobra"
+)
+
+// circulationExpireHoldsCmd represents the circulationExpireHolds command
+var circulationExpireHoldsCmd = &cobra.Command{
+	Use:   "circulationExpireHolds",
+	Short: "Expire holds that were not picked up in time",
+	Long: `Close every ready hold whose pickup deadline has passed and pass its copy on
+to the next hold in the queue, emailing the new holder. Copies nobody is waiting
+for go back on the shelf. Meant to run from a scheduler, it is safe to re-run.
+For example:
+
+go run cmd/cli/main.go circulationExpireHolds`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := config.InitConfig()
+		db := database.NewGormDB(cfg.PostgresDB)
+		defer db.Disconnect()
+
+		// the job checks nothing out, so there are no loan events to count
+		circulationService := circulation.NewCirculationService(cfg, circulation.NewCirculationRepository(cfg, db),
+			user.NewUserRepository(cfg, db), email.NewEmailRepo(cfg.Email), activity.New(cfg.Activity, nil))
+
+		expired, err := circulationService.ExpireHolds(nil)
+		if err != nil {
+			fmt.Println("Expiring holds failed:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("holds expired: %d\n", expired)
+
+		defer fmt.Println("RUN circulationExpireHolds Completed")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(circulationExpireHoldsCmd)
+}
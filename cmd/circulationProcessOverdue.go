@@ -0,0 +1,53 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/circulation"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/user"
+	"github.com/jerpsp/go-fiber-beginner/pkg/activity"
+	"github.com/jerpsp/go-fiber-beginner/pkg/database"
+	"github.com/jerpsp/go-fiber-beginner/pkg/email"
+	"github.com/spf13/cobra"
+)
+
+// circulationProcessOverdueCmd represents the circulationProcessOverdue command
+var circulationProcessOverdueCmd = &cobra.Command{
+	Use:   "circulationProcessOverdue",
+	Short: "Send loan reminders and accrue overdue fines",
+	Long: `Remind borrowers of loans falling due soon, accrue fines on overdue loans
+into the fines ledger and send each overdue borrower one notice. Reminders and
+fines are only recorded once, so it is meant to run from a scheduler (daily or
+more often) and is safe to re-run.
+For example:
+
+go run cmd/cli/main.go circulationProcessOverdue`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := config.InitConfig()
+		db := database.NewGormDB(cfg.PostgresDB)
+		defer db.Disconnect()
+
+		// the job checks nothing out, so there are no loan events to count
+		circulationService := circulation.NewCirculationService(cfg, circulation.NewCirculationRepository(cfg, db),
+			user.NewUserRepository(cfg, db), email.NewEmailRepo(cfg.Email), activity.New(cfg.Activity, nil))
+
+		report, err := circulationService.ProcessOverdue(nil)
+		if err != nil {
+			fmt.Println("Processing overdue loans failed:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("due reminders: %d, overdue loans: %d, overdue reminders: %d, fines accrued: %d cents\n",
+			report.DueReminders, report.OverdueLoans, report.OverdueReminders, report.AccruedCents)
+
+		defer fmt.Println("RUN circulationProcessOverdue Completed")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(circulationProcessOverdueCmd)
+}
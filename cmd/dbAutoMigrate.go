@@ -7,8 +7,17 @@ import (
 	"fmt"
 
 	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/author"
 	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/category"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/circulation"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/readinglist"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/recommendation"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/review"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/series"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/stats"
 	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/user"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/work"
 	"github.com/jerpsp/go-fiber-beginner/pkg/database"
 	"github.com/spf13/cobra"
 )
@@ -27,7 +36,20 @@ to quickly create a Cobra application.`,
 		cfg := config.InitConfig()
 
 		db := database.NewGormDB(cfg.PostgresDB)
-		db.DB.AutoMigrate(&book.Book{}, &user.User{})
+		// the trigram indexes on books need pg_trgm, databases created before it was added lack the extension
+		if err := db.AddTrigramExtension(); err != nil {
+			fmt.Println("Failed to enable pg_trgm:", err)
+		}
+		if err := circulation.RestrictBorrowerKeys(db.DB); err != nil {
+			fmt.Println("Failed to restrict the borrower keys:", err)
+		}
+		db.DB.AutoMigrate(
+			&author.Author{}, &category.Category{}, &work.Work{}, &series.Series{}, &book.Tag{}, &book.Book{}, &book.BookAuthor{}, &book.BookRevision{}, &book.BookRedirect{}, &book.BookFile{}, &book.BookDownload{}, &book.BookTranslation{},
+			&user.User{}, &review.Review{}, &review.ReviewVote{},
+			&readinglist.ReadingList{}, &readinglist.ReadingListItem{}, &readinglist.ReadingProgress{},
+			&circulation.Copy{}, &circulation.Loan{}, &circulation.Hold{}, &circulation.FineEntry{},
+			&recommendation.BookSimilarity{}, &recommendation.UserRecommendation{}, &stats.BookDailyStat{},
+		)
 		db.Disconnect()
 
 		defer fmt.Println("RUN dbAutoMigrate Completed")
@@ -30,6 +30,7 @@ to quickly create a Cobra application.`,
 
 		db = database.NewGormDB(cfg.PostgresDB)
 		db.AddExtension()
+		db.AddTrigramExtension()
 		db.Disconnect()
 
 		defer fmt.Println("RUN dbCreate Completed")
@@ -0,0 +1,84 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/author"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+	"github.com/jerpsp/go-fiber-beginner/pkg/catalogue"
+	"github.com/jerpsp/go-fiber-beginner/pkg/database"
+	"github.com/jerpsp/go-fiber-beginner/pkg/feed"
+	"github.com/jerpsp/go-fiber-beginner/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+// dbImportBooksCmd represents the dbImportBooks command
+var dbImportBooksCmd = &cobra.Command{
+	Use:   "dbImportBooks",
+	Short: "Import books from a CSV or NDJSON file",
+	Long: `Import books from a CSV (title,author,isbn header) or NDJSON file.
+Rows are de-duplicated on ISBN or title+author and written in one transaction.
+For example:
+
+go run cmd/cli/main.go dbImportBooks --file catalogue.csv --dry-run`,
+	Run: func(cmd *cobra.Command, args []string) {
+		filePath, _ := cmd.Flags().GetString("file")
+		format, _ := cmd.Flags().GetString("format")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(filePath)), ".")
+		}
+
+		file, err := os.Open(filePath)
+		if err != nil {
+			fmt.Println("Failed to open import file:", err)
+			os.Exit(1)
+		}
+		defer file.Close()
+
+		cfg := config.InitConfig()
+		db := database.NewGormDB(cfg.PostgresDB)
+		defer db.Disconnect()
+		redis := database.NewRedisClient(cfg.Redis)
+
+		s3Repo := storage.NewS3Repo(storage.NewS3Client(cfg.AWS))
+		// the changes show up on the event streams of the running API instances
+		bookFeed := feed.New(cfg.Feed, redis, book.BookFeedName)
+		bookRepo := book.NewPublishingBookRepository(cfg, book.NewCachedBookRepository(cfg, book.NewBookRepository(cfg, db, redis), redis), bookFeed, s3Repo)
+		bookService := book.NewBookService(cfg, bookRepo, author.NewAuthorRepository(cfg, db), s3Repo,
+			catalogue.New(cfg.Catalogue, redis))
+
+		report, err := bookService.ImportBooks(nil, file, format, dryRun)
+		if err != nil {
+			fmt.Println("Import failed:", err)
+			os.Exit(1)
+		}
+
+		for _, row := range report.Rows {
+			if row.Status == book.ImportStatusFailed || row.Status == book.ImportStatusSkipped {
+				fmt.Printf("row %d: %s (%s)\n", row.Row, row.Status, row.Reason)
+			}
+		}
+		fmt.Printf("created: %d, updated: %d, skipped: %d, failed: %d, dry run: %t\n",
+			report.Created, report.Updated, report.Skipped, report.Failed, report.DryRun)
+
+		defer fmt.Println("RUN dbImportBooks Completed")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(dbImportBooksCmd)
+
+	dbImportBooksCmd.Flags().StringP("file", "f", "", "Path to the CSV or NDJSON file")
+	dbImportBooksCmd.Flags().String("format", "", "File format: csv or ndjson (default: file extension)")
+	dbImportBooksCmd.Flags().Bool("dry-run", false, "Validate and report without writing")
+	dbImportBooksCmd.MarkFlagRequired("file")
+}
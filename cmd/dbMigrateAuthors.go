@@ -0,0 +1,56 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/author"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+	"github.com/jerpsp/go-fiber-beginner/pkg/catalogue"
+	"github.com/jerpsp/go-fiber-beginner/pkg/database"
+	"github.com/jerpsp/go-fiber-beginner/pkg/feed"
+	"github.com/jerpsp/go-fiber-beginner/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+// dbMigrateAuthorsCmd represents the dbMigrateAuthors command
+var dbMigrateAuthorsCmd = &cobra.Command{
+	Use:   "dbMigrateAuthors",
+	Short: "Split legacy author strings into author records",
+	Long: `Split the free-text author of every book without author credits into author
+records ("Orwell, George" and "George Orwell" become one author) and credit them
+in order. Books that already have credits are left alone, so it is safe to re-run.
+For example:
+
+go run cmd/cli/main.go dbMigrateAuthors`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := config.InitConfig()
+		db := database.NewGormDB(cfg.PostgresDB)
+		defer db.Disconnect()
+		redis := database.NewRedisClient(cfg.Redis)
+
+		s3Repo := storage.NewS3Repo(storage.NewS3Client(cfg.AWS))
+		// the changes show up on the event streams of the running API instances
+		bookFeed := feed.New(cfg.Feed, redis, book.BookFeedName)
+		bookRepo := book.NewPublishingBookRepository(cfg, book.NewCachedBookRepository(cfg, book.NewBookRepository(cfg, db, redis), redis), bookFeed, s3Repo)
+		bookService := book.NewBookService(cfg, bookRepo, author.NewAuthorRepository(cfg, db), s3Repo,
+			catalogue.New(cfg.Catalogue, redis))
+
+		linked, err := bookService.MigrateLegacyAuthors(nil)
+		if err != nil {
+			fmt.Println("Author migration failed:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("books linked to authors: %d\n", linked)
+
+		defer fmt.Println("RUN dbMigrateAuthors Completed")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(dbMigrateAuthorsCmd)
+}
@@ -0,0 +1,48 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/recommendation"
+	"github.com/jerpsp/go-fiber-beginner/pkg/database"
+	"github.com/spf13/cobra"
+)
+
+// recommendationsRebuildCmd represents the recommendationsRebuild command
+var recommendationsRebuildCmd = &cobra.Command{
+	Use:   "recommendationsRebuild",
+	Short: "Recompute similar books and user recommendations",
+	Long: `Score every book against the books sharing an author, a category or a reader
+on reading lists and loans, keep the closest neighbours of each book and rank
+the unseen books for every user. The previous run is replaced in one go, so it
+is meant to run from a scheduler (nightly or more often) and is safe to re-run.
+For example:
+
+go run cmd/cli/main.go recommendationsRebuild`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := config.InitConfig()
+		db := database.NewGormDB(cfg.PostgresDB)
+		defer db.Disconnect()
+
+		recommendationService := recommendation.NewRecommendationService(cfg, recommendation.NewRecommendationRepository(cfg, db))
+
+		report, err := recommendationService.Rebuild(nil)
+		if err != nil {
+			fmt.Println("Rebuilding recommendations failed:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("books: %d, similarities: %d, users: %d, recommendations: %d\n",
+			report.Books, report.Similarities, report.Users, report.Recommendations)
+
+		defer fmt.Println("RUN recommendationsRebuild Completed")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(recommendationsRebuildCmd)
+}
@@ -0,0 +1,49 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/stats"
+	"github.com/jerpsp/go-fiber-beginner/pkg/activity"
+	"github.com/jerpsp/go-fiber-beginner/pkg/database"
+	"github.com/spf13/cobra"
+)
+
+// statsRollupCmd represents the statsRollup command
+var statsRollupCmd = &cobra.Command{
+	Use:   "statsRollup",
+	Short: "Roll up the book event counters from Redis to Postgres",
+	Long: `Copy the per day counts of book views, loans, list additions and reviews
+from Redis to the daily stats table that trending and the admin stats read.
+Counts only grow, so it is meant to run from a scheduler (hourly or more often,
+and at least once within ACTIVITY_COUNTER_TTL) and is safe to re-run.
+For example:
+
+go run cmd/cli/main.go statsRollup`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := config.InitConfig()
+		db := database.NewGormDB(cfg.PostgresDB)
+		defer db.Disconnect()
+		redis := database.NewRedisClient(cfg.Redis)
+
+		statsService := stats.NewStatsService(cfg, stats.NewStatsRepository(cfg, db), activity.New(cfg.Activity, redis))
+
+		report, err := statsService.Rollup(nil)
+		if err != nil {
+			fmt.Println("Rolling up stats failed:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("days: %d, rows: %d\n", report.Days, report.Rows)
+
+		defer fmt.Println("RUN statsRollup Completed")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statsRollupCmd)
+}
@@ -4,8 +4,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jerpsp/go-fiber-beginner/pkg/activity"
+	"github.com/jerpsp/go-fiber-beginner/pkg/catalogue"
 	"github.com/jerpsp/go-fiber-beginner/pkg/database"
 	"github.com/jerpsp/go-fiber-beginner/pkg/email"
+	"github.com/jerpsp/go-fiber-beginner/pkg/feed"
 	"github.com/jerpsp/go-fiber-beginner/pkg/storage"
 	"github.com/spf13/viper"
 )
@@ -24,13 +27,60 @@ type (
 		RefreshTokenExp time.Duration `mapstructure:"JWT_REFRESH_TOKEN_EXP" validate:"required"`
 	}
 
+	// Circulation holds the lending rules for each user role, loan periods are in days and fines in cents.
+	// Borrowers owing more than FineBlockCents can't check out.
+	Circulation struct {
+		UserLoanDays         int   `mapstructure:"CIRCULATION_USER_LOAN_DAYS"`
+		UserMaxRenewals      int   `mapstructure:"CIRCULATION_USER_MAX_RENEWALS"`
+		UserMaxLoans         int   `mapstructure:"CIRCULATION_USER_MAX_LOANS"`
+		ModeratorLoanDays    int   `mapstructure:"CIRCULATION_MODERATOR_LOAN_DAYS"`
+		ModeratorMaxRenewals int   `mapstructure:"CIRCULATION_MODERATOR_MAX_RENEWALS"`
+		ModeratorMaxLoans    int   `mapstructure:"CIRCULATION_MODERATOR_MAX_LOANS"`
+		AdminLoanDays        int   `mapstructure:"CIRCULATION_ADMIN_LOAN_DAYS"`
+		AdminMaxRenewals     int   `mapstructure:"CIRCULATION_ADMIN_MAX_RENEWALS"`
+		AdminMaxLoans        int   `mapstructure:"CIRCULATION_ADMIN_MAX_LOANS"`
+		HoldPickupDays       int   `mapstructure:"CIRCULATION_HOLD_PICKUP_DAYS"`
+		DueReminderDays      int   `mapstructure:"CIRCULATION_DUE_REMINDER_DAYS"`
+		FineDailyCents       int64 `mapstructure:"CIRCULATION_FINE_DAILY_CENTS"`
+		FineCapCents         int64 `mapstructure:"CIRCULATION_FINE_CAP_CENTS"`
+		FineBlockCents       int64 `mapstructure:"CIRCULATION_FINE_BLOCK_CENTS"`
+	}
+
+	// BookCache controls the Redis read-through cache in front of book reads, lists expire sooner than single books.
+	// Jitter is the fraction of the TTL randomly added to each entry.
+	BookCache struct {
+		Enabled     bool          `mapstructure:"BOOK_CACHE_ENABLED"`
+		BookTTL     time.Duration `mapstructure:"BOOK_CACHE_BOOK_TTL"`
+		ListTTL     time.Duration `mapstructure:"BOOK_CACHE_LIST_TTL"`
+		NegativeTTL time.Duration `mapstructure:"BOOK_CACHE_NEGATIVE_TTL"`
+		Jitter      float64       `mapstructure:"BOOK_CACHE_JITTER"`
+	}
+
+	// BookBatch limits how many operations one POST /books/batch call may carry
+	BookBatch struct {
+		MaxOperations int `mapstructure:"BOOK_BATCH_MAX_OPERATIONS"`
+	}
+
+	// BookFiles limits e-book uploads and sets how long a download link stays valid
+	BookFiles struct {
+		MaxSize     int64         `mapstructure:"BOOK_FILES_MAX_SIZE"`
+		DownloadTTL time.Duration `mapstructure:"BOOK_FILES_DOWNLOAD_TTL"`
+	}
+
 	Config struct {
-		Server     *Server                  `mapstructure:"server" validate:"required"`
-		PostgresDB *database.PostgresConfig `mapstructure:"postgresdb" validate:"required"`
-		JWT        *JWT                     `mapstructure:"jwt" validate:"required"`
-		Redis      *database.RedisConfig    `mapstructure:"redis" validate:"required"`
-		AWS        *storage.AWSConfig       `mapstructure:"aws" validate:"required"`
-		Email      *email.EmailConfig       `mapstructure:"email" validate:"required"`
+		Server      *Server                    `mapstructure:"server" validate:"required"`
+		PostgresDB  *database.PostgresConfig   `mapstructure:"postgresdb" validate:"required"`
+		JWT         *JWT                       `mapstructure:"jwt" validate:"required"`
+		Redis       *database.RedisConfig      `mapstructure:"redis" validate:"required"`
+		AWS         *storage.AWSConfig         `mapstructure:"aws" validate:"required"`
+		Email       *email.EmailConfig         `mapstructure:"email" validate:"required"`
+		Circulation *Circulation               `mapstructure:"circulation"`
+		Catalogue   *catalogue.CatalogueConfig `mapstructure:"catalogue"`
+		BookCache   *BookCache                 `mapstructure:"book_cache"`
+		BookBatch   *BookBatch                 `mapstructure:"book_batch"`
+		BookFiles   *BookFiles                 `mapstructure:"book_files"`
+		Activity    *activity.ActivityConfig   `mapstructure:"activity"`
+		Feed        *feed.FeedConfig           `mapstructure:"feed"`
 	}
 )
 
@@ -41,6 +91,13 @@ func InitConfig() *Config {
 	var redis database.RedisConfig
 	var aws storage.AWSConfig
 	var email email.EmailConfig
+	var circulation Circulation
+	var catalogueCfg catalogue.CatalogueConfig
+	var bookCache BookCache
+	var bookBatch BookBatch
+	var bookFiles BookFiles
+	var activityCfg activity.ActivityConfig
+	var feedCfg feed.FeedConfig
 
 	viper.SetConfigName("dev")
 	viper.SetConfigType("env")
@@ -48,6 +105,44 @@ func InitConfig() *Config {
 	viper.AutomaticEnv()
 	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 
+	// Lending rules fall back to these when the environment does not set them
+	viper.SetDefault("CIRCULATION_USER_LOAN_DAYS", 14)
+	viper.SetDefault("CIRCULATION_USER_MAX_RENEWALS", 2)
+	viper.SetDefault("CIRCULATION_USER_MAX_LOANS", 5)
+	viper.SetDefault("CIRCULATION_MODERATOR_LOAN_DAYS", 28)
+	viper.SetDefault("CIRCULATION_MODERATOR_MAX_RENEWALS", 3)
+	viper.SetDefault("CIRCULATION_MODERATOR_MAX_LOANS", 10)
+	viper.SetDefault("CIRCULATION_ADMIN_LOAN_DAYS", 28)
+	viper.SetDefault("CIRCULATION_ADMIN_MAX_RENEWALS", 3)
+	viper.SetDefault("CIRCULATION_ADMIN_MAX_LOANS", 10)
+	viper.SetDefault("CIRCULATION_HOLD_PICKUP_DAYS", 3)
+	viper.SetDefault("CIRCULATION_DUE_REMINDER_DAYS", 2)
+	viper.SetDefault("CIRCULATION_FINE_DAILY_CENTS", 25)
+	viper.SetDefault("CIRCULATION_FINE_CAP_CENTS", 1000)
+	viper.SetDefault("CIRCULATION_FINE_BLOCK_CENTS", 500)
+
+	// ISBN lookups go to Open Library unless another compatible catalogue is configured
+	viper.SetDefault("CATALOGUE_BASE_URL", "https://openlibrary.org")
+	viper.SetDefault("CATALOGUE_TIMEOUT", 5*time.Second)
+	viper.SetDefault("CATALOGUE_CACHE_TTL", 24*time.Hour)
+	viper.SetDefault("CATALOGUE_FAILURE_THRESHOLD", 5)
+	viper.SetDefault("CATALOGUE_OPEN_TIMEOUT", 30*time.Second)
+
+	// Book reads are cached unless an environment turns it off
+	viper.SetDefault("BOOK_CACHE_ENABLED", true)
+	viper.SetDefault("BOOK_CACHE_BOOK_TTL", 10*time.Minute)
+	viper.SetDefault("BOOK_CACHE_LIST_TTL", time.Minute)
+	viper.SetDefault("BOOK_CACHE_NEGATIVE_TTL", 30*time.Second)
+	viper.SetDefault("BOOK_CACHE_JITTER", 0.1)
+	viper.SetDefault("BOOK_BATCH_MAX_OPERATIONS", 100)
+	viper.SetDefault("BOOK_FILES_MAX_SIZE", 50*1024*1024)
+	viper.SetDefault("BOOK_FILES_DOWNLOAD_TTL", 5*time.Minute)
+	viper.SetDefault("ACTIVITY_COUNTER_TTL", 72*time.Hour)
+
+	// The book change feed keeps the last events for reconnecting clients and pings idle streams
+	viper.SetDefault("FEED_REPLAY_SIZE", 1000)
+	viper.SetDefault("FEED_HEARTBEAT", 15*time.Second)
+
 	// Try to read from config file, but don't panic if not found (for cloud environments)
 	if err := viper.ReadInConfig(); err != nil {
 		// Only panic if we're in development mode and config file is missing
@@ -80,13 +175,48 @@ func InitConfig() *Config {
 		panic(err)
 	}
 
+	if err := viper.Unmarshal(&circulation); err != nil {
+		panic(err)
+	}
+
+	if err := viper.Unmarshal(&catalogueCfg); err != nil {
+		panic(err)
+	}
+
+	if err := viper.Unmarshal(&bookCache); err != nil {
+		panic(err)
+	}
+
+	if err := viper.Unmarshal(&bookBatch); err != nil {
+		panic(err)
+	}
+
+	if err := viper.Unmarshal(&bookFiles); err != nil {
+		panic(err)
+	}
+
+	if err := viper.Unmarshal(&activityCfg); err != nil {
+		panic(err)
+	}
+
+	if err := viper.Unmarshal(&feedCfg); err != nil {
+		panic(err)
+	}
+
 	cfg := &Config{
-		Server:     &server,
-		PostgresDB: &postgresDB,
-		JWT:        &jwt,
-		Redis:      &redis,
-		AWS:        &aws,
-		Email:      &email,
+		Server:      &server,
+		PostgresDB:  &postgresDB,
+		JWT:         &jwt,
+		Redis:       &redis,
+		AWS:         &aws,
+		Email:       &email,
+		Circulation: &circulation,
+		Catalogue:   &catalogueCfg,
+		BookCache:   &bookCache,
+		BookBatch:   &bookBatch,
+		BookFiles:   &bookFiles,
+		Activity:    &activityCfg,
+		Feed:        &feedCfg,
 	}
 
 	return cfg
@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/user"
 	"github.com/jerpsp/go-fiber-beginner/pkg/database"
 	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/redis/go-redis/v9"
 )
 
 type AuthRepository interface {
@@ -19,6 +21,8 @@ type AuthRepository interface {
 	GetTokenByValue(c *fiber.Ctx, tokenStr string) (*Token, error)
 	DeleteToken(c *fiber.Ctx, tokenID uuid.UUID) error
 	DeleteUserTokens(c *fiber.Ctx, userID uuid.UUID, tokenType utils.TokenType) error
+	RevokeAccessTokens(c *fiber.Ctx, userID uuid.UUID) error
+	AccessTokensRevokedAt(ctx context.Context, userID uuid.UUID) (time.Time, error)
 	CreateUser(user *user.User) error
 }
 
@@ -146,6 +150,25 @@ func (r *authRepository) DeleteUserTokens(c *fiber.Ctx, userID uuid.UUID, tokenT
 	return r.redis.Client.Del(context.Background(), userTokenKey).Err()
 }
 
+// RevokeAccessTokens ends the access tokens issued to a user so far. The mark only has to outlive the longest
+// lived of them.
+func (r *authRepository) RevokeAccessTokens(c *fiber.Ctx, userID uuid.UUID) error {
+	revokedKey := fmt.Sprintf("access_revoked:%s", userID.String())
+	return r.redis.Client.Set(context.Background(), revokedKey, time.Now().Unix(), r.config.JWT.AccessTokenExp).Err()
+}
+
+func (r *authRepository) AccessTokensRevokedAt(ctx context.Context, userID uuid.UUID) (time.Time, error) {
+	revokedKey := fmt.Sprintf("access_revoked:%s", userID.String())
+	revokedAt, err := r.redis.Client.Get(ctx, revokedKey).Int64()
+	if errors.Is(err, redis.Nil) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(revokedAt, 0), nil
+}
+
 func (r *authRepository) CreateUser(user *user.User) error {
 	return r.db.DB.Create(user).Error
 }
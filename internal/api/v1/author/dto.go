@@ -0,0 +1,14 @@
+package author
+
+// AuthorRequest is the writable view of an author, dates are plain YYYY-MM-DD strings
+type AuthorRequest struct {
+	Name      string `json:"name" form:"name" validate:"required,max=255"`
+	SortName  string `json:"sort_name" form:"sort_name" validate:"omitempty,max=255"`
+	Bio       string `json:"bio" form:"bio" validate:"omitempty"`
+	BirthDate string `json:"birth_date" form:"birth_date" validate:"omitempty,datetime=2006-01-02"`
+	DeathDate string `json:"death_date" form:"death_date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type AuthorFilter struct {
+	Query string `query:"q"`
+}
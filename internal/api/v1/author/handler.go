@@ -0,0 +1,131 @@
+package author
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/pkg/patch"
+	"gorm.io/gorm"
+)
+
+type AuthorHandler struct {
+	config  *config.Config
+	service AuthorService
+}
+
+func NewAuthorHandler(config *config.Config, service AuthorService) *AuthorHandler {
+	return &AuthorHandler{config: config, service: service}
+}
+
+// Handler methods
+func (h *AuthorHandler) GetAuthors(c *fiber.Ctx) error {
+	var filter AuthorFilter
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid filter parameters"})
+	}
+
+	authors, err := h.service.GetAuthors(c, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"authors": authors})
+}
+
+func (h *AuthorHandler) GetAuthor(c *fiber.Ctx) error {
+	authorID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid author ID"})
+	}
+
+	author, err := h.service.GetAuthor(c, authorID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"author": author})
+}
+
+func (h *AuthorHandler) CreateAuthor(c *fiber.Ctx) error {
+	var authorParams AuthorRequest
+	if err := c.BodyParser(&authorParams); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid input"})
+	}
+
+	// the photo is optional, JSON requests simply have no multipart form
+	file, _ := c.FormFile("photo")
+
+	author, err := h.service.CreateAuthor(c, authorParams, file)
+	if err != nil {
+		if errors.Is(err, ErrInvalidAuthor) || errors.Is(err, ErrInvalidPhoto) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		}
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": "An author with this name already exists"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"author": author})
+}
+
+func (h *AuthorHandler) UpdateAuthor(c *fiber.Ctx) error {
+	authorID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid author ID"})
+	}
+
+	author, err := h.service.UpdateAuthor(c, authorID, c.Get(fiber.HeaderContentType), c.Body())
+	if err != nil {
+		switch {
+		case errors.Is(err, patch.ErrUnsupportedMediaType):
+			return c.Status(fiber.StatusUnsupportedMediaType).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, patch.ErrInvalidPatch), errors.Is(err, ErrInvalidAuthor):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, patch.ErrTestFailed):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, gorm.ErrDuplicatedKey):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": "An author with this name already exists"})
+		}
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"author": author})
+}
+
+func (h *AuthorHandler) UploadPhoto(c *fiber.Ctx) error {
+	authorID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid author ID"})
+	}
+
+	file, err := c.FormFile("photo")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Photo is required"})
+	}
+
+	author, err := h.service.UploadPhoto(c, authorID, file)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, ErrInvalidPhoto):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"author": author})
+}
+
+func (h *AuthorHandler) DeleteAuthor(c *fiber.Ctx) error {
+	authorID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid author ID"})
+	}
+
+	if err := h.service.DeleteAuthor(c, authorID); err != nil {
+		if errors.Is(err, ErrAuthorHasBooks) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,20 @@
+package author
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Author struct {
+	ID        uuid.UUID  `gorm:"type:uuid; default:uuid_generate_v4()" json:"id"`
+	Name      string     `gorm:"size:255;not null;uniqueIndex:idx_authors_name_lower,expression:lower(name)" json:"name"`
+	SortName  string     `gorm:"size:255;index" json:"sort_name"`
+	Bio       string     `gorm:"type:text" json:"bio"`
+	BirthDate *time.Time `gorm:"type:date" json:"birth_date"`
+	DeathDate *time.Time `gorm:"type:date" json:"death_date"`
+	PhotoKey  string     `gorm:"size:255" json:"-"`
+	PhotoURL  string     `gorm:"-" json:"photo_url,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
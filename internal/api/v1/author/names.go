@@ -0,0 +1,52 @@
+package author
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	nameSeparator = regexp.MustCompile(`(?i)\s*(?:;|&|\band\b)\s*`)
+
+	// surnamePrefixes stay with the surname when sorting, e.g. "Le Guin, Ursula K."
+	surnamePrefixes = map[string]bool{"da": true, "de": true, "del": true, "der": true, "di": true, "du": true, "la": true, "le": true, "van": true, "von": true}
+)
+
+// SortName turns "George Orwell" into "Orwell, George", single-word names are returned as they are
+func SortName(name string) string {
+	parts := strings.Fields(name)
+	if len(parts) < 2 {
+		return strings.Join(parts, " ")
+	}
+
+	surname := len(parts) - 1
+	for surname > 1 && surnamePrefixes[strings.ToLower(parts[surname-1])] {
+		surname--
+	}
+	return strings.Join(parts[surname:], " ") + ", " + strings.Join(parts[:surname], " ")
+}
+
+// SplitNames splits a free-text author credit such as "Orwell, George" or "Neil Gaiman & Terry Pratchett"
+// into display names. A single comma after a one-word surname is read as "Last, First", any other comma
+// separates authors.
+func SplitNames(credit string) []string {
+	var names []string
+	for _, part := range nameSeparator.Split(credit, -1) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		pieces := strings.Split(part, ",")
+		if len(pieces) == 2 && !strings.Contains(strings.TrimSpace(pieces[0]), " ") && strings.TrimSpace(pieces[1]) != "" {
+			names = append(names, strings.Join(strings.Fields(pieces[1]+" "+pieces[0]), " "))
+			continue
+		}
+		for _, piece := range pieces {
+			if piece = strings.Join(strings.Fields(piece), " "); piece != "" {
+				names = append(names, piece)
+			}
+		}
+	}
+	return names
+}
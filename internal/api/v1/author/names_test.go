@@ -0,0 +1,49 @@
+package author_test
+
+import (
+	"testing"
+
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/author"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type NamesSuite struct {
+	suite.Suite
+}
+
+func TestNamesSuite(t *testing.T) {
+	suite.Run(t, new(NamesSuite))
+}
+
+func (s *NamesSuite) TestSplitNames1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Split Inverted Name", 34)
+
+	s.Equal([]string{"George Orwell"}, author.SplitNames("Orwell, George"))
+}
+
+func (s *NamesSuite) TestSplitNames2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Split Co-Authors", 34)
+
+	s.Equal([]string{"Neil Gaiman", "Terry Pratchett"}, author.SplitNames("Neil Gaiman & Terry Pratchett"))
+	s.Equal([]string{"Neil Gaiman", "Terry Pratchett"}, author.SplitNames("Neil Gaiman and  Terry Pratchett"))
+	s.Equal([]string{"Brian Kernighan", "Dennis Ritchie", "Rob Pike"}, author.SplitNames("Brian Kernighan, Dennis Ritchie; Rob Pike"))
+}
+
+func (s *NamesSuite) TestSplitNames3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Split Empty Credit", 31)
+
+	s.Empty(author.SplitNames(" ; "))
+}
+
+func (s *NamesSuite) TestSortName1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Sort Name", 34)
+
+	s.Equal("Orwell, George", author.SortName("George Orwell"))
+	s.Equal("Le Guin, Ursula K.", author.SortName("Ursula K. Le Guin"))
+	s.Equal("Plato", author.SortName("Plato"))
+}
@@ -0,0 +1,128 @@
+package author
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/pkg/database"
+	"gorm.io/gorm"
+)
+
+type AuthorRepository interface {
+	FindAllAuthors(c *fiber.Ctx, filter AuthorFilter) ([]Author, error)
+	FindAuthorByID(c *fiber.Ctx, authorID uuid.UUID) (Author, error)
+	FindAuthorsByIDs(c *fiber.Ctx, authorIDs []uuid.UUID) ([]Author, error)
+	FindOrCreateAuthorByName(c *fiber.Ctx, name string) (Author, error)
+	CreateAuthor(c *fiber.Ctx, newAuthor Author) (Author, error)
+	UpdateAuthorFields(c *fiber.Ctx, authorID uuid.UUID, fields map[string]interface{}) error
+	CountAuthorBooks(c *fiber.Ctx, authorID uuid.UUID) (int64, error)
+	FindAuthorBookIDs(c *fiber.Ctx, authorID uuid.UUID) ([]uuid.UUID, error)
+	DeleteAuthor(c *fiber.Ctx, authorID uuid.UUID) error
+}
+
+type authorRepository struct {
+	config *config.Config
+	db     *database.GormDB
+}
+
+func NewAuthorRepository(cfg *config.Config, db *database.GormDB) AuthorRepository {
+	return &authorRepository{config: cfg, db: db}
+}
+
+// Repository methods
+
+func (r *authorRepository) FindAllAuthors(c *fiber.Ctx, filter AuthorFilter) ([]Author, error) {
+	var authors []Author
+	query := r.db.DB.Order("sort_name")
+	if filter.Query != "" {
+		query = query.Where("name ILIKE ?", "%"+strings.TrimSpace(filter.Query)+"%")
+	}
+	if err := query.Find(&authors).Error; err != nil {
+		return nil, err
+	}
+	return authors, nil
+}
+
+func (r *authorRepository) FindAuthorByID(c *fiber.Ctx, authorID uuid.UUID) (Author, error) {
+	var author Author
+	if err := r.db.DB.Where("id = ?", authorID).First(&author).Error; err != nil {
+		return Author{}, err
+	}
+	return author, nil
+}
+
+func (r *authorRepository) FindAuthorsByIDs(c *fiber.Ctx, authorIDs []uuid.UUID) ([]Author, error) {
+	var authors []Author
+	if len(authorIDs) == 0 {
+		return authors, nil
+	}
+	if err := r.db.DB.Where("id IN ?", authorIDs).Find(&authors).Error; err != nil {
+		return nil, err
+	}
+	return authors, nil
+}
+
+// FindOrCreateAuthorByName matches names case-insensitively so repeated credits resolve to one author
+func (r *authorRepository) FindOrCreateAuthorByName(c *fiber.Ctx, name string) (Author, error) {
+	var author Author
+	err := r.db.DB.Where("LOWER(name) = LOWER(?)", name).
+		Attrs(Author{Name: name, SortName: SortName(name)}).
+		FirstOrCreate(&author).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		// a concurrent credit created the author between the lookup and the insert
+		err = r.db.DB.Where("LOWER(name) = LOWER(?)", name).First(&author).Error
+	}
+	if err != nil {
+		return Author{}, err
+	}
+	return author, nil
+}
+
+func (r *authorRepository) CreateAuthor(c *fiber.Ctx, newAuthor Author) (Author, error) {
+	if err := r.db.DB.Create(&newAuthor).Error; err != nil {
+		return Author{}, err
+	}
+	return newAuthor, nil
+}
+
+func (r *authorRepository) UpdateAuthorFields(c *fiber.Ctx, authorID uuid.UUID, fields map[string]interface{}) error {
+	result := r.db.DB.Model(&Author{}).Where("id = ?", authorID).Updates(fields)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *authorRepository) CountAuthorBooks(c *fiber.Ctx, authorID uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.db.DB.Table("book_authors").Where("author_id = ?", authorID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// FindAuthorBookIDs lists the books that credit the author
+func (r *authorRepository) FindAuthorBookIDs(c *fiber.Ctx, authorID uuid.UUID) ([]uuid.UUID, error) {
+	var bookIDs []uuid.UUID
+	if err := r.db.DB.Table("book_authors").Where("author_id = ?", authorID).Pluck("book_id", &bookIDs).Error; err != nil {
+		return nil, err
+	}
+	return bookIDs, nil
+}
+
+func (r *authorRepository) DeleteAuthor(c *fiber.Ctx, authorID uuid.UUID) error {
+	result := r.db.DB.Delete(&Author{}, "id = ?", authorID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package author
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/middleware"
+)
+
+func RegisterRoutes(cfg *config.Config, router fiber.Router, handler *AuthorHandler) {
+	authorGroup := router.Group("/authors")
+	{
+		// Public routes - anyone can access
+		authorGroup.Get("", handler.GetAuthors)
+		authorGroup.Get("/:id", handler.GetAuthor)
+
+		// Moderator or Admin routes - only moderators and admins can curate authors
+		authorGroup.Post("", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.CreateAuthor)
+		authorGroup.Patch("/:id", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.UpdateAuthor)
+		authorGroup.Put("/:id/photo", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.UploadPhoto)
+
+		// Admin only routes - only admins can delete authors
+		authorGroup.Delete("/:id", middleware.JWTMiddleware(cfg), middleware.AdminOnly(), handler.DeleteAuthor)
+	}
+}
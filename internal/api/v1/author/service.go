@@ -0,0 +1,255 @@
+package author
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/pkg/patch"
+	"github.com/jerpsp/go-fiber-beginner/pkg/storage"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+)
+
+const (
+	dateLayout   = "2006-01-02"
+	maxPhotoSize = 1 * 1024 * 1024
+)
+
+var (
+	ErrInvalidAuthor  = errors.New("invalid author")
+	ErrInvalidPhoto   = errors.New("invalid author photo")
+	ErrAuthorHasBooks = errors.New("author is still credited on books")
+)
+
+// Setup
+type AuthorService interface {
+	GetAuthors(c *fiber.Ctx, filter AuthorFilter) ([]Author, error)
+	GetAuthor(c *fiber.Ctx, authorID uuid.UUID) (Author, error)
+	CreateAuthor(c *fiber.Ctx, authorParams AuthorRequest, file *multipart.FileHeader) (Author, error)
+	UpdateAuthor(c *fiber.Ctx, authorID uuid.UUID, contentType string, body []byte) (Author, error)
+	UploadPhoto(c *fiber.Ctx, authorID uuid.UUID, file *multipart.FileHeader) (Author, error)
+	DeleteAuthor(c *fiber.Ctx, authorID uuid.UUID) error
+}
+
+// BookCache drops cached copies of books, it is implemented by the book package which embeds its credits in its books
+type BookCache interface {
+	InvalidateBooks(c *fiber.Ctx, bookIDs ...uuid.UUID)
+}
+
+type authorService struct {
+	config    *config.Config
+	repo      AuthorRepository
+	s3Repo    storage.S3Repository
+	bookCache BookCache
+}
+
+func NewAuthorService(config *config.Config, repo AuthorRepository, s3Repo storage.S3Repository, bookCache BookCache) AuthorService {
+	return &authorService{config: config, repo: repo, s3Repo: s3Repo, bookCache: bookCache}
+}
+
+// Service methods
+func (s *authorService) GetAuthors(c *fiber.Ctx, filter AuthorFilter) ([]Author, error) {
+	authors, err := s.repo.FindAllAuthors(c, filter)
+	if err != nil {
+		return nil, err
+	}
+	for i := range authors {
+		authors[i] = s.withPhoto(authors[i])
+	}
+	return authors, nil
+}
+
+func (s *authorService) GetAuthor(c *fiber.Ctx, authorID uuid.UUID) (Author, error) {
+	author, err := s.repo.FindAuthorByID(c, authorID)
+	if err != nil {
+		return Author{}, err
+	}
+	return s.withPhoto(author), nil
+}
+
+func (s *authorService) CreateAuthor(c *fiber.Ctx, authorParams AuthorRequest, file *multipart.FileHeader) (Author, error) {
+	authorParams.normalize()
+	if err := authorParams.validate(); err != nil {
+		return Author{}, err
+	}
+
+	newAuthor := Author{Name: authorParams.Name, SortName: authorParams.SortName, Bio: authorParams.Bio}
+	newAuthor.BirthDate, _ = parseDate(authorParams.BirthDate)
+	newAuthor.DeathDate, _ = parseDate(authorParams.DeathDate)
+
+	if file != nil {
+		photoKey, err := s.uploadPhoto(file)
+		if err != nil {
+			return Author{}, err
+		}
+		newAuthor.PhotoKey = photoKey
+	}
+
+	createdAuthor, err := s.repo.CreateAuthor(c, newAuthor)
+	if err != nil {
+		return Author{}, err
+	}
+	return s.withPhoto(createdAuthor), nil
+}
+
+// UpdateAuthor applies a merge patch or JSON patch and writes only the columns it changed
+func (s *authorService) UpdateAuthor(c *fiber.Ctx, authorID uuid.UUID, contentType string, body []byte) (Author, error) {
+	author, err := s.repo.FindAuthorByID(c, authorID)
+	if err != nil {
+		return Author{}, err
+	}
+
+	current := newAuthorRequest(author)
+	patched := current
+	if err := patch.Apply(&patched, contentType, body); err != nil {
+		return Author{}, err
+	}
+	patched.normalize()
+	if err := patched.validate(); err != nil {
+		return Author{}, err
+	}
+
+	changes := patch.Changes(current, patched)
+	for _, column := range []string{"birth_date", "death_date"} {
+		if value, ok := changes[column]; ok {
+			changes[column], _ = parseDate(value.(string))
+		}
+	}
+	if len(changes) > 0 {
+		if err := s.repo.UpdateAuthorFields(c, authorID, changes); err != nil {
+			return Author{}, err
+		}
+		// deleting an author is refused while it is credited, so only renames reach cached books
+		if bookIDs, err := s.repo.FindAuthorBookIDs(c, authorID); err != nil {
+			log.Errorf("failed to find the books of the author: %v", err)
+		} else {
+			s.bookCache.InvalidateBooks(c, bookIDs...)
+		}
+	}
+
+	return s.GetAuthor(c, authorID)
+}
+
+func (s *authorService) UploadPhoto(c *fiber.Ctx, authorID uuid.UUID, file *multipart.FileHeader) (Author, error) {
+	author, err := s.repo.FindAuthorByID(c, authorID)
+	if err != nil {
+		return Author{}, err
+	}
+
+	photoKey, err := s.uploadPhoto(file)
+	if err != nil {
+		return Author{}, err
+	}
+	if err := s.repo.UpdateAuthorFields(c, authorID, map[string]interface{}{"photo_key": photoKey}); err != nil {
+		return Author{}, err
+	}
+
+	// the old photo is only removed once nothing points at it anymore
+	if author.PhotoKey != "" {
+		if err := s.s3Repo.DeletePublicFile(author.PhotoKey); err != nil {
+			log.Errorf("failed to delete old author photo: %v", err)
+		}
+	}
+
+	author.PhotoKey = photoKey
+	return s.withPhoto(author), nil
+}
+
+func (s *authorService) DeleteAuthor(c *fiber.Ctx, authorID uuid.UUID) error {
+	books, err := s.repo.CountAuthorBooks(c, authorID)
+	if err != nil {
+		return err
+	}
+	if books > 0 {
+		return fmt.Errorf("%w: %d books", ErrAuthorHasBooks, books)
+	}
+
+	author, err := s.repo.FindAuthorByID(c, authorID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteAuthor(c, authorID); err != nil {
+		return err
+	}
+	if author.PhotoKey != "" {
+		if err := s.s3Repo.DeletePublicFile(author.PhotoKey); err != nil {
+			log.Errorf("failed to delete author photo: %v", err)
+		}
+	}
+	return nil
+}
+
+func (s *authorService) uploadPhoto(file *multipart.FileHeader) (string, error) {
+	fileExt := strings.ToLower(path.Ext(file.Filename))
+	if !slices.Contains([]string{".png", ".jpg", ".jpeg", ".webp"}, fileExt) {
+		return "", fmt.Errorf("%w: invalid file format: %s", ErrInvalidPhoto, fileExt)
+	}
+	if file.Size > maxPhotoSize {
+		return "", fmt.Errorf("%w: file size exceeds limit: %d", ErrInvalidPhoto, file.Size)
+	}
+	return s.s3Repo.UploadPublicFile(file)
+}
+
+func (s *authorService) withPhoto(author Author) Author {
+	if author.PhotoKey != "" {
+		author.PhotoURL = s.s3Repo.GetPublicURLFile(author.PhotoKey)
+	}
+	return author
+}
+
+func newAuthorRequest(author Author) AuthorRequest {
+	return AuthorRequest{
+		Name:      author.Name,
+		SortName:  author.SortName,
+		Bio:       author.Bio,
+		BirthDate: formatDate(author.BirthDate),
+		DeathDate: formatDate(author.DeathDate),
+	}
+}
+
+// normalize trims the request and derives a sort name when none is given
+func (r *AuthorRequest) normalize() {
+	r.Name = strings.Join(strings.Fields(r.Name), " ")
+	r.SortName = strings.TrimSpace(r.SortName)
+	r.Bio = strings.TrimSpace(r.Bio)
+	if r.SortName == "" {
+		r.SortName = SortName(r.Name)
+	}
+}
+
+func (r *AuthorRequest) validate() error {
+	if err := utils.Validate(r); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAuthor, err)
+	}
+	if r.BirthDate != "" && r.DeathDate != "" && r.DeathDate < r.BirthDate {
+		return fmt.Errorf("%w: death_date is before birth_date", ErrInvalidAuthor)
+	}
+	return nil
+}
+
+// parseDate expects an already validated YYYY-MM-DD string, an empty string clears the date
+func parseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
+
+func formatDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format(dateLayout)
+}
@@ -0,0 +1,91 @@
+package book
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/author"
+)
+
+const legacyAuthorBatchSize = 200
+
+var (
+	ErrInvalidAuthors = errors.New("invalid book authors")
+)
+
+// byline renders the credited authors as the display string kept in Book.Author
+func byline(credits []BookAuthor) string {
+	var names []string
+	for _, credit := range credits {
+		if credit.Role == BookAuthorRoleAuthor {
+			names = append(names, credit.Author.Name)
+		}
+	}
+	return strings.Join(names, " & ")
+}
+
+// creditsFromRequest resolves the requested author IDs, every author must exist and appear once
+func creditsFromRequest(c *fiber.Ctx, authorRepo author.AuthorRepository, request BookAuthorsRequest) ([]BookAuthor, error) {
+	authorIDs := make([]uuid.UUID, 0, len(request.Authors))
+	seen := map[uuid.UUID]bool{}
+	for _, credit := range request.Authors {
+		if seen[credit.AuthorID] {
+			return nil, fmt.Errorf("%w: author %s is listed twice", ErrInvalidAuthors, credit.AuthorID)
+		}
+		seen[credit.AuthorID] = true
+		authorIDs = append(authorIDs, credit.AuthorID)
+	}
+
+	authors, err := authorRepo.FindAuthorsByIDs(c, authorIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]author.Author, len(authors))
+	for _, a := range authors {
+		byID[a.ID] = a
+	}
+
+	credits := make([]BookAuthor, 0, len(request.Authors))
+	for i, credit := range request.Authors {
+		a, ok := byID[credit.AuthorID]
+		if !ok {
+			return nil, fmt.Errorf("%w: author %s not found", ErrInvalidAuthors, credit.AuthorID)
+		}
+		role := BookAuthorRole(credit.Role)
+		if role == "" {
+			role = BookAuthorRoleAuthor
+		}
+		credits = append(credits, BookAuthor{AuthorID: a.ID, Position: i, Role: role, Author: a})
+	}
+	return credits, nil
+}
+
+// linkLegacyAuthors splits the free-text author string of a book into author records and credits them in order
+func linkLegacyAuthors(c *fiber.Ctx, repo BookRepository, authorRepo author.AuthorRepository, book Book) (bool, error) {
+	names := author.SplitNames(book.Author)
+	if len(names) == 0 {
+		return false, nil
+	}
+
+	credits := make([]BookAuthor, 0, len(names))
+	seen := map[uuid.UUID]bool{}
+	for _, name := range names {
+		a, err := authorRepo.FindOrCreateAuthorByName(c, name)
+		if err != nil {
+			return false, err
+		}
+		if seen[a.ID] {
+			continue
+		}
+		seen[a.ID] = true
+		credits = append(credits, BookAuthor{AuthorID: a.ID, Position: len(credits), Role: BookAuthorRoleAuthor})
+	}
+
+	if err := repo.ReplaceBookAuthors(c, book.ID, credits); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,85 @@
+package book
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jerpsp/go-fiber-beginner/middleware"
+	"github.com/jerpsp/go-fiber-beginner/pkg/patch"
+	"gorm.io/gorm"
+)
+
+const (
+	BatchOpCreate = "create"
+	BatchOpPatch  = "patch"
+	BatchOpDelete = "delete"
+)
+
+var (
+	ErrInvalidBatch = errors.New("invalid batch")
+	// errBatchFailed aborts an atomic batch transaction, the failing item carries the actual error
+	errBatchFailed = errors.New("batch operation failed")
+)
+
+// batchAllowed mirrors the role rules of the single book routes in RegisterRoutes
+func batchAllowed(role string, op string) bool {
+	switch op {
+	case BatchOpCreate:
+		return role != ""
+	case BatchOpPatch:
+		return middleware.UserRole(role) == middleware.RoleAdmin || middleware.UserRole(role) == middleware.RoleModerator
+	case BatchOpDelete:
+		return middleware.UserRole(role) == middleware.RoleAdmin
+	}
+	return false
+}
+
+// batchErrorStatus maps the error of one batch item to the status the single book route would answer with
+func batchErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, patch.ErrUnsupportedMediaType):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, patch.ErrInvalidPatch), errors.Is(err, ErrInvalidBook):
+		return http.StatusBadRequest
+	case errors.Is(err, patch.ErrTestFailed), errors.Is(err, ErrVersionConflict), errors.Is(err, ErrBookHasCopies):
+		return http.StatusConflict
+	case errors.Is(err, ErrPreconditionFailed):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+// newBatchResponse counts the item results, a batch only counts as committed when nothing was rolled back
+func newBatchResponse(atomic bool, results []BatchResult) BatchResponse {
+	response := BatchResponse{Atomic: atomic, Committed: true, Results: results}
+	for _, result := range results {
+		if result.Error != "" {
+			response.Failed++
+		} else {
+			response.Succeeded++
+		}
+	}
+	if atomic && response.Failed > 0 {
+		response.Committed = false
+	}
+	return response
+}
+
+// rollBackResults marks every item of a failed atomic batch that did not fail itself
+func rollBackResults(results []BatchResult, operations []BatchOperation) {
+	for i := range results {
+		switch {
+		case results[i].Error != "":
+			continue
+		case results[i].Status == 0:
+			results[i] = BatchResult{Index: i, Op: operations[i].Op, ID: operations[i].ID, Error: "not attempted"}
+		default:
+			results[i].Book = nil
+			results[i].Error = "rolled back"
+		}
+		results[i].Status = fiber.StatusFailedDependency
+	}
+}
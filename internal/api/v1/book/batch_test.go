@@ -0,0 +1,183 @@
+package book_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+	"github.com/jerpsp/go-fiber-beginner/middleware"
+	"github.com/jerpsp/go-fiber-beginner/mocks"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type BatchSuite struct {
+	suite.Suite
+	mockRepo    *mocks.BookRepository
+	mockStorage *mocks.S3Repository
+	service     book.BookService
+}
+
+func TestBatchSuite(t *testing.T) {
+	suite.Run(t, new(BatchSuite))
+}
+
+func (s *BatchSuite) SetupTest() {
+	s.mockRepo = mocks.NewBookRepository(s.T())
+	cfg := &config.Config{BookBatch: &config.BookBatch{MaxOperations: 3}}
+	s.mockStorage = mocks.NewS3Repository(s.T())
+	s.service = book.NewBookService(cfg, s.mockRepo, mocks.NewAuthorRepository(s.T()), s.mockStorage, nil)
+
+	s.mockRepo.EXPECT().Transaction(mock.Anything, mock.Anything).RunAndReturn(func(c *fiber.Ctx, fn func(txRepo book.BookRepository) error) error {
+		return fn(s.mockRepo)
+	}).Maybe()
+}
+
+// expectDelete sets up an unconditional delete of a book with one e-book
+func (s *BatchSuite) expectDelete(bookID uuid.UUID, err error) {
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, bookID).Return(book.Book{ID: bookID, Version: 1}, nil)
+	s.mockRepo.EXPECT().FindBookFiles(mock.Anything, bookID).Return([]book.BookFile{{BookID: bookID, ObjectKey: bookID.String() + ".epub"}}, nil)
+	s.mockRepo.EXPECT().DeleteBook(mock.Anything, bookID, 0).Return(err)
+}
+
+func (s *BatchSuite) TestBatchBooks1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Batch Operations Follow The Route Roles", 34)
+
+	// Setup Mock
+	bookID := uuid.New()
+	// an allowed create fails on its body, an allowed patch on its If-Match, so neither reaches a write
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, bookID).Return(book.Book{ID: bookID, Version: 1}, nil).Maybe()
+	s.mockRepo.EXPECT().FindBookFiles(mock.Anything, bookID).Return(nil, nil).Maybe()
+	s.mockRepo.EXPECT().DeleteBook(mock.Anything, bookID, 0).Return(nil).Maybe()
+
+	operations := map[string]book.BatchOperation{
+		book.BatchOpCreate: {Op: book.BatchOpCreate, Body: json.RawMessage(`[]`)},
+		book.BatchOpPatch:  {Op: book.BatchOpPatch, ID: &bookID, Body: json.RawMessage(`{"title":"Dune"}`), IfMatch: `"2"`},
+		book.BatchOpDelete: {Op: book.BatchOpDelete, ID: &bookID},
+	}
+	tests := []struct {
+		role   string
+		op     string
+		status int
+	}{
+		{"", book.BatchOpCreate, fiber.StatusForbidden},
+		{string(middleware.RoleUser), book.BatchOpCreate, fiber.StatusBadRequest},
+		{string(middleware.RoleModerator), book.BatchOpCreate, fiber.StatusBadRequest},
+		{string(middleware.RoleAdmin), book.BatchOpCreate, fiber.StatusBadRequest},
+		{"", book.BatchOpPatch, fiber.StatusForbidden},
+		{string(middleware.RoleUser), book.BatchOpPatch, fiber.StatusForbidden},
+		{string(middleware.RoleModerator), book.BatchOpPatch, fiber.StatusPreconditionFailed},
+		{string(middleware.RoleAdmin), book.BatchOpPatch, fiber.StatusPreconditionFailed},
+		{"", book.BatchOpDelete, fiber.StatusForbidden},
+		{string(middleware.RoleUser), book.BatchOpDelete, fiber.StatusForbidden},
+		{string(middleware.RoleModerator), book.BatchOpDelete, fiber.StatusForbidden},
+		{string(middleware.RoleAdmin), book.BatchOpDelete, fiber.StatusNoContent},
+	}
+
+	for _, test := range tests {
+		// Call the service method
+		request := book.BatchRequest{Operations: []book.BatchOperation{operations[test.op]}}
+		response, err := s.service.BatchBooks(&fiber.Ctx{}, request, test.role)
+
+		// Assertions
+		s.NoError(err)
+		s.Equal(test.status, response.Results[0].Status, "%s as %q", test.op, test.role)
+	}
+}
+
+func (s *BatchSuite) TestBatchBooks2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Atomic Batch Rolls Back On Failure", 31)
+
+	// Setup Mock
+	// the rolled back delete leaves the e-book in storage, the S3 mock has no expectations
+	deletedID, keptID, skippedID := uuid.New(), uuid.New(), uuid.New()
+	s.expectDelete(deletedID, nil)
+	s.expectDelete(keptID, book.ErrBookHasCopies)
+
+	// Call the service method
+	response, err := s.service.BatchBooks(&fiber.Ctx{}, book.BatchRequest{Atomic: true, Operations: []book.BatchOperation{
+		{Op: book.BatchOpDelete, ID: &deletedID},
+		{Op: book.BatchOpDelete, ID: &keptID},
+		{Op: book.BatchOpDelete, ID: &skippedID},
+	}}, string(middleware.RoleAdmin))
+
+	// Assertions
+	s.NoError(err)
+	s.False(response.Committed)
+	s.Equal(0, response.Succeeded)
+	s.Equal(3, response.Failed)
+	s.Equal(fiber.StatusFailedDependency, response.Results[0].Status)
+	s.Equal("rolled back", response.Results[0].Error)
+	s.Equal(fiber.StatusConflict, response.Results[1].Status)
+	s.Equal(fiber.StatusFailedDependency, response.Results[2].Status)
+	s.Equal("not attempted", response.Results[2].Error)
+}
+
+func (s *BatchSuite) TestBatchBooks3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Non-Atomic Batch Keeps Going After A Failure", 34)
+
+	// Setup Mock
+	keptID, deletedID := uuid.New(), uuid.New()
+	s.expectDelete(keptID, book.ErrBookHasCopies)
+	s.expectDelete(deletedID, nil)
+	s.mockStorage.EXPECT().DeletePrivateFile(deletedID.String() + ".epub").Return(nil)
+
+	// Call the service method
+	response, err := s.service.BatchBooks(&fiber.Ctx{}, book.BatchRequest{Operations: []book.BatchOperation{
+		{Op: book.BatchOpDelete, ID: &keptID},
+		{Op: book.BatchOpDelete, ID: &deletedID},
+	}}, string(middleware.RoleAdmin))
+
+	// Assertions
+	s.NoError(err)
+	s.True(response.Committed)
+	s.Equal(1, response.Succeeded)
+	s.Equal(1, response.Failed)
+	s.Equal(fiber.StatusConflict, response.Results[0].Status)
+	s.Equal(fiber.StatusNoContent, response.Results[1].Status)
+}
+
+func (s *BatchSuite) TestBatchBooks4() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Batch Over The Operation Limit", 31)
+
+	tests := []struct {
+		count int
+		err   bool
+	}{
+		{0, true},
+		{3, false},
+		{4, true},
+	}
+
+	for _, test := range tests {
+		// Setup Mock
+		operations := make([]book.BatchOperation, test.count)
+		for i := range operations {
+			bookID := uuid.New()
+			operations[i] = book.BatchOperation{Op: book.BatchOpDelete, ID: &bookID}
+			if !test.err {
+				s.expectDelete(bookID, nil)
+				s.mockStorage.EXPECT().DeletePrivateFile(bookID.String() + ".epub").Return(nil)
+			}
+		}
+
+		// Call the service method
+		response, err := s.service.BatchBooks(&fiber.Ctx{}, book.BatchRequest{Operations: operations}, string(middleware.RoleAdmin))
+
+		// Assertions
+		if test.err {
+			s.ErrorIs(err, book.ErrInvalidBatch, "%d operations", test.count)
+			continue
+		}
+		s.NoError(err)
+		s.Equal(test.count, response.Succeeded)
+	}
+}
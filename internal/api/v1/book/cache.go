@@ -0,0 +1,390 @@
+package book
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/pkg/database"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
+)
+
+// bookCacheListGenKey numbers the list generations, every invalidation moves it on
+const bookCacheListGenKey = "book_cache:list:gen"
+
+// bookCacheStats is published under /debug/vars as "book_cache"
+var bookCacheStats = expvar.NewMap("book_cache")
+
+// bookCacheEntry is what is stored per book, a nil Book records that the ID does not exist
+type bookCacheEntry struct {
+	Book *Book
+}
+
+// cachedBookRepository reads books through Redis and drops the cached copies when the book package writes them.
+// Packages whose rows are embedded in books, like authors and review ratings, drop them through a BookCacheInvalidator.
+type cachedBookRepository struct {
+	BookRepository
+	config *config.BookCache
+	redis  *database.RedisDB
+	group  *singleflight.Group
+	// pending collects the books written inside a transaction, they are invalidated after the commit
+	pending *bookInvalidation
+}
+
+type bookInvalidation struct {
+	bookIDs []uuid.UUID
+	dirty   bool
+}
+
+// NewCachedBookRepository wraps repo with the read-through cache, or returns it as is when the cache is disabled
+func NewCachedBookRepository(cfg *config.Config, repo BookRepository, redis *database.RedisDB) BookRepository {
+	if cfg.BookCache == nil || !cfg.BookCache.Enabled {
+		return repo
+	}
+	return &cachedBookRepository{BookRepository: repo, config: cfg.BookCache, redis: redis, group: &singleflight.Group{}}
+}
+
+func bookCacheKey(bookID uuid.UUID) string {
+	return fmt.Sprintf("book_cache:book:%s", bookID.String())
+}
+
+func bookListCacheKey(gen int64, filter BookFilter) (string, error) {
+	data, err := json.Marshal(filter)
+	if err != nil {
+		return "", err
+	}
+	sum := sha1.Sum(data)
+	return fmt.Sprintf("book_cache:list:%d:%s", gen, hex.EncodeToString(sum[:])), nil
+}
+
+func (r *cachedBookRepository) FindBookByID(c *fiber.Ctx, bookID uuid.UUID) (Book, error) {
+	if r.pending != nil {
+		return r.BookRepository.FindBookByID(c, bookID)
+	}
+
+	key := bookCacheKey(bookID)
+	var entry bookCacheEntry
+	if r.get(key, &entry) {
+		if entry.Book == nil {
+			bookCacheStats.Add("negative_hits", 1)
+			return Book{}, gorm.ErrRecordNotFound
+		}
+		bookCacheStats.Add("hits", 1)
+		return *entry.Book, nil
+	}
+	bookCacheStats.Add("misses", 1)
+
+	// concurrent misses for the same book share one query
+	result, err, _ := r.group.Do(key, func() (interface{}, error) {
+		gen, genErr := r.generation()
+		book, err := r.BookRepository.FindBookByID(c, bookID)
+		if genErr != nil {
+			// without the generation a write racing the load can't be seen, so nothing is cached
+			return book, err
+		}
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			r.setUnlessInvalidated(key, bookCacheEntry{}, r.config.NegativeTTL, gen)
+		case err == nil:
+			r.setUnlessInvalidated(key, bookCacheEntry{Book: &book}, r.config.BookTTL, gen)
+		}
+		return book, err
+	})
+	if err != nil {
+		return Book{}, err
+	}
+	return result.(Book), nil
+}
+
+// FindAllBooks caches each filter under a generation number, any write moves to a new generation
+// so stale lists are never read again and simply expire
+func (r *cachedBookRepository) FindAllBooks(c *fiber.Ctx, filter BookFilter) ([]Book, error) {
+	if r.pending != nil {
+		return r.BookRepository.FindAllBooks(c, filter)
+	}
+
+	gen, err := r.generation()
+	if err != nil {
+		return r.BookRepository.FindAllBooks(c, filter)
+	}
+	key, err := bookListCacheKey(gen, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var books []Book
+	if r.get(key, &books) {
+		bookCacheStats.Add("hits", 1)
+		// gob decodes an empty list as nil
+		if books == nil {
+			books = []Book{}
+		}
+		return books, nil
+	}
+	bookCacheStats.Add("misses", 1)
+
+	// a write that commits during the load moves to a new generation, so a stale list is stored under a key
+	// no one reads again
+	result, err, _ := r.group.Do(key, func() (interface{}, error) {
+		books, err := r.BookRepository.FindAllBooks(c, filter)
+		if err == nil {
+			r.set(key, books, r.config.ListTTL)
+		}
+		return books, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	// callers decorate the books in place, so a shared result is copied
+	return slices.Clone(result.([]Book)), nil
+}
+
+func (r *cachedBookRepository) CreateBook(c *fiber.Ctx, newBook Book) (Book, error) {
+	book, err := r.BookRepository.CreateBook(c, newBook)
+	if err == nil {
+		r.invalidate(book.ID)
+	}
+	return book, err
+}
+
+func (r *cachedBookRepository) UpdateBook(c *fiber.Ctx, updatedBook Book) (Book, error) {
+	book, err := r.BookRepository.UpdateBook(c, updatedBook)
+	if err == nil {
+		r.invalidate(updatedBook.ID)
+	}
+	return book, err
+}
+
+func (r *cachedBookRepository) PatchBook(c *fiber.Ctx, book Book, fields map[string]interface{}) (Book, error) {
+	updatedBook, err := r.BookRepository.PatchBook(c, book, fields)
+	if err == nil {
+		r.invalidate(book.ID)
+	}
+	return updatedBook, err
+}
+
+func (r *cachedBookRepository) RevertBook(c *fiber.Ctx, book Book, revision BookRevision) (Book, error) {
+	updatedBook, err := r.BookRepository.RevertBook(c, book, revision)
+	if err == nil {
+		r.invalidate(book.ID)
+	}
+	return updatedBook, err
+}
+
+func (r *cachedBookRepository) DeleteBook(c *fiber.Ctx, bookID uuid.UUID, version int) error {
+	err := r.BookRepository.DeleteBook(c, bookID, version)
+	if err == nil {
+		r.invalidate(bookID)
+	}
+	return err
+}
+
+func (r *cachedBookRepository) CreateBooks(c *fiber.Ctx, newBooks []Book, batchSize int) error {
+	err := r.BookRepository.CreateBooks(c, newBooks, batchSize)
+	if err == nil {
+		// new IDs may have been cached as missing
+		bookIDs := make([]uuid.UUID, 0, len(newBooks))
+		for _, book := range newBooks {
+			bookIDs = append(bookIDs, book.ID)
+		}
+		r.invalidate(bookIDs...)
+	}
+	return err
+}
+
+func (r *cachedBookRepository) ReplaceBookAuthors(c *fiber.Ctx, bookID uuid.UUID, credits []BookAuthor) error {
+	err := r.BookRepository.ReplaceBookAuthors(c, bookID, credits)
+	if err == nil {
+		r.invalidate(bookID)
+	}
+	return err
+}
+
+func (r *cachedBookRepository) ReplaceBookTags(c *fiber.Ctx, bookID uuid.UUID, names []string) error {
+	err := r.BookRepository.ReplaceBookTags(c, bookID, names)
+	if err == nil {
+		r.invalidate(bookID)
+	}
+	return err
+}
+
+func (r *cachedBookRepository) ReplaceBookCategories(c *fiber.Ctx, bookID uuid.UUID, categoryIDs []uuid.UUID) error {
+	err := r.BookRepository.ReplaceBookCategories(c, bookID, categoryIDs)
+	if err == nil {
+		r.invalidate(bookID)
+	}
+	return err
+}
+
+func (r *cachedBookRepository) MergeBooks(c *fiber.Ctx, canonicalID, duplicateID uuid.UUID, version int) error {
+	err := r.BookRepository.MergeBooks(c, canonicalID, duplicateID, version)
+	if err == nil {
+		r.invalidate(canonicalID, duplicateID)
+	}
+	return err
+}
+
+func (r *cachedBookRepository) UpdateBookWork(c *fiber.Ctx, bookID uuid.UUID, workID *uuid.UUID, version int) error {
+	err := r.BookRepository.UpdateBookWork(c, bookID, workID, version)
+	if err == nil {
+		r.invalidate(bookID)
+	}
+	return err
+}
+
+func (r *cachedBookRepository) UpdateBookSeries(c *fiber.Ctx, bookID uuid.UUID, seriesID *uuid.UUID, volume *float64, version int) error {
+	err := r.BookRepository.UpdateBookSeries(c, bookID, seriesID, volume, version)
+	if err == nil {
+		r.invalidate(bookID)
+	}
+	return err
+}
+
+func (r *cachedBookRepository) SaveBookTranslation(c *fiber.Ctx, translation BookTranslation) (BookTranslation, error) {
+	saved, err := r.BookRepository.SaveBookTranslation(c, translation)
+	if err == nil {
+		r.invalidate(translation.BookID)
+	}
+	return saved, err
+}
+
+func (r *cachedBookRepository) DeleteBookTranslation(c *fiber.Ctx, bookID uuid.UUID, locale string) error {
+	err := r.BookRepository.DeleteBookTranslation(c, bookID, locale)
+	if err == nil {
+		r.invalidate(bookID)
+	}
+	return err
+}
+
+// Transaction bypasses the cache inside the transaction and invalidates what it wrote once it committed
+func (r *cachedBookRepository) Transaction(c *fiber.Ctx, fn func(txRepo BookRepository) error) error {
+	pending := &bookInvalidation{}
+	err := r.BookRepository.Transaction(c, func(txRepo BookRepository) error {
+		return fn(&cachedBookRepository{BookRepository: txRepo, config: r.config, redis: r.redis, group: r.group, pending: pending})
+	})
+	if err == nil && pending.dirty {
+		r.invalidate(pending.bookIDs...)
+	}
+	return err
+}
+
+// invalidate drops the cached books and every cached list
+func (r *cachedBookRepository) invalidate(bookIDs ...uuid.UUID) {
+	if r.pending != nil {
+		r.pending.bookIDs = append(r.pending.bookIDs, bookIDs...)
+		r.pending.dirty = true
+		return
+	}
+	invalidateBookCache(r.redis, bookIDs...)
+}
+
+// BookCacheInvalidator drops cached books for writes made outside the book package
+type BookCacheInvalidator struct {
+	redis *database.RedisDB
+}
+
+// NewBookCacheInvalidator returns nil when the cache is disabled, a nil invalidator does nothing
+func NewBookCacheInvalidator(cfg *config.Config, redis *database.RedisDB) *BookCacheInvalidator {
+	if cfg.BookCache == nil || !cfg.BookCache.Enabled {
+		return nil
+	}
+	return &BookCacheInvalidator{redis: redis}
+}
+
+// InvalidateBooks drops the given books and every cached list, it is called after the write committed
+func (i *BookCacheInvalidator) InvalidateBooks(c *fiber.Ctx, bookIDs ...uuid.UUID) {
+	if i == nil {
+		return
+	}
+	invalidateBookCache(i.redis, bookIDs...)
+}
+
+func invalidateBookCache(redisDB *database.RedisDB, bookIDs ...uuid.UUID) {
+	ctx := context.Background()
+	pipe := redisDB.Client.TxPipeline()
+	pipe.Incr(ctx, bookCacheListGenKey)
+	for _, bookID := range bookIDs {
+		pipe.Del(ctx, bookCacheKey(bookID))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		bookCacheStats.Add("errors", 1)
+		log.Errorf("Error invalidating book cache: %v", err)
+	}
+}
+
+// get decodes a cached value, a Redis failure is treated as a miss so reads keep working without Redis
+func (r *cachedBookRepository) get(key string, value interface{}) bool {
+	data, err := r.redis.Client.Get(context.Background(), key).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			bookCacheStats.Add("errors", 1)
+		}
+		return false
+	}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(value); err != nil {
+		bookCacheStats.Add("errors", 1)
+		return false
+	}
+	return true
+}
+
+// generation returns the current list generation, a Redis failure is counted and returned
+func (r *cachedBookRepository) generation() (int64, error) {
+	gen, err := r.redis.Client.Get(context.Background(), bookCacheListGenKey).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		bookCacheStats.Add("errors", 1)
+	}
+	return gen, err
+}
+
+// setUnlessInvalidated stores a value loaded at generation gen. An invalidation after the load began either
+// deletes the value itself or has moved the generation on by the time it is checked here, then the value is
+// deleted again so a book read before a write committed is not served after it.
+func (r *cachedBookRepository) setUnlessInvalidated(key string, value interface{}, ttl time.Duration, gen int64) {
+	r.set(key, value, ttl)
+	if current, err := r.generation(); err == nil && current == gen {
+		return
+	}
+	bookCacheStats.Add("stale_sets", 1)
+	if err := r.redis.Client.Del(context.Background(), key).Err(); err != nil {
+		bookCacheStats.Add("errors", 1)
+	}
+}
+
+// set stores a value with the TTL stretched by a random jitter, so entries filled together don't expire together
+func (r *cachedBookRepository) set(key string, value interface{}, ttl time.Duration) {
+	// gob rather than JSON, the json tags hide fields like the cover prefix
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(value); err != nil {
+		bookCacheStats.Add("errors", 1)
+		return
+	}
+	if err := r.redis.Client.Set(context.Background(), key, buf.Bytes(), jitterTTL(ttl, r.config.Jitter)).Err(); err != nil {
+		bookCacheStats.Add("errors", 1)
+	}
+}
+
+func jitterTTL(ttl time.Duration, jitter float64) time.Duration {
+	if jitter <= 0 || ttl <= 0 {
+		return ttl
+	}
+	return ttl + time.Duration(rand.Float64()*jitter*float64(ttl))
+}
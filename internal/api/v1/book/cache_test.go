@@ -0,0 +1,318 @@
+package book_test
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+	"github.com/jerpsp/go-fiber-beginner/mocks"
+	"github.com/jerpsp/go-fiber-beginner/pkg/database"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+// memoryRedis answers the commands the book cache sends from a map, so the cache is tested without a Redis server
+type memoryRedis struct {
+	mu   sync.Mutex
+	data map[string]string
+}
+
+func (m *memoryRedis) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return nil, redis.ErrClosed
+	}
+}
+
+func (m *memoryRedis) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		m.process(cmd)
+		return cmd.Err()
+	}
+}
+
+func (m *memoryRedis) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		for _, cmd := range cmds {
+			m.process(cmd)
+		}
+		return nil
+	}
+}
+
+func (m *memoryRedis) process(cmd redis.Cmder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	args := cmd.Args()
+	switch cmd.Name() {
+	case "get":
+		value, ok := m.data[args[1].(string)]
+		if !ok {
+			cmd.SetErr(redis.Nil)
+			return
+		}
+		cmd.(*redis.StringCmd).SetVal(value)
+	case "set":
+		switch value := args[2].(type) {
+		case []byte:
+			m.data[args[1].(string)] = string(value)
+		case string:
+			m.data[args[1].(string)] = value
+		}
+		cmd.(*redis.StatusCmd).SetVal("OK")
+	case "incr":
+		count, _ := strconv.ParseInt(m.data[args[1].(string)], 10, 64)
+		count++
+		m.data[args[1].(string)] = strconv.FormatInt(count, 10)
+		cmd.(*redis.IntCmd).SetVal(count)
+	case "del":
+		var deleted int64
+		for _, key := range args[1:] {
+			if _, ok := m.data[key.(string)]; ok {
+				delete(m.data, key.(string))
+				deleted++
+			}
+		}
+		cmd.(*redis.IntCmd).SetVal(deleted)
+	}
+}
+
+type BookCacheSuite struct {
+	suite.Suite
+	mockRepo    *mocks.BookRepository
+	store       *memoryRedis
+	redis       *database.RedisDB
+	config      *config.Config
+	repo        book.BookRepository
+	invalidator *book.BookCacheInvalidator
+}
+
+func TestBookCacheSuite(t *testing.T) {
+	suite.Run(t, new(BookCacheSuite))
+}
+
+func (s *BookCacheSuite) SetupTest() {
+	s.mockRepo = mocks.NewBookRepository(s.T())
+	s.store = &memoryRedis{data: map[string]string{}}
+	client := redis.NewClient(&redis.Options{Addr: "memory:6379"})
+	client.AddHook(s.store)
+	s.redis = &database.RedisDB{Client: client}
+	s.config = &config.Config{BookCache: &config.BookCache{Enabled: true, BookTTL: time.Minute, ListTTL: time.Minute, NegativeTTL: time.Minute}}
+	s.repo = book.NewCachedBookRepository(s.config, s.mockRepo, s.redis)
+	s.invalidator = book.NewBookCacheInvalidator(s.config, s.redis)
+
+	s.mockRepo.EXPECT().Transaction(mock.Anything, mock.Anything).RunAndReturn(func(c *fiber.Ctx, fn func(txRepo book.BookRepository) error) error {
+		return fn(s.mockRepo)
+	}).Maybe()
+}
+
+func (s *BookCacheSuite) TestFindBookByID1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Find Book Miss Then Hit", 34)
+
+	// Setup Mock
+	cached := book.Book{ID: uuid.New(), Title: "Dune", Version: 3}
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, cached.ID).Return(cached, nil).Once()
+
+	// Call the service method
+	first, err1 := s.repo.FindBookByID(&fiber.Ctx{}, cached.ID)
+	second, err2 := s.repo.FindBookByID(&fiber.Ctx{}, cached.ID)
+
+	// Assertions
+	s.NoError(err1)
+	s.NoError(err2)
+	s.Equal(cached, first)
+	s.Equal(cached, second)
+}
+
+func (s *BookCacheSuite) TestFindBookByID2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Find Book Missing Is Cached", 31)
+
+	// Setup Mock
+	bookID := uuid.New()
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, bookID).Return(book.Book{}, gorm.ErrRecordNotFound).Once()
+
+	// Call the service method
+	_, err1 := s.repo.FindBookByID(&fiber.Ctx{}, bookID)
+	_, err2 := s.repo.FindBookByID(&fiber.Ctx{}, bookID)
+
+	// Assertions
+	s.ErrorIs(err1, gorm.ErrRecordNotFound)
+	s.ErrorIs(err2, gorm.ErrRecordNotFound)
+}
+
+func (s *BookCacheSuite) TestFindBookByID3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Find Book After Create Drops Negative Entry", 34)
+
+	// Setup Mock
+	created := book.Book{ID: uuid.New(), Title: "Dune", Version: 1}
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, created.ID).Return(book.Book{}, gorm.ErrRecordNotFound).Once()
+	s.mockRepo.EXPECT().CreateBook(mock.Anything, mock.Anything).Return(created, nil)
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, created.ID).Return(created, nil).Once()
+
+	// Call the service method
+	_, err1 := s.repo.FindBookByID(&fiber.Ctx{}, created.ID)
+	_, err2 := s.repo.CreateBook(&fiber.Ctx{}, book.Book{Title: created.Title})
+	result, err3 := s.repo.FindBookByID(&fiber.Ctx{}, created.ID)
+
+	// Assertions
+	s.ErrorIs(err1, gorm.ErrRecordNotFound)
+	s.NoError(err2)
+	s.NoError(err3)
+	s.Equal(created, result)
+}
+
+func (s *BookCacheSuite) TestFindBookByID4() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Find Book After Transaction Write", 34)
+
+	// Setup Mock
+	current := book.Book{ID: uuid.New(), Title: "Dune", Version: 1}
+	patched := book.Book{ID: current.ID, Title: "Dune Messiah", Version: 2}
+	fields := map[string]interface{}{"title": patched.Title}
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).Return(current, nil).Once()
+	s.mockRepo.EXPECT().PatchBook(mock.Anything, current, fields).Return(patched, nil)
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).Return(patched, nil).Once()
+
+	// Call the service method
+	_, err1 := s.repo.FindBookByID(&fiber.Ctx{}, current.ID)
+	err2 := s.repo.Transaction(&fiber.Ctx{}, func(txRepo book.BookRepository) error {
+		_, err := txRepo.PatchBook(&fiber.Ctx{}, current, fields)
+		return err
+	})
+	result, err3 := s.repo.FindBookByID(&fiber.Ctx{}, current.ID)
+
+	// Assertions
+	s.NoError(err1)
+	s.NoError(err2)
+	s.NoError(err3)
+	s.Equal(patched, result)
+}
+
+func (s *BookCacheSuite) TestFindBookByID5() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Find Book Racing A Write Is Not Cached", 31)
+
+	// Setup Mock
+	// the write commits and invalidates while the first read is still loading the old row
+	current := book.Book{ID: uuid.New(), Title: "Dune", Version: 1}
+	patched := book.Book{ID: current.ID, Title: "Dune Messiah", Version: 2}
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).RunAndReturn(func(c *fiber.Ctx, bookID uuid.UUID) (book.Book, error) {
+		s.invalidator.InvalidateBooks(c, bookID)
+		return current, nil
+	}).Once()
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).Return(patched, nil).Once()
+
+	// Call the service method
+	first, err1 := s.repo.FindBookByID(&fiber.Ctx{}, current.ID)
+	second, err2 := s.repo.FindBookByID(&fiber.Ctx{}, current.ID)
+	third, err3 := s.repo.FindBookByID(&fiber.Ctx{}, current.ID)
+
+	// Assertions
+	s.NoError(err1)
+	s.NoError(err2)
+	s.NoError(err3)
+	s.Equal(current, first)
+	s.Equal(patched, second)
+	s.Equal(patched, third)
+}
+
+func (s *BookCacheSuite) TestFindAllBooks1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Find All Books Hit Until Invalidated", 34)
+
+	// Setup Mock
+	filter := book.BookFilter{Author: "Herbert"}
+	books := []book.Book{{ID: uuid.New(), Title: "Dune", RatingCount: 1}}
+	rated := []book.Book{{ID: books[0].ID, Title: "Dune", RatingCount: 2}}
+	s.mockRepo.EXPECT().FindAllBooks(mock.Anything, filter).Return(books, nil).Once()
+	s.mockRepo.EXPECT().FindAllBooks(mock.Anything, filter).Return(rated, nil).Once()
+
+	// Call the service method
+	first, err1 := s.repo.FindAllBooks(&fiber.Ctx{}, filter)
+	second, err2 := s.repo.FindAllBooks(&fiber.Ctx{}, filter)
+	s.invalidator.InvalidateBooks(&fiber.Ctx{}, books[0].ID)
+	third, err3 := s.repo.FindAllBooks(&fiber.Ctx{}, filter)
+
+	// Assertions
+	s.NoError(err1)
+	s.NoError(err2)
+	s.NoError(err3)
+	s.Equal(books, first)
+	s.Equal(books, second)
+	s.Equal(rated, third)
+}
+
+func (s *BookCacheSuite) TestFindAllBooks2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Find All Books Racing A Write Is Not Served", 31)
+
+	// Setup Mock
+	filter := book.BookFilter{Author: "Herbert"}
+	books := []book.Book{{ID: uuid.New(), Title: "Dune", RatingCount: 1}}
+	rated := []book.Book{{ID: books[0].ID, Title: "Dune", RatingCount: 2}}
+	s.mockRepo.EXPECT().FindAllBooks(mock.Anything, filter).RunAndReturn(func(c *fiber.Ctx, filter book.BookFilter) ([]book.Book, error) {
+		s.invalidator.InvalidateBooks(c, books[0].ID)
+		return books, nil
+	}).Once()
+	s.mockRepo.EXPECT().FindAllBooks(mock.Anything, filter).Return(rated, nil).Once()
+
+	// Call the service method
+	first, err1 := s.repo.FindAllBooks(&fiber.Ctx{}, filter)
+	second, err2 := s.repo.FindAllBooks(&fiber.Ctx{}, filter)
+	third, err3 := s.repo.FindAllBooks(&fiber.Ctx{}, filter)
+
+	// Assertions
+	s.NoError(err1)
+	s.NoError(err2)
+	s.NoError(err3)
+	s.Equal(books, first)
+	s.Equal(rated, second)
+	s.Equal(rated, third)
+}
+
+func (s *BookCacheSuite) TestInvalidateBooks1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Invalidate Books Drops Cached Book", 34)
+
+	// Setup Mock
+	current := book.Book{ID: uuid.New(), Title: "Dune", Author: "Frank Herbert"}
+	renamed := book.Book{ID: current.ID, Title: "Dune", Author: "F. Herbert"}
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).Return(current, nil).Once()
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).Return(renamed, nil).Once()
+
+	// Call the service method
+	_, err1 := s.repo.FindBookByID(&fiber.Ctx{}, current.ID)
+	s.invalidator.InvalidateBooks(&fiber.Ctx{}, current.ID)
+	result, err2 := s.repo.FindBookByID(&fiber.Ctx{}, current.ID)
+
+	// Assertions
+	s.NoError(err1)
+	s.NoError(err2)
+	s.Equal(renamed, result)
+}
+
+func (s *BookCacheSuite) TestInvalidateBooks2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Invalidate Books With Cache Disabled", 34)
+
+	// Call the service method
+	invalidator := book.NewBookCacheInvalidator(&config.Config{}, s.redis)
+	invalidator.InvalidateBooks(&fiber.Ctx{}, uuid.New())
+
+	// Assertions
+	s.Nil(invalidator)
+	s.Empty(s.store.data)
+}
@@ -0,0 +1,36 @@
+package book
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/pkg/imaging"
+)
+
+// maxCoverSize limits uploaded and downloaded cover images
+const maxCoverSize = 2 * 1024 * 1024
+
+var (
+	coverSizes   = []int{64, 256, 1024}
+	coverFormats = []string{imaging.FormatJPEG, imaging.FormatWebP}
+)
+
+// newCoverPrefix returns a fresh key prefix so a replaced cover never shares keys with the previous one
+func newCoverPrefix(bookID uuid.UUID) string {
+	return fmt.Sprintf("books/%s/covers/%s", bookID.String(), uuid.New().String())
+}
+
+func coverKey(prefix string, size int, format string) string {
+	return fmt.Sprintf("%s/%d.%s", prefix, size, format)
+}
+
+// coverKeys lists every object stored under a cover prefix
+func coverKeys(prefix string) []string {
+	keys := make([]string, 0, len(coverSizes)*len(coverFormats))
+	for _, size := range coverSizes {
+		for _, format := range coverFormats {
+			keys = append(keys, coverKey(prefix, size, format))
+		}
+	}
+	return keys
+}
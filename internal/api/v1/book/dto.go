@@ -1,6 +1,226 @@
 package book
 
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/pkg/catalogue"
+)
+
+// BookRequest is the patchable view of a book, json names double as column names for partial updates
 type BookRequest struct {
-	Title  string `json:"title"`
-	Author string `json:"author"`
+	Title       string `json:"title" validate:"required,max=255"`
+	Subtitle    string `json:"subtitle" validate:"omitempty,max=255"`
+	Description string `json:"description" validate:"omitempty,max=10000"`
+	Author      string `json:"author" validate:"required,max=255"`
+	ISBN        string `json:"isbn" validate:"omitempty"`
+	Language    string `json:"language" validate:"omitempty,bcp47_language_tag"`
+	Publisher   string `json:"publisher" validate:"omitempty,max=255"`
+	PageCount   int    `json:"page_count" validate:"omitempty,min=0"`
+}
+
+// Book copies the editable fields into a new book, server-owned fields like the rating and version keep their zero value
+func (r BookRequest) Book() Book {
+	return Book{Title: r.Title, Subtitle: r.Subtitle, Description: r.Description, Author: r.Author,
+		ISBN: r.ISBN, Language: r.Language, Publisher: r.Publisher, PageCount: r.PageCount}
+}
+
+type BookAuthorRequest struct {
+	AuthorID uuid.UUID `json:"author_id" validate:"required"`
+	Role     string    `json:"role" validate:"omitempty,oneof=author editor translator"`
+}
+
+// BookAuthorsRequest replaces all credits of a book, the list order becomes the credit order
+type BookAuthorsRequest struct {
+	Authors []BookAuthorRequest `json:"authors" validate:"required,dive"`
+}
+
+type BookTagsRequest struct {
+	Tags []string `json:"tags" validate:"dive,required,max=64"`
+}
+
+type BookCategoriesRequest struct {
+	CategoryIDs []uuid.UUID `json:"category_ids"`
+}
+
+// BookFilter narrows book listings, category matches the category by ID or slug including its subcategories
+type BookFilter struct {
+	Query    string `query:"q"`
+	Title    string `query:"title"`
+	Author   string `query:"author"`
+	ISBN     string `query:"isbn"`
+	Category string `query:"category"`
+	Tag      string `query:"tag"`
+	Language string `query:"language"`
+}
+
+// FacetCount is the number of matching books for one value of a facet
+type FacetCount struct {
+	ID    *uuid.UUID `json:"id,omitempty"`
+	Value string     `json:"value"`
+	Label string     `json:"label,omitempty"`
+	Count int64      `json:"count"`
+}
+
+type BookFacets struct {
+	Categories []FacetCount `json:"categories"`
+	Tags       []FacetCount `json:"tags"`
+	Languages  []FacetCount `json:"languages"`
+	Authors    []FacetCount `json:"authors"`
+}
+
+type ImportBookRow struct {
+	Title  string `json:"title" validate:"required,max=255"`
+	Author string `json:"author" validate:"required,max=255"`
+	ISBN   string `json:"isbn" validate:"omitempty"`
+}
+
+type ImportRowResult struct {
+	Row    int        `json:"row"`
+	Status string     `json:"status"`
+	BookID *uuid.UUID `json:"book_id,omitempty"`
+	Reason string     `json:"reason,omitempty"`
+}
+
+type ImportReport struct {
+	DryRun  bool              `json:"dry_run"`
+	Created int               `json:"created"`
+	Updated int               `json:"updated"`
+	Skipped int               `json:"skipped"`
+	Failed  int               `json:"failed"`
+	Rows    []ImportRowResult `json:"rows"`
+}
+
+// BookLookup is the catalogue answer for an ISBN, Book is set when the lookup also created the book
+type BookLookup struct {
+	Metadata *catalogue.Metadata `json:"metadata"`
+	Book     *Book               `json:"book,omitempty"`
+}
+
+// BatchRequest runs several book operations in one call, atomic runs them in a single transaction
+type BatchRequest struct {
+	Atomic     bool             `json:"atomic"`
+	Operations []BatchOperation `json:"operations" validate:"required,min=1,dive"`
+}
+
+// BatchOperation is one create, patch or delete. Body is the book for a create and the patch document for a patch,
+// ContentType and IfMatch play the role of the matching headers on the single routes.
+type BatchOperation struct {
+	Op          string          `json:"op" validate:"required,oneof=create patch delete"`
+	ID          *uuid.UUID      `json:"id" validate:"required_unless=Op create"`
+	Body        json.RawMessage `json:"body" validate:"required_unless=Op delete"`
+	ContentType string          `json:"content_type"`
+	IfMatch     string          `json:"if_match"`
+}
+
+type BatchResult struct {
+	Index  int        `json:"index"`
+	Op     string     `json:"op"`
+	ID     *uuid.UUID `json:"id,omitempty"`
+	Status int        `json:"status"`
+	Book   *Book      `json:"book,omitempty"`
+	Error  string     `json:"error,omitempty"`
+}
+
+type BatchResponse struct {
+	Atomic    bool          `json:"atomic"`
+	Committed bool          `json:"committed"`
+	Succeeded int           `json:"succeeded"`
+	Failed    int           `json:"failed"`
+	Results   []BatchResult `json:"results"`
+}
+
+type BookHistoryFilter struct {
+	Page  int `query:"page"`
+	Limit int `query:"limit"`
+}
+
+type BookHistoryResponse struct {
+	History    []BookRevision `json:"history"`
+	Total      int64          `json:"total"`
+	Page       int            `json:"page"`
+	PerPage    int            `json:"per_page"`
+	TotalPages int            `json:"total_pages"`
+}
+
+type DuplicateFilter struct {
+	Page     int     `query:"page"`
+	Limit    int     `query:"limit"`
+	MinScore float64 `query:"min_score"`
+}
+
+// DuplicateCandidate is a pair of books that look like the same edition, Book is the older one
+// and the suggested canonical book. Score is 1 for equal ISBNs, otherwise weighted title and author similarity.
+type DuplicateCandidate struct {
+	BookID      uuid.UUID `json:"-"`
+	DuplicateID uuid.UUID `json:"-"`
+	Book        Book      `gorm:"-" json:"book"`
+	Duplicate   Book      `gorm:"-" json:"duplicate"`
+	TitleScore  float64   `json:"title_score"`
+	AuthorScore float64   `json:"author_score"`
+	SameISBN    bool      `gorm:"column:same_isbn" json:"same_isbn"`
+	Score       float64   `json:"score"`
+}
+
+type DuplicatesResponse struct {
+	Duplicates []DuplicateCandidate `json:"duplicates"`
+	Total      int64                `json:"total"`
+	Page       int                  `json:"page"`
+	PerPage    int                  `json:"per_page"`
+	TotalPages int                  `json:"total_pages"`
+}
+
+type MergeRequest struct {
+	DuplicateID uuid.UUID `json:"duplicate_id" validate:"required"`
+}
+
+// BookWorkRequest files a book as an edition of a work, a null work_id takes it out again
+type BookWorkRequest struct {
+	WorkID *uuid.UUID `json:"work_id"`
+}
+
+// BookSeriesRequest places a book in a series, a null series_id takes it out again
+type BookSeriesRequest struct {
+	SeriesID *uuid.UUID `json:"series_id"`
+	Volume   *float64   `json:"volume" validate:"omitempty,gt=0,lt=10000"`
+}
+
+// BookDownloadLink is a presigned URL to the e-book, valid until ExpiresAt
+type BookDownloadLink struct {
+	Format    string    `json:"format"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Size      int64     `json:"size"`
+	Checksum  string    `json:"checksum"`
+}
+
+type DownloadStatsFilter struct {
+	Page  int `query:"page"`
+	Limit int `query:"limit"`
+}
+
+// UserDownloads is how often one user downloaded a book
+type UserDownloads struct {
+	UserID           uuid.UUID `json:"user_id"`
+	Downloads        int64     `json:"downloads"`
+	LastDownloadedAt time.Time `json:"last_downloaded_at"`
+}
+
+type DownloadStatsResponse struct {
+	BookID     uuid.UUID        `json:"book_id"`
+	Downloads  int64            `json:"downloads"`
+	Formats    map[string]int64 `json:"formats"`
+	Users      []UserDownloads  `json:"users"`
+	Total      int64            `json:"total"`
+	Page       int              `json:"page"`
+	PerPage    int              `json:"per_page"`
+	TotalPages int              `json:"total_pages"`
+}
+
+// BookTranslationRequest adds or replaces the translation of a book in the locale of the route
+type BookTranslationRequest struct {
+	Title       string `json:"title" validate:"required,max=255"`
+	Subtitle    string `json:"subtitle" validate:"omitempty,max=255"`
+	Description string `json:"description" validate:"omitempty,max=10000"`
 }
@@ -0,0 +1,277 @@
+package book
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/pkg/feed"
+	"github.com/jerpsp/go-fiber-beginner/pkg/storage"
+)
+
+// BookFeedName names the Redis stream and channel of the book change feed
+const BookFeedName = "books"
+
+// bookStreamRetry is how long a client waits before it reconnects to a dropped stream
+const bookStreamRetry = 3 * time.Second
+
+const (
+	BookEventCreated = "created"
+	BookEventUpdated = "updated"
+	BookEventDeleted = "deleted"
+	// BookEventReset tells a resuming client that the events it missed are gone and it has to reload the books
+	BookEventReset = "reset"
+)
+
+// BookEvent is the data of a change feed event, a deleted book is sent as it was before the delete. Created and
+// updated books carry their cover URLs like the book reads, a deleted book's covers are gone with it.
+type BookEvent struct {
+	Book Book `json:"book"`
+}
+
+// bookChange is a write waiting to be published, Book is only set for deletes
+type bookChange struct {
+	event  string
+	bookID uuid.UUID
+	book   *Book
+}
+
+// publishingBookRepository announces every book written through it on the book feed. Writes inside a transaction
+// are announced once it committed, a failed publish is logged and never fails the write.
+type publishingBookRepository struct {
+	BookRepository
+	config *config.Config
+	feed   feed.Feed
+	s3Repo storage.S3Repository
+	// pending collects the changes made inside a transaction
+	pending *[]bookChange
+}
+
+func NewPublishingBookRepository(cfg *config.Config, repo BookRepository, bookFeed feed.Feed, s3Repo storage.S3Repository) BookRepository {
+	return &publishingBookRepository{BookRepository: repo, config: cfg, feed: bookFeed, s3Repo: s3Repo}
+}
+
+func (r *publishingBookRepository) CreateBook(c *fiber.Ctx, newBook Book) (Book, error) {
+	book, err := r.BookRepository.CreateBook(c, newBook)
+	if err == nil {
+		r.record(c, bookChange{event: BookEventCreated, bookID: book.ID})
+	}
+	return book, err
+}
+
+func (r *publishingBookRepository) UpdateBook(c *fiber.Ctx, updatedBook Book) (Book, error) {
+	book, err := r.BookRepository.UpdateBook(c, updatedBook)
+	if err == nil {
+		r.record(c, bookChange{event: BookEventUpdated, bookID: updatedBook.ID})
+	}
+	return book, err
+}
+
+func (r *publishingBookRepository) PatchBook(c *fiber.Ctx, book Book, fields map[string]interface{}) (Book, error) {
+	updatedBook, err := r.BookRepository.PatchBook(c, book, fields)
+	if err == nil {
+		r.record(c, bookChange{event: BookEventUpdated, bookID: book.ID})
+	}
+	return updatedBook, err
+}
+
+func (r *publishingBookRepository) RevertBook(c *fiber.Ctx, book Book, revision BookRevision) (Book, error) {
+	updatedBook, err := r.BookRepository.RevertBook(c, book, revision)
+	if err == nil {
+		r.record(c, bookChange{event: BookEventUpdated, bookID: book.ID})
+	}
+	return updatedBook, err
+}
+
+func (r *publishingBookRepository) DeleteBook(c *fiber.Ctx, bookID uuid.UUID, version int) error {
+	deleted := r.deletedBook(c, bookID)
+	err := r.BookRepository.DeleteBook(c, bookID, version)
+	if err == nil {
+		r.record(c, bookChange{event: BookEventDeleted, bookID: bookID, book: &deleted})
+	}
+	return err
+}
+
+func (r *publishingBookRepository) CreateBooks(c *fiber.Ctx, newBooks []Book, batchSize int) error {
+	err := r.BookRepository.CreateBooks(c, newBooks, batchSize)
+	if err == nil {
+		changes := make([]bookChange, 0, len(newBooks))
+		for _, book := range newBooks {
+			changes = append(changes, bookChange{event: BookEventCreated, bookID: book.ID})
+		}
+		r.record(c, changes...)
+	}
+	return err
+}
+
+func (r *publishingBookRepository) ReplaceBookAuthors(c *fiber.Ctx, bookID uuid.UUID, credits []BookAuthor) error {
+	err := r.BookRepository.ReplaceBookAuthors(c, bookID, credits)
+	if err == nil {
+		r.record(c, bookChange{event: BookEventUpdated, bookID: bookID})
+	}
+	return err
+}
+
+func (r *publishingBookRepository) ReplaceBookTags(c *fiber.Ctx, bookID uuid.UUID, names []string) error {
+	err := r.BookRepository.ReplaceBookTags(c, bookID, names)
+	if err == nil {
+		r.record(c, bookChange{event: BookEventUpdated, bookID: bookID})
+	}
+	return err
+}
+
+func (r *publishingBookRepository) ReplaceBookCategories(c *fiber.Ctx, bookID uuid.UUID, categoryIDs []uuid.UUID) error {
+	err := r.BookRepository.ReplaceBookCategories(c, bookID, categoryIDs)
+	if err == nil {
+		r.record(c, bookChange{event: BookEventUpdated, bookID: bookID})
+	}
+	return err
+}
+
+// MergeBooks announces the canonical book as updated and the duplicate as deleted
+func (r *publishingBookRepository) MergeBooks(c *fiber.Ctx, canonicalID, duplicateID uuid.UUID, version int) error {
+	duplicate := r.deletedBook(c, duplicateID)
+	err := r.BookRepository.MergeBooks(c, canonicalID, duplicateID, version)
+	if err == nil {
+		r.record(c,
+			bookChange{event: BookEventUpdated, bookID: canonicalID},
+			bookChange{event: BookEventDeleted, bookID: duplicateID, book: &duplicate})
+	}
+	return err
+}
+
+func (r *publishingBookRepository) UpdateBookWork(c *fiber.Ctx, bookID uuid.UUID, workID *uuid.UUID, version int) error {
+	err := r.BookRepository.UpdateBookWork(c, bookID, workID, version)
+	if err == nil {
+		r.record(c, bookChange{event: BookEventUpdated, bookID: bookID})
+	}
+	return err
+}
+
+func (r *publishingBookRepository) UpdateBookSeries(c *fiber.Ctx, bookID uuid.UUID, seriesID *uuid.UUID, volume *float64, version int) error {
+	err := r.BookRepository.UpdateBookSeries(c, bookID, seriesID, volume, version)
+	if err == nil {
+		r.record(c, bookChange{event: BookEventUpdated, bookID: bookID})
+	}
+	return err
+}
+
+func (r *publishingBookRepository) SaveBookTranslation(c *fiber.Ctx, translation BookTranslation) (BookTranslation, error) {
+	saved, err := r.BookRepository.SaveBookTranslation(c, translation)
+	if err == nil {
+		r.record(c, bookChange{event: BookEventUpdated, bookID: translation.BookID})
+	}
+	return saved, err
+}
+
+func (r *publishingBookRepository) DeleteBookTranslation(c *fiber.Ctx, bookID uuid.UUID, locale string) error {
+	err := r.BookRepository.DeleteBookTranslation(c, bookID, locale)
+	if err == nil {
+		r.record(c, bookChange{event: BookEventUpdated, bookID: bookID})
+	}
+	return err
+}
+
+// Transaction holds the changes back until the transaction committed, a rollback publishes nothing
+func (r *publishingBookRepository) Transaction(c *fiber.Ctx, fn func(txRepo BookRepository) error) error {
+	pending := &[]bookChange{}
+	err := r.BookRepository.Transaction(c, func(txRepo BookRepository) error {
+		return fn(&publishingBookRepository{BookRepository: txRepo, config: r.config, feed: r.feed, s3Repo: r.s3Repo, pending: pending})
+	})
+	if err == nil {
+		r.publish(c, *pending...)
+	}
+	return err
+}
+
+// deletedBook reads a book about to be deleted, the event still carries the ID when the read fails
+func (r *publishingBookRepository) deletedBook(c *fiber.Ctx, bookID uuid.UUID) Book {
+	book, err := r.BookRepository.FindBookByID(c, bookID)
+	if err != nil {
+		return Book{ID: bookID}
+	}
+	return book
+}
+
+func (r *publishingBookRepository) record(c *fiber.Ctx, changes ...bookChange) {
+	if r.pending != nil {
+		*r.pending = append(*r.pending, changes...)
+		return
+	}
+	r.publish(c, changes...)
+}
+
+// publish reads each created or updated book as it is now, a book deleted again in the meantime is skipped
+func (r *publishingBookRepository) publish(c *fiber.Ctx, changes ...bookChange) {
+	for _, change := range changes {
+		book := change.book
+		if book == nil {
+			current, err := r.BookRepository.FindBookByID(c, change.bookID)
+			if err != nil {
+				continue
+			}
+			current = withCoverURLs(r.s3Repo, current)
+			book = &current
+		}
+		if err := r.feed.Publish(context.Background(), change.event, BookEvent{Book: *book}); err != nil {
+			log.Errorf("failed to publish %s event of book %s: %v", change.event, change.bookID, err)
+		}
+	}
+}
+
+// streamBookEvents writes the replayed events and then the live ones in the text/event-stream format until the
+// client goes away or the subscription is dropped. Heartbeat comments keep idle proxies from closing the stream.
+func streamBookEvents(w *bufio.Writer, conn net.Conn, events <-chan feed.Event, replay []feed.Event, reset bool, heartbeat time.Duration) {
+	// every write moves the deadline, the stream outlives the server's write timeout but not a stalled client
+	write := func(format string, args ...interface{}) error {
+		if err := conn.SetWriteDeadline(time.Now().Add(2 * heartbeat)); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, format, args...)
+		return w.Flush()
+	}
+
+	if err := write("retry: %d\n\n", bookStreamRetry.Milliseconds()); err != nil {
+		return
+	}
+	if reset {
+		if err := write("event: %s\ndata: {}\n\n", BookEventReset); err != nil {
+			return
+		}
+	}
+	lastID := ""
+	for _, event := range replay {
+		if err := write("id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Data); err != nil {
+			return
+		}
+		lastID = event.ID
+	}
+
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			// the subscription started before the replay was read, so it may repeat replayed events
+			if lastID != "" && !event.After(lastID) {
+				continue
+			}
+			if err := write("id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Data); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := write(": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+	}
+}
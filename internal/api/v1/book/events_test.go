@@ -0,0 +1,194 @@
+package book_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+	"github.com/jerpsp/go-fiber-beginner/mocks"
+	"github.com/jerpsp/go-fiber-beginner/pkg/activity"
+	"github.com/jerpsp/go-fiber-beginner/pkg/feed"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// stubFeed replays a fixed buffer and hands out a subscription that already holds the live events
+type stubFeed struct {
+	buffered []feed.Event
+	complete bool
+	live     []feed.Event
+	lastID   string
+	canceled bool
+	// published keeps the data of every published event
+	published []interface{}
+}
+
+func (f *stubFeed) Publish(ctx context.Context, eventType string, data interface{}) error {
+	f.published = append(f.published, data)
+	return nil
+}
+
+func (f *stubFeed) Since(ctx context.Context, lastID string) ([]feed.Event, bool, error) {
+	f.lastID = lastID
+	return f.buffered, f.complete, nil
+}
+
+// Subscribe closes the channel after the live events so the stream ends
+func (f *stubFeed) Subscribe() (<-chan feed.Event, func()) {
+	events := make(chan feed.Event, len(f.live))
+	for _, event := range f.live {
+		events <- event
+	}
+	close(events)
+	return events, func() { f.canceled = true }
+}
+
+func (f *stubFeed) Run(ctx context.Context) {}
+
+func bookEvent(id, eventType string) feed.Event {
+	return feed.Event{ID: id, Type: eventType, Data: json.RawMessage(`{"book":{}}`)}
+}
+
+type BookEventsSuite struct {
+	suite.Suite
+	feed   *stubFeed
+	router *fiber.App
+}
+
+func TestBookEventsSuite(t *testing.T) {
+	suite.Run(t, new(BookEventsSuite))
+}
+
+func (s *BookEventsSuite) SetupTest() {
+	s.feed = &stubFeed{complete: true}
+	cfg := &config.Config{Feed: &feed.FeedConfig{Heartbeat: time.Minute}}
+	handler := book.NewBookHandler(cfg, mocks.NewBookService(s.T()), activity.New(&activity.ActivityConfig{}, nil), s.feed)
+
+	s.router = fiber.New()
+	s.router.Get("/api/v1/books/events", handler.StreamBookEvents)
+}
+
+// stream runs the request and returns the event stream it wrote
+func (s *BookEventsSuite) stream(lastEventID string) string {
+	req, _ := http.NewRequest("GET", "/api/v1/books/events", nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := s.router.Test(req)
+	s.Require().NoError(err)
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal("text/event-stream", resp.Header.Get(fiber.HeaderContentType))
+
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+
+func (s *BookEventsSuite) TestStreamBookEvents1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Stream Live Events Without Last-Event-ID", 34)
+
+	// Setup Mock
+	s.feed.live = []feed.Event{bookEvent("5-0", book.BookEventCreated), bookEvent("6-0", book.BookEventUpdated)}
+
+	// Run Test Request
+	body := s.stream("")
+
+	s.Equal("retry: 3000\n\n"+
+		"id: 5-0\nevent: created\ndata: {\"book\":{}}\n\n"+
+		"id: 6-0\nevent: updated\ndata: {\"book\":{}}\n\n", body)
+	s.Empty(s.feed.lastID)
+	s.True(s.feed.canceled)
+}
+
+func (s *BookEventsSuite) TestStreamBookEvents2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Stream Replays Then Hands Over To Live Events", 34)
+
+	// Setup Mock
+	// the subscription started before the replay was read, so it repeats 4-0 and 5-0
+	s.feed.buffered = []feed.Event{bookEvent("4-0", book.BookEventUpdated), bookEvent("5-0", book.BookEventDeleted)}
+	s.feed.live = []feed.Event{
+		bookEvent("4-0", book.BookEventUpdated),
+		bookEvent("5-0", book.BookEventDeleted),
+		bookEvent("10-0", book.BookEventCreated),
+	}
+
+	// Run Test Request
+	body := s.stream("3-0")
+
+	s.Equal("3-0", s.feed.lastID)
+	s.Equal("retry: 3000\n\n"+
+		"id: 4-0\nevent: updated\ndata: {\"book\":{}}\n\n"+
+		"id: 5-0\nevent: deleted\ndata: {\"book\":{}}\n\n"+
+		"id: 10-0\nevent: created\ndata: {\"book\":{}}\n\n", body)
+}
+
+func (s *BookEventsSuite) TestStreamBookEvents3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Stream With Nothing Missed", 34)
+
+	// Setup Mock
+	// with an empty replay every live event goes out, 3-0 included
+	s.feed.live = []feed.Event{bookEvent("3-0", book.BookEventUpdated), bookEvent("7-0", book.BookEventUpdated)}
+
+	// Run Test Request
+	body := s.stream("3-0")
+
+	s.Equal(2, strings.Count(body, "id: "))
+	s.NotContains(body, book.BookEventReset)
+}
+
+func (s *BookEventsSuite) TestStreamBookEvents4() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Stream Resets On A Lost Or Malformed Last-Event-ID", 31)
+
+	// Setup Mock
+	// Since reports a malformed ID or one that was trimmed away as incomplete
+	s.feed.complete = false
+	s.feed.live = []feed.Event{bookEvent("9-0", book.BookEventCreated)}
+
+	// Run Test Request
+	body := s.stream("not-an-id")
+
+	s.Equal("not-an-id", s.feed.lastID)
+	s.Equal("retry: 3000\n\n"+
+		"event: reset\ndata: {}\n\n"+
+		"id: 9-0\nevent: created\ndata: {\"book\":{}}\n\n", body)
+}
+
+func (s *BookEventsSuite) TestPublishBookEvents1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Updated Book Event Carries Cover URLs", 34)
+
+	// Setup Mock
+	current := book.Book{ID: uuid.New(), Title: "Dune", Version: 2, CoverPrefix: "covers/dune"}
+	mockRepo := mocks.NewBookRepository(s.T())
+	mockStorage := mocks.NewS3Repository(s.T())
+	mockRepo.EXPECT().PatchBook(mock.Anything, current, map[string]interface{}{}).Return(current, nil)
+	mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).Return(current, nil)
+	mockStorage.EXPECT().GetPublicURLFile(mock.Anything).RunAndReturn(func(key string) string {
+		return "https://cdn.example.com/" + key
+	})
+	repo := book.NewPublishingBookRepository(&config.Config{}, mockRepo, s.feed, mockStorage)
+
+	// Call the service method
+	_, err := repo.PatchBook(&fiber.Ctx{}, current, map[string]interface{}{})
+
+	// Assertions
+	s.NoError(err)
+	s.Require().Len(s.feed.published, 1)
+	event := s.feed.published[0].(book.BookEvent)
+	s.NotEmpty(event.Book.Covers)
+	for _, cover := range event.Book.Covers {
+		s.Contains(cover.URL, "https://cdn.example.com/covers/dune")
+	}
+}
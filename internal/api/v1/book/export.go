@@ -0,0 +1,76 @@
+package book
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	exportBatchSize = 1000
+
+	// exportJobTTL matches the lifetime of the presigned download link
+	exportJobTTL = 24 * time.Hour
+
+	// ExportStatusTrailer is sent after the rows of a streamed export, "complete" or "failed". An export that
+	// failed halfway has already sent a 200 and part of the file, the trailer is the only place left to say so.
+	ExportStatusTrailer = "X-Export-Status"
+)
+
+var bookExportColumns = []string{"id", "title", "author", "isbn", "created_at", "updated_at"}
+
+func bookExportRow(book Book) []string {
+	return []string{
+		book.ID.String(),
+		book.Title,
+		book.Author,
+		book.ISBN,
+		book.CreatedAt.UTC().Format(time.RFC3339),
+		book.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func ExportFileName(format string) string {
+	return fmt.Sprintf("books-%s.%s", time.Now().UTC().Format("20060102-150405"), format)
+}
+
+func exportObjectKey(jobID uuid.UUID, format string) string {
+	return fmt.Sprintf("exports/books/%s.%s", jobID.String(), format)
+}
+
+// exportStream is the body of a streamed export. The export only starts on the first read, once the response
+// headers were written, so the trailer it sets at the end can't race with them. Closing the stream, as the
+// server does when the client goes away, makes the export's next write fail.
+type exportStream struct {
+	export func(w io.Writer) error
+	done   func(err error)
+	reader *io.PipeReader
+}
+
+func (s *exportStream) Read(p []byte) (int, error) {
+	if s.reader == nil {
+		reader, writer := io.Pipe()
+		s.reader = reader
+		go func() {
+			w := bufio.NewWriter(writer)
+			err := s.export(w)
+			if flushErr := w.Flush(); err == nil {
+				err = flushErr
+			}
+			// the body ends normally even after a failure, the trailer reports it
+			s.done(err)
+			writer.Close()
+		}()
+	}
+	return s.reader.Read(p)
+}
+
+func (s *exportStream) Close() error {
+	if s.reader == nil {
+		return nil
+	}
+	return s.reader.Close()
+}
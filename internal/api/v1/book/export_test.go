@@ -0,0 +1,178 @@
+package book_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+	"github.com/jerpsp/go-fiber-beginner/mocks"
+	"github.com/jerpsp/go-fiber-beginner/pkg/export"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/valyala/fasthttp"
+)
+
+type ExportSuite struct {
+	suite.Suite
+	mockRepo    *mocks.BookRepository
+	mockStorage *mocks.S3Repository
+	service     book.BookService
+}
+
+func TestExportSuite(t *testing.T) {
+	suite.Run(t, new(ExportSuite))
+}
+
+func (s *ExportSuite) SetupTest() {
+	s.mockRepo = mocks.NewBookRepository(s.T())
+	s.mockStorage = mocks.NewS3Repository(s.T())
+	s.service = book.NewBookService(&config.Config{}, s.mockRepo, nil, s.mockStorage, nil)
+}
+
+func (s *ExportSuite) TestExportBooks1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Export Books CSV Across Batches", 34)
+
+	// Setup Mock
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.FixedZone("ICT", 7*60*60))
+	first := book.Book{ID: uuid.New(), Title: `Tom & "Jerry"`, Author: "Hanna, Barbera", ISBN: "9780306406157", CreatedAt: created, UpdatedAt: created}
+	second := book.Book{ID: uuid.New(), Title: "Dune", Author: "Frank Herbert", CreatedAt: created, UpdatedAt: created}
+	s.mockRepo.EXPECT().StreamBooks(mock.Anything, book.BookFilter{}, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, filter book.BookFilter, batchSize int, fn func(books []book.Book) error) error {
+			if err := fn([]book.Book{first}); err != nil {
+				return err
+			}
+			return fn([]book.Book{second})
+		})
+
+	// Call the service method
+	var buf bytes.Buffer
+	err := s.service.ExportBooks(context.Background(), &buf, book.BookFilter{}, export.FormatCSV)
+
+	// Assertions
+	s.NoError(err)
+	records, err := csv.NewReader(&buf).ReadAll()
+	s.NoError(err)
+	s.Equal([][]string{
+		{"id", "title", "author", "isbn", "created_at", "updated_at"},
+		{first.ID.String(), `Tom & "Jerry"`, "Hanna, Barbera", "9780306406157", "2025-01-01T20:04:05Z", "2025-01-01T20:04:05Z"},
+		{second.ID.String(), "Dune", "Frank Herbert", "", "2025-01-01T20:04:05Z", "2025-01-01T20:04:05Z"},
+	}, records)
+}
+
+func (s *ExportSuite) TestExportBooks2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Export Books Unsupported Format", 31)
+
+	// Call the service method
+	var buf bytes.Buffer
+	err := s.service.ExportBooks(context.Background(), &buf, book.BookFilter{}, "pdf")
+
+	// Assertions
+	s.ErrorIs(err, export.ErrUnsupportedFormat)
+	s.Zero(buf.Len())
+}
+
+// finishedJob returns a func that waits for the background run to save the finished export job
+func (s *ExportSuite) finishedJob() func() book.ExportJob {
+	finished := make(chan book.ExportJob, 1)
+	s.mockRepo.EXPECT().SaveExportJob(mock.Anything, mock.MatchedBy(func(job book.ExportJob) bool {
+		return job.Status == book.ExportJobPending
+	}), mock.Anything).Return(nil).Once()
+	s.mockRepo.EXPECT().SaveExportJob(mock.Anything, mock.MatchedBy(func(job book.ExportJob) bool {
+		return job.Status != book.ExportJobPending
+	}), mock.Anything).RunAndReturn(func(ctx context.Context, job book.ExportJob, ttl time.Duration) error {
+		finished <- job
+		return nil
+	}).Once()
+
+	return func() book.ExportJob {
+		select {
+		case job := <-finished:
+			return job
+		case <-time.After(5 * time.Second):
+			s.FailNow("export job did not finish")
+			return book.ExportJob{}
+		}
+	}
+}
+
+func (s *ExportSuite) TestStartExportJob1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Export Job Uploads And Links The File", 34)
+
+	// Setup Mock
+	finished := s.finishedJob()
+	s.mockRepo.EXPECT().StreamBooks(mock.Anything, book.BookFilter{}, mock.Anything, mock.Anything).Return(nil)
+	s.mockStorage.EXPECT().UploadPrivateFile(mock.Anything, mock.Anything, export.ContentType(export.FormatCSV)).Return(nil)
+	s.mockStorage.EXPECT().GetURLFile(mock.Anything).Return("https://example.com/books.csv", nil)
+
+	// Call the service method
+	app := fiber.New()
+	c := app.AcquireCtx(&fasthttp.RequestCtx{})
+	defer app.ReleaseCtx(c)
+	job, err := s.service.StartExportJob(c, book.BookFilter{}, export.FormatCSV)
+
+	// Assertions
+	s.NoError(err)
+	s.Equal(book.ExportJobPending, job.Status)
+	done := finished()
+	s.Equal(job.ID, done.ID)
+	s.Equal(book.ExportJobCompleted, done.Status)
+	s.Equal("https://example.com/books.csv", done.URL)
+}
+
+func (s *ExportSuite) TestStartExportJob2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Export Job Failing After Upload Deletes The File", 31)
+
+	// Setup Mock
+	finished := s.finishedJob()
+	s.mockRepo.EXPECT().StreamBooks(mock.Anything, book.BookFilter{}, mock.Anything, mock.Anything).Return(nil)
+	s.mockStorage.EXPECT().UploadPrivateFile(mock.Anything, mock.Anything, export.ContentType(export.FormatCSV)).Return(nil)
+	s.mockStorage.EXPECT().GetURLFile(mock.Anything).Return("", errors.New("presign failed"))
+	s.mockStorage.EXPECT().DeletePrivateFile(mock.Anything).Return(nil)
+
+	// Call the service method
+	app := fiber.New()
+	c := app.AcquireCtx(&fasthttp.RequestCtx{})
+	defer app.ReleaseCtx(c)
+	job, err := s.service.StartExportJob(c, book.BookFilter{}, export.FormatCSV)
+
+	// Assertions
+	s.NoError(err)
+	done := finished()
+	s.Equal(job.ID, done.ID)
+	s.Equal(book.ExportJobFailed, done.Status)
+	s.Equal("presign failed", done.Error)
+	s.Empty(done.URL)
+}
+
+func (s *ExportSuite) TestStartExportJob3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Export Job Failing Mid-Stream Uploads Nothing", 31)
+
+	// Setup Mock
+	finished := s.finishedJob()
+	s.mockRepo.EXPECT().StreamBooks(mock.Anything, book.BookFilter{}, mock.Anything, mock.Anything).Return(errors.New("connection lost"))
+
+	// Call the service method
+	app := fiber.New()
+	c := app.AcquireCtx(&fasthttp.RequestCtx{})
+	defer app.ReleaseCtx(c)
+	_, err := s.service.StartExportJob(c, book.BookFilter{}, export.FormatCSV)
+
+	// Assertions
+	s.NoError(err)
+	done := finished()
+	s.Equal(book.ExportJobFailed, done.Status)
+	s.Equal("connection lost", done.Error)
+}
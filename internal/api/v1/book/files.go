@@ -0,0 +1,90 @@
+package book
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidBookFile = errors.New("invalid book file")
+	ErrNotEntitled     = errors.New("no active loan of this book")
+)
+
+// bookFormats lists the e-book formats in the order a download without ?format= prefers them
+var bookFormats = []string{BookFormatEPUB, BookFormatPDF}
+
+var bookFormatContentTypes = map[string]string{
+	BookFormatEPUB: "application/epub+zip",
+	BookFormatPDF:  "application/pdf",
+}
+
+// epubSignature is what every EPUB starts with, the OCF spec requires an uncompressed "mimetype" first entry
+var epubSignature = []byte("PK\x03\x04")
+
+const epubMimetypeOffset = 30
+
+// detectBookFormat tells the format from the file content, the name and declared type of the upload are not trusted
+func detectBookFormat(head []byte) (string, bool) {
+	if bytes.HasPrefix(head, []byte("%PDF-")) {
+		return BookFormatPDF, true
+	}
+	mimetype := []byte("mimetype" + bookFormatContentTypes[BookFormatEPUB])
+	if bytes.HasPrefix(head, epubSignature) && len(head) >= epubMimetypeOffset+len(mimetype) &&
+		bytes.Equal(head[epubMimetypeOffset:epubMimetypeOffset+len(mimetype)], mimetype) {
+		return BookFormatEPUB, true
+	}
+	return "", false
+}
+
+// checkDeclaredContentType rejects uploads that claim to be something else than their content, a generic
+// or missing type is accepted
+func checkDeclaredContentType(declared, format string) error {
+	if declared == "" {
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(declared)
+	if err != nil {
+		return fmt.Errorf("%w: content type: %v", ErrInvalidBookFile, err)
+	}
+	if mediaType == "application/octet-stream" || mediaType == bookFormatContentTypes[format] {
+		return nil
+	}
+	return fmt.Errorf("%w: declared %s but the file is %s", ErrInvalidBookFile, mediaType, format)
+}
+
+// fileChecksum returns the hex SHA-256 of the reader's content
+func fileChecksum(r io.Reader) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func newBookFileKey(bookID uuid.UUID, format string) string {
+	return fmt.Sprintf("books/%s/files/%s.%s", bookID.String(), uuid.New().String(), format)
+}
+
+// downloadFileName names the saved file after the book title, characters that trip up file systems are dropped
+func downloadFileName(title, format string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r < 0x20, r == 0x7f, strings.ContainsRune(`/\:*?"<>|`, r):
+			return -1
+		}
+		return r
+	}, title)
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "book"
+	}
+	return name + "." + format
+}
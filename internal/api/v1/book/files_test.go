@@ -0,0 +1,174 @@
+package book_test
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+	"github.com/jerpsp/go-fiber-beginner/middleware"
+	"github.com/jerpsp/go-fiber-beginner/mocks"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/valyala/fasthttp"
+	"gorm.io/gorm"
+)
+
+var (
+	pdfContent  = []byte("%PDF-1.7\n1 0 obj\n<< /Type /Catalog >>\nendobj\n")
+	epubContent = append([]byte("PK\x03\x04"+string(make([]byte, 26))+"mimetypeapplication/epub+zip"), "META-INF/container.xml"...)
+	zipContent  = append([]byte("PK\x03\x04"+string(make([]byte, 26))), "word/document.xml"...)
+)
+
+type FilesSuite struct {
+	suite.Suite
+	mockRepo    *mocks.BookRepository
+	mockStorage *mocks.S3Repository
+	service     book.BookService
+	app         *fiber.App
+}
+
+func TestFilesSuite(t *testing.T) {
+	suite.Run(t, new(FilesSuite))
+}
+
+func (s *FilesSuite) SetupTest() {
+	s.mockRepo = mocks.NewBookRepository(s.T())
+	s.mockStorage = mocks.NewS3Repository(s.T())
+	cfg := &config.Config{BookFiles: &config.BookFiles{MaxSize: 1024 * 1024, DownloadTTL: time.Minute}}
+	s.service = book.NewBookService(cfg, s.mockRepo, mocks.NewAuthorRepository(s.T()), s.mockStorage, nil)
+	s.app = fiber.New()
+}
+
+// newUpload builds the file header of a multipart upload, an empty content type leaves the header out
+func newUpload(fileName, contentType string, content []byte) *multipart.FileHeader {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename=%q`, fileName))
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	part, _ := writer.CreatePart(header)
+	_, _ = part.Write(content)
+	_ = writer.Close()
+
+	form, _ := multipart.NewReader(&buf, writer.Boundary()).ReadForm(1024 * 1024)
+	return form.File["file"][0]
+}
+
+func (s *FilesSuite) TestUploadBookFile1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Upload Book File Detects Format From Content", 34)
+
+	// Setup Mock
+	bookID := uuid.New()
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, bookID).Return(book.Book{ID: bookID}, nil)
+	s.mockStorage.EXPECT().UploadPrivateFile(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	s.mockRepo.EXPECT().FindBookFile(mock.Anything, bookID, mock.Anything).Return(book.BookFile{}, gorm.ErrRecordNotFound)
+	s.mockRepo.EXPECT().SaveBookFile(mock.Anything, mock.Anything).RunAndReturn(func(c *fiber.Ctx, file book.BookFile) (book.BookFile, error) {
+		return file, nil
+	})
+
+	tests := []struct {
+		fileName    string
+		contentType string
+		content     []byte
+		format      string
+		mediaType   string
+	}{
+		{"dune.pdf", "application/pdf", pdfContent, book.BookFormatPDF, "application/pdf"},
+		// the name and a generic or missing type are not trusted either way
+		{"dune.epub", "", pdfContent, book.BookFormatPDF, "application/pdf"},
+		{"dune.bin", "application/octet-stream", epubContent, book.BookFormatEPUB, "application/epub+zip"},
+		{"dune.epub", "application/epub+zip; charset=binary", epubContent, book.BookFormatEPUB, "application/epub+zip"},
+	}
+
+	for _, test := range tests {
+		// Call the service method
+		c := s.app.AcquireCtx(&fasthttp.RequestCtx{})
+		result, err := s.service.UploadBookFile(c, bookID, newUpload(test.fileName, test.contentType, test.content), "")
+		s.app.ReleaseCtx(c)
+
+		// Assertions
+		s.NoError(err, test.fileName)
+		s.Equal(test.format, result.Format, test.fileName)
+		s.Equal(test.mediaType, result.ContentType, test.fileName)
+	}
+}
+
+func (s *FilesSuite) TestUploadBookFile2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Upload Book File Rejects Other Content", 31)
+
+	// Setup Mock
+	bookID := uuid.New()
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, bookID).Return(book.Book{ID: bookID}, nil)
+
+	tests := []struct {
+		fileName    string
+		contentType string
+		content     []byte
+	}{
+		{"notes.pdf", "application/pdf", []byte("just some notes")},
+		// a plain zip starts like an EPUB but lacks the mimetype entry
+		{"dune.epub", "application/epub+zip", zipContent},
+		{"dune.pdf", "application/pdf", pdfContent[:3]},
+		// declared types that contradict the content
+		{"dune.epub", "application/epub+zip", pdfContent},
+		{"dune.pdf", "application/pdf", epubContent},
+		{"dune.pdf", "text/html", pdfContent},
+		{"dune.pdf", "application/pdf;;", pdfContent},
+	}
+
+	for _, test := range tests {
+		// Call the service method
+		_, err := s.service.UploadBookFile(&fiber.Ctx{}, bookID, newUpload(test.fileName, test.contentType, test.content), "")
+
+		// Assertions
+		s.ErrorIs(err, book.ErrInvalidBookFile, "%s as %s", test.fileName, test.contentType)
+	}
+}
+
+func (s *FilesSuite) TestGetDownloadLink1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Download File Named After The Title", 34)
+
+	tests := []struct {
+		title    string
+		format   string
+		fileName string
+	}{
+		{"Dune", book.BookFormatPDF, "Dune.pdf"},
+		{"Dune: Part One", book.BookFormatEPUB, "Dune Part One.epub"},
+		{`Who/What? "Why" <When> *Where* \ | How`, book.BookFormatPDF, "WhoWhat Why When Where   How.pdf"},
+		{"Line\nBreak\tand\x7fDelete", book.BookFormatPDF, "LineBreakandDelete.pdf"},
+		{"Ünïcødé 本", book.BookFormatEPUB, "Ünïcødé 本.epub"},
+		{"  ", book.BookFormatPDF, "book.pdf"},
+		{`?*"`, book.BookFormatEPUB, "book.epub"},
+	}
+
+	for _, test := range tests {
+		// Setup Mock
+		bookID := uuid.New()
+		file := book.BookFile{ID: uuid.New(), BookID: bookID, Format: test.format, ObjectKey: "books/" + bookID.String()}
+		s.mockRepo.EXPECT().FindBookByID(mock.Anything, bookID).Return(book.Book{ID: bookID, Title: test.title}, nil)
+		s.mockRepo.EXPECT().FindBookFile(mock.Anything, bookID, test.format).Return(file, nil)
+		s.mockStorage.EXPECT().GetDownloadURLFile(file.ObjectKey, test.fileName, time.Minute).Return("https://files.example/dune", nil)
+		s.mockRepo.EXPECT().CreateBookDownload(mock.Anything, mock.Anything).Return(nil)
+
+		// Call the service method
+		link, err := s.service.GetDownloadLink(&fiber.Ctx{}, bookID, uuid.New(), string(middleware.RoleAdmin), test.format)
+
+		// Assertions
+		s.NoError(err, test.title)
+		s.Equal(test.format, link.Format)
+	}
+}
@@ -0,0 +1,67 @@
+package book
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/series"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/work"
+	"gorm.io/gorm"
+)
+
+const (
+	IncludeEditions = "editions"
+	IncludeSeries   = "series"
+)
+
+var (
+	ErrInvalidWork    = errors.New("invalid book work")
+	ErrInvalidSeries  = errors.New("invalid book series")
+	ErrInvalidInclude = errors.New("invalid include")
+)
+
+// BookIncludes selects what GET /books/:id embeds next to the book
+type BookIncludes struct {
+	Editions bool
+	Series   bool
+}
+
+func (i BookIncludes) Any() bool {
+	return i.Editions || i.Series
+}
+
+// ParseBookIncludes reads a comma separated include parameter such as "editions,series"
+func ParseBookIncludes(value string) (BookIncludes, error) {
+	var includes BookIncludes
+	for _, name := range strings.Split(value, ",") {
+		switch strings.TrimSpace(name) {
+		case "":
+		case IncludeEditions:
+			includes.Editions = true
+		case IncludeSeries:
+			includes.Series = true
+		default:
+			return BookIncludes{}, fmt.Errorf("%w: %s, expected %s or %s", ErrInvalidInclude, name, IncludeEditions, IncludeSeries)
+		}
+	}
+	return includes, nil
+}
+
+// findWork fails with ErrInvalidWork for an unknown work so the caller can tell it from an unknown book
+func findWork(db *gorm.DB, workID uuid.UUID) error {
+	err := db.Select("id").Where("id = ?", workID).First(&work.Work{}).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: work not found", ErrInvalidWork)
+	}
+	return err
+}
+
+func findSeries(db *gorm.DB, seriesID uuid.UUID) error {
+	err := db.Select("id").Where("id = ?", seriesID).First(&series.Series{}).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: series not found", ErrInvalidSeries)
+	}
+	return err
+}
@@ -1,26 +1,61 @@
 package book
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
 	"github.com/google/uuid"
 	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/pkg/activity"
+	"github.com/jerpsp/go-fiber-beginner/pkg/catalogue"
+	"github.com/jerpsp/go-fiber-beginner/pkg/export"
+	"github.com/jerpsp/go-fiber-beginner/pkg/feed"
+	"github.com/jerpsp/go-fiber-beginner/pkg/patch"
+	"gorm.io/gorm"
 )
 
 type BookHandler struct {
-	config  *config.Config
-	service BookService
+	config   *config.Config
+	service  BookService
+	activity activity.Counter
+	feed     feed.Feed
 }
 
-func NewBookHandler(config *config.Config, service BookService) *BookHandler {
-	return &BookHandler{config: config, service: service}
+func NewBookHandler(config *config.Config, service BookService, activityCounter activity.Counter, bookFeed feed.Feed) *BookHandler {
+	return &BookHandler{config: config, service: service, activity: activityCounter, feed: bookFeed}
 }
 
 // Handler methods
 func (h *BookHandler) GetBooks(c *fiber.Ctx) error {
-	books, err := h.service.GetBooks(c)
+	var filter BookFilter
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid filter parameters"})
+	}
+	chain, err := localeChain(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	books, err := h.service.GetBooks(c, filter)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
 	}
+	books = LocalizeBooks(books, chain)
+
+	if c.QueryBool("facets", false) {
+		facets, err := h.service.GetBookFacets(c, filter)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.JSON(fiber.Map{"books": books, "facets": facets})
+	}
 	return c.JSON(fiber.Map{"books": books})
 }
 
@@ -30,20 +65,50 @@ func (h *BookHandler) GetBook(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
 	}
 
+	includes, err := ParseBookIncludes(c.Query("include"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+	}
+	chain, err := localeChain(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+	}
+
 	book, err := h.service.GetBook(c, bookID)
 	if err != nil {
+		// a book merged into another one redirects to it
+		if movedTo, redirectErr := h.service.ResolveBookRedirect(c, bookID); redirectErr == nil {
+			return c.Redirect(strings.Replace(c.OriginalURL(), bookID.String(), movedTo.String(), 1), fiber.StatusMovedPermanently)
+		}
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
 	}
+
+	h.activity.Record(c.UserContext(), activity.EventView, book.ID)
+
+	// embedded editions and volumes change without the book's version, so they get no ETag
+	if includes.Any() {
+		if book, err = h.service.IncludeRelated(c, book, includes); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"book": localize(c, book, chain)})
+	}
+
+	// the body depends on Accept-Language, which localeChain lists in Vary, and the ETag names the locales used
+	book = localize(c, book, chain)
+	c.Set(fiber.HeaderETag, book.ETag())
+	if ifNoneMatch := c.Get(fiber.HeaderIfNoneMatch); ifNoneMatch != "" && book.MatchesIfNoneMatch(ifNoneMatch) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"book": book})
 }
 
 func (h *BookHandler) CreateBook(c *fiber.Ctx) error {
-	var newBook Book
-	if err := c.BodyParser(&newBook); err != nil {
+	var bookParams BookRequest
+	if err := c.BodyParser(&bookParams); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid input"})
 	}
 
-	book, err := h.service.CreateBook(c, newBook)
+	book, err := h.service.CreateBook(c, bookParams.Book())
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
 	}
@@ -56,28 +121,772 @@ func (h *BookHandler) UpdateBook(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
 	}
 
-	var updatedBook BookRequest
-	if err := c.BodyParser(&updatedBook); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid input"})
+	book, err := h.service.UpdateBook(c, bookID, c.Get(fiber.HeaderContentType), c.Body(), c.Get(fiber.HeaderIfMatch))
+	if err != nil {
+		switch {
+		case errors.Is(err, patch.ErrUnsupportedMediaType):
+			return c.Status(fiber.StatusUnsupportedMediaType).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, patch.ErrInvalidPatch), errors.Is(err, ErrInvalidBook):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, patch.ErrTestFailed):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, ErrPreconditionFailed):
+			return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, ErrVersionConflict):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	c.Set(fiber.HeaderETag, book.ETag())
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"book": book})
+}
+
+func (h *BookHandler) DeleteBook(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	if err := h.service.DeleteBook(c, bookID, c.Get(fiber.HeaderIfMatch)); err != nil {
+		switch {
+		case errors.Is(err, ErrPreconditionFailed):
+			return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, ErrBookHasCopies):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *BookHandler) GetBookHistory(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	filter := BookHistoryFilter{Page: 1, Limit: 10}
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid query parameters"})
+	}
+
+	revisions, total, err := h.service.GetBookHistory(c, bookID, filter)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 || filter.Limit > 100 {
+		filter.Limit = 10
+	}
+	totalPages := int(total) / filter.Limit
+	if int(total)%filter.Limit > 0 {
+		totalPages++
+	}
+
+	return c.Status(fiber.StatusOK).JSON(BookHistoryResponse{
+		History:    revisions,
+		Total:      total,
+		Page:       filter.Page,
+		PerPage:    filter.Limit,
+		TotalPages: totalPages,
+	})
+}
+
+func (h *BookHandler) GetBookRevision(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+	version, err := c.ParamsInt("version")
+	if err != nil || version < 1 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid version"})
+	}
+
+	revision, err := h.service.GetBookRevision(c, bookID, version)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"revision": revision})
+}
+
+func (h *BookHandler) RevertBook(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+	version, err := c.ParamsInt("version")
+	if err != nil || version < 1 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid version"})
 	}
 
-	book, err := h.service.UpdateBook(c, bookID, updatedBook)
+	book, err := h.service.RevertBook(c, bookID, version, c.Get(fiber.HeaderIfMatch))
 	if err != nil {
+		switch {
+		case errors.Is(err, ErrPreconditionFailed):
+			return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, ErrVersionConflict):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, ErrBookDeleted):
+			return c.Status(fiber.StatusGone).JSON(fiber.Map{"message": err.Error()})
+		}
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
 	}
 
+	c.Set(fiber.HeaderETag, book.ETag())
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"book": book})
 }
 
-func (h *BookHandler) DeleteBook(c *fiber.Ctx) error {
+func (h *BookHandler) SetBookAuthors(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	var request BookAuthorsRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid input"})
+	}
+
+	book, err := h.service.SetBookAuthors(c, bookID, request)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidAuthors):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, ErrVersionConflict):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	c.Set(fiber.HeaderETag, book.ETag())
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"book": book})
+}
+
+func (h *BookHandler) SetBookTags(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	var request BookTagsRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid input"})
+	}
+
+	book, err := h.service.SetBookTags(c, bookID, request)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidTags):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, ErrVersionConflict):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	c.Set(fiber.HeaderETag, book.ETag())
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"book": book})
+}
+
+func (h *BookHandler) SetBookCategories(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	var request BookCategoriesRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid input"})
+	}
+
+	book, err := h.service.SetBookCategories(c, bookID, request)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidCategories):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, ErrVersionConflict):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	c.Set(fiber.HeaderETag, book.ETag())
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"book": book})
+}
+
+func (h *BookHandler) SetBookWork(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	var request BookWorkRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid input"})
+	}
+
+	book, err := h.service.SetBookWork(c, bookID, request, c.Get(fiber.HeaderIfMatch))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidWork):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, ErrPreconditionFailed):
+			return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, ErrVersionConflict):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	c.Set(fiber.HeaderETag, book.ETag())
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"book": book})
+}
+
+func (h *BookHandler) SetBookSeries(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	var request BookSeriesRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid input"})
+	}
+
+	book, err := h.service.SetBookSeries(c, bookID, request, c.Get(fiber.HeaderIfMatch))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidSeries):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, ErrPreconditionFailed):
+			return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, ErrVersionConflict):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	c.Set(fiber.HeaderETag, book.ETag())
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"book": book})
+}
+
+func (h *BookHandler) GetWorkBooks(c *fiber.Ctx) error {
+	workID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid work ID"})
+	}
+
+	chain, err := localeChain(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	books, err := h.service.GetWorkBooks(c, workID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"books": LocalizeBooks(books, chain)})
+}
+
+func (h *BookHandler) GetSeriesBooks(c *fiber.Ctx) error {
+	seriesID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid series ID"})
+	}
+
+	chain, err := localeChain(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	books, err := h.service.GetSeriesBooks(c, seriesID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"books": LocalizeBooks(books, chain)})
+}
+
+func (h *BookHandler) GetBookFiles(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	files, err := h.service.GetBookFiles(c, bookID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"files": files})
+}
+
+func (h *BookHandler) UploadBookFile(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "EPUB or PDF file is required"})
+	}
+
+	bookFile, err := h.service.UploadBookFile(c, bookID, file, c.FormValue("checksum"))
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, ErrInvalidBookFile):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"file": bookFile})
+}
+
+func (h *BookHandler) DeleteBookFile(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	if err := h.service.DeleteBookFile(c, bookID, c.Params("format")); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *BookHandler) DownloadBook(c *fiber.Ctx) error {
 	bookID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
 	}
 
-	if err := h.service.DeleteBook(c, bookID); err != nil {
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	role, _ := c.Locals("role").(string)
+	link, err := h.service.GetDownloadLink(c, bookID, userID, role, c.Query("format"))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidBookFile):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, ErrNotEntitled):
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	// the link is personal and short-lived, keep it out of shared caches
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"download": link})
+}
+
+func (h *BookHandler) GetDownloadStats(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	filter := DownloadStatsFilter{Page: 1, Limit: 10}
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid query parameters"})
+	}
+
+	stats, err := h.service.GetDownloadStats(c, bookID, filter)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	stats.TotalPages = int(stats.Total) / stats.PerPage
+	if int(stats.Total)%stats.PerPage > 0 {
+		stats.TotalPages++
+	}
+	return c.Status(fiber.StatusOK).JSON(stats)
+}
+
+func (h *BookHandler) GetAuthorBooks(c *fiber.Ctx) error {
+	authorID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid author ID"})
+	}
+
+	chain, err := localeChain(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	books, err := h.service.GetAuthorBooks(c, authorID)
+	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
 	}
+	return c.JSON(fiber.Map{"books": LocalizeBooks(books, chain)})
+}
+
+func (h *BookHandler) UploadCover(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	file, err := c.FormFile("cover")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Cover image is required"})
+	}
+
+	book, err := h.service.UploadCover(c, bookID, file)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, ErrInvalidCover):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, ErrVersionConflict):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"book": book})
+}
+
+func (h *BookHandler) LookupBook(c *fiber.Ctx) error {
+	isbn := c.Query("isbn")
+	if isbn == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "ISBN is required"})
+	}
+
+	lookup, err := h.service.LookupISBN(c, isbn, c.QueryBool("create", false))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidBook):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, catalogue.ErrNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, ErrISBNExists):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, catalogue.ErrUnavailable):
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	if lookup.Book != nil {
+		return c.Status(fiber.StatusCreated).JSON(lookup)
+	}
+	return c.Status(fiber.StatusOK).JSON(lookup)
+}
+
+func (h *BookHandler) BatchBooks(c *fiber.Ctx) error {
+	var request BatchRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid input"})
+	}
+
+	role, _ := c.Locals("role").(string)
+	response, err := h.service.BatchBooks(c, request, role)
+	if err != nil {
+		if errors.Is(err, ErrInvalidBatch) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
 
+	// 207 tells clients to look at the item results
+	if response.Failed > 0 {
+		return c.Status(fiber.StatusMultiStatus).JSON(response)
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+func (h *BookHandler) GetDuplicates(c *fiber.Ctx) error {
+	filter := DuplicateFilter{Page: 1, Limit: 10, MinScore: defaultDuplicateScore}
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid query parameters"})
+	}
+
+	duplicates, total, err := h.service.GetDuplicates(c, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 || filter.Limit > 100 {
+		filter.Limit = 10
+	}
+	totalPages := int(total) / filter.Limit
+	if int(total)%filter.Limit > 0 {
+		totalPages++
+	}
+
+	return c.Status(fiber.StatusOK).JSON(DuplicatesResponse{
+		Duplicates: duplicates,
+		Total:      total,
+		Page:       filter.Page,
+		PerPage:    filter.Limit,
+		TotalPages: totalPages,
+	})
+}
+
+func (h *BookHandler) MergeBook(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	var request MergeRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid input"})
+	}
+
+	book, err := h.service.MergeBook(c, bookID, request, c.Get(fiber.HeaderIfMatch))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidMerge):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, ErrPreconditionFailed), errors.Is(err, ErrVersionConflict):
+			return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	c.Set(fiber.HeaderETag, book.ETag())
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"book": book})
+}
+
+func (h *BookHandler) ImportBooks(c *fiber.Ctx) error {
+	format := strings.ToLower(c.Query("format"))
+	dryRun := c.QueryBool("dry_run", false)
+
+	var body io.Reader
+	if file, err := c.FormFile("file"); err == nil {
+		src, err := file.Open()
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid import file"})
+		}
+		defer src.Close()
+		body = src
+
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(path.Ext(file.Filename)), ".")
+		}
+	} else {
+		body = bytes.NewReader(c.Body())
+	}
+
+	if format == "" {
+		switch {
+		case strings.Contains(c.Get(fiber.HeaderContentType), "csv"):
+			format = ImportFormatCSV
+		case strings.Contains(c.Get(fiber.HeaderContentType), "ndjson"):
+			format = ImportFormatNDJSON
+		}
+	}
+
+	report, err := h.service.ImportBooks(c, body, format, dryRun)
+	if err != nil {
+		if errors.Is(err, ErrInvalidImport) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"report": report})
+}
+
+func (h *BookHandler) ExportBooks(c *fiber.Ctx) error {
+	var filter BookFilter
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid filter parameters"})
+	}
+
+	format := strings.ToLower(c.Query("format", export.FormatCSV))
+	if err := export.ValidateFormat(format); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	if c.QueryBool("async", false) {
+		job, err := h.service.StartExportJob(c, filter, format)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusAccepted).JSON(fiber.Map{"job": job})
+	}
+
+	c.Set(fiber.HeaderContentType, export.ContentType(format))
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, ExportFileName(format)))
+
+	if err := c.Response().Header.SetTrailer(ExportStatusTrailer); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	// rows are written while the response is sent, the fiber context is no longer valid at that point
+	ctx := c.UserContext()
+	header := &c.Response().Header
+	c.Context().SetBodyStream(&exportStream{
+		export: func(w io.Writer) error {
+			return h.service.ExportBooks(ctx, w, filter, format)
+		},
+		done: func(err error) {
+			if err != nil {
+				log.Errorf("book export failed: %v", err)
+				header.Set(ExportStatusTrailer, "failed")
+				return
+			}
+			header.Set(ExportStatusTrailer, "complete")
+		},
+	}, -1)
+
+	return nil
+}
+
+func (h *BookHandler) GetExportJob(c *fiber.Ctx) error {
+	jobID, err := uuid.Parse(c.Params("jobID"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid job ID"})
+	}
+
+	job, err := h.service.GetExportJob(c, jobID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": "Export job not found"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"job": job})
+}
+
+// StreamBookEvents streams book changes as server-sent events. A client reconnecting with Last-Event-ID first gets
+// what it missed from the replay buffer, or a reset event when that is gone and it has to reload the books.
+func (h *BookHandler) StreamBookEvents(c *fiber.Ctx) error {
+	// subscribed before the buffer is read so nothing published in between is lost
+	events, cancel := h.feed.Subscribe()
+
+	var replay []feed.Event
+	reset := false
+	if lastID := c.Get("Last-Event-ID"); lastID != "" {
+		buffered, complete, err := h.feed.Since(c.UserContext(), lastID)
+		if err != nil {
+			cancel()
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+		}
+		replay, reset = buffered, !complete
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	// nginx would otherwise buffer the stream
+	c.Set("X-Accel-Buffering", "no")
+
+	// the stream is written after the handler returned, only the connection may be used from here on
+	conn := c.Context().Conn()
+	heartbeat := h.config.Feed.Heartbeat
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+		streamBookEvents(w, conn, events, replay, reset, heartbeat)
+	})
+	return nil
+}
+
+func (h *BookHandler) GetBookTranslations(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	translations, err := h.service.GetBookTranslations(c, bookID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"translations": translations})
+}
+
+func (h *BookHandler) SetBookTranslation(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	var request BookTranslationRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid input"})
+	}
+
+	translation, err := h.service.SetBookTranslation(c, bookID, c.Params("locale"), request)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidLocale), errors.Is(err, ErrInvalidTranslation):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"translation": translation})
+}
+
+func (h *BookHandler) DeleteBookTranslation(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	if err := h.service.DeleteBookTranslation(c, bookID, c.Params("locale")); err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidLocale):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
 	return c.SendStatus(fiber.StatusNoContent)
 }
+
+// localeChain reads the locales the reader asked for, the response differs by Accept-Language from here on
+func localeChain(c *fiber.Ctx) ([]string, error) {
+	c.Vary(fiber.HeaderAcceptLanguage)
+	return LocaleChain(c.Query("lang"), c.Get(fiber.HeaderAcceptLanguage))
+}
+
+// localize translates the book for the reader and announces the language of the text
+func localize(c *fiber.Ctx, book Book, chain []string) Book {
+	book = Localize(book, chain)
+	if book.Locale != "" {
+		c.Set(fiber.HeaderContentLanguage, book.Locale)
+	}
+	return book
+}
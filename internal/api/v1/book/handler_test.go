@@ -0,0 +1,574 @@
+package book_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+	"github.com/jerpsp/go-fiber-beginner/middleware"
+	"github.com/jerpsp/go-fiber-beginner/mocks"
+	"github.com/jerpsp/go-fiber-beginner/pkg/activity"
+	"github.com/jerpsp/go-fiber-beginner/pkg/patch"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type BookHandlerSuite struct {
+	suite.Suite
+	mockBookSvc *mocks.BookService
+	bookHandler *book.BookHandler
+	router      *fiber.App
+}
+
+func TestBookHandlerSuite(t *testing.T) {
+	suite.Run(t, new(BookHandlerSuite))
+}
+
+func (s *BookHandlerSuite) SetupTest() {
+	// Setup Test Engine
+	s.mockBookSvc = mocks.NewBookService(s.T())
+	s.bookHandler = book.NewBookHandler(&config.Config{}, s.mockBookSvc, activity.New(&activity.ActivityConfig{}, nil), nil)
+
+	// Setup Router
+	s.router = fiber.New()
+
+	// Book
+	bookGroup := s.router.Group("api/v1/books", signedIn)
+	{
+		bookGroup.Post("", s.bookHandler.CreateBook)
+		bookGroup.Get("/export", s.bookHandler.ExportBooks)
+		bookGroup.Get("/:id", s.bookHandler.GetBook)
+		bookGroup.Patch("/:id", s.bookHandler.UpdateBook)
+		bookGroup.Delete("/:id", s.bookHandler.DeleteBook)
+		bookGroup.Get("/:id/history", middleware.ModeratorOrAdmin(), s.bookHandler.GetBookHistory)
+		bookGroup.Post("/:id/revert/:version", middleware.ModeratorOrAdmin(), s.bookHandler.RevertBook)
+		bookGroup.Post("/:id/merge", middleware.AdminOnly(), s.bookHandler.MergeBook)
+		bookGroup.Put("/:id/tags", s.bookHandler.SetBookTags)
+		bookGroup.Put("/:id/work", s.bookHandler.SetBookWork)
+		bookGroup.Put("/:id/series", s.bookHandler.SetBookSeries)
+	}
+}
+
+// signedIn stands in for JWTMiddleware and signs the request in with the role in the X-User-Role header
+func signedIn(c *fiber.Ctx) error {
+	if role := c.Get("X-User-Role"); role != "" {
+		c.Locals("role", role)
+	}
+	return c.Next()
+}
+
+func (s *BookHandlerSuite) TestGetBook1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Get Book Sends ETag", 34)
+
+	// Setup Mock
+	serviceResponse := book.Book{ID: uuid.New(), Title: "Dune", Version: 2}
+	s.mockBookSvc.EXPECT().GetBook(mock.Anything, serviceResponse.ID).Return(serviceResponse, nil)
+
+	// Setup Request
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/books/%s", serviceResponse.ID), nil)
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal(`"2"`, resp.Header.Get(fiber.HeaderETag))
+}
+
+func (s *BookHandlerSuite) TestGetBook2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Get Book Not Modified", 34)
+
+	// Setup Mock
+	serviceResponse := book.Book{ID: uuid.New(), Title: "Dune", Version: 2}
+	s.mockBookSvc.EXPECT().GetBook(mock.Anything, serviceResponse.ID).Return(serviceResponse, nil)
+
+	// Setup Request
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/books/%s", serviceResponse.ID), nil)
+	req.Header.Set(fiber.HeaderIfNoneMatch, `W/"2"`)
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	actualResp, _ := io.ReadAll(resp.Body)
+
+	s.Equal(http.StatusNotModified, resp.StatusCode)
+	s.Empty(actualResp)
+}
+
+func (s *BookHandlerSuite) TestGetBook3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Get Localized Book Varies On Accept-Language", 34)
+
+	// Setup Mock
+	serviceResponse := book.Book{ID: uuid.New(), Title: "Dune", Language: "en", Version: 2, Translations: []book.BookTranslation{{Locale: "fr", Title: "Dune (fr)"}}}
+	s.mockBookSvc.EXPECT().GetBook(mock.Anything, serviceResponse.ID).Return(serviceResponse, nil)
+
+	tests := []struct {
+		acceptLanguage string
+		ifNoneMatch    string
+		status         int
+		etag           string
+		contentLang    string
+	}{
+		{"fr", "", http.StatusOK, `"2-fr"`, "fr"},
+		{"fr", `"2-fr"`, http.StatusNotModified, `"2-fr"`, "fr"},
+		// the tag of the original text does not validate the translation, nor the other way round
+		{"fr", `"2"`, http.StatusOK, `"2-fr"`, "fr"},
+		{"en", `"2-fr"`, http.StatusOK, `"2"`, "en"},
+		{"en", `"2"`, http.StatusNotModified, `"2"`, "en"},
+	}
+
+	for _, test := range tests {
+		// Setup Request
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/books/%s", serviceResponse.ID), nil)
+		req.Header.Set(fiber.HeaderAcceptLanguage, test.acceptLanguage)
+		if test.ifNoneMatch != "" {
+			req.Header.Set(fiber.HeaderIfNoneMatch, test.ifNoneMatch)
+		}
+
+		// Run Test Request
+		resp, _ := s.router.Test(req)
+
+		s.Equal(test.status, resp.StatusCode, "%s %s", test.acceptLanguage, test.ifNoneMatch)
+		s.Equal(test.etag, resp.Header.Get(fiber.HeaderETag))
+		s.Equal(test.contentLang, resp.Header.Get(fiber.HeaderContentLanguage))
+		s.Contains(resp.Header.Get(fiber.HeaderVary), fiber.HeaderAcceptLanguage)
+	}
+}
+
+func (s *BookHandlerSuite) TestUpdateBook1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Update Book Precondition Failed", 31)
+
+	// Setup Mock
+	bookID := uuid.New()
+	s.mockBookSvc.EXPECT().UpdateBook(mock.Anything, bookID, patch.ContentTypeMergePatch, mock.Anything, `"1"`).Return(book.Book{}, book.ErrPreconditionFailed)
+
+	// Setup Request
+	req, _ := http.NewRequest("PATCH", fmt.Sprintf("/api/v1/books/%s", bookID), bytes.NewBufferString(`{"title":"Dune"}`))
+	req.Header.Set("Content-Type", patch.ContentTypeMergePatch)
+	req.Header.Set(fiber.HeaderIfMatch, `"1"`)
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	expectedResp, _ := json.Marshal(fiber.Map{"message": book.ErrPreconditionFailed.Error()})
+	actualResp, _ := io.ReadAll(resp.Body)
+
+	s.Equal(http.StatusPreconditionFailed, resp.StatusCode)
+	s.Equal(string(expectedResp), string(actualResp))
+}
+
+func (s *BookHandlerSuite) TestUpdateBook2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Update Book Version Conflict", 31)
+
+	// Setup Mock
+	bookID := uuid.New()
+	s.mockBookSvc.EXPECT().UpdateBook(mock.Anything, bookID, patch.ContentTypeMergePatch, mock.Anything, "").Return(book.Book{}, book.ErrVersionConflict)
+
+	// Setup Request
+	req, _ := http.NewRequest("PATCH", fmt.Sprintf("/api/v1/books/%s", bookID), bytes.NewBufferString(`{"title":"Dune"}`))
+	req.Header.Set("Content-Type", patch.ContentTypeMergePatch)
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	expectedResp, _ := json.Marshal(fiber.Map{"message": book.ErrVersionConflict.Error()})
+	actualResp, _ := io.ReadAll(resp.Body)
+
+	s.Equal(http.StatusConflict, resp.StatusCode)
+	s.Equal(string(expectedResp), string(actualResp))
+}
+
+func (s *BookHandlerSuite) TestUpdateBook3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Update Book Sends New ETag", 34)
+
+	// Setup Mock
+	bookID := uuid.New()
+	s.mockBookSvc.EXPECT().UpdateBook(mock.Anything, bookID, patch.ContentTypeMergePatch, mock.Anything, `"1"`).Return(book.Book{ID: bookID, Version: 2}, nil)
+
+	// Setup Request
+	req, _ := http.NewRequest("PATCH", fmt.Sprintf("/api/v1/books/%s", bookID), bytes.NewBufferString(`{"title":"Dune"}`))
+	req.Header.Set("Content-Type", patch.ContentTypeMergePatch)
+	req.Header.Set(fiber.HeaderIfMatch, `"1"`)
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal(`"2"`, resp.Header.Get(fiber.HeaderETag))
+}
+
+func (s *BookHandlerSuite) TestDeleteBook1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Delete Book Precondition Failed", 31)
+
+	// Setup Mock
+	bookID := uuid.New()
+	s.mockBookSvc.EXPECT().DeleteBook(mock.Anything, bookID, `"1"`).Return(book.ErrPreconditionFailed)
+
+	// Setup Request
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/api/v1/books/%s", bookID), nil)
+	req.Header.Set(fiber.HeaderIfMatch, `"1"`)
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	s.Equal(http.StatusPreconditionFailed, resp.StatusCode)
+}
+
+func (s *BookHandlerSuite) TestMergeBook1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Merge Book Sends ETag", 34)
+
+	// Setup Mock
+	canonicalID, duplicateID := uuid.New(), uuid.New()
+	serviceResponse := book.Book{ID: canonicalID, Title: "Dune", Version: 5}
+	s.mockBookSvc.EXPECT().MergeBook(mock.Anything, canonicalID, book.MergeRequest{DuplicateID: duplicateID}, `"4"`).Return(serviceResponse, nil)
+
+	// Setup Request
+	reqBody, _ := json.Marshal(book.MergeRequest{DuplicateID: duplicateID})
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/api/v1/books/%s/merge", canonicalID), bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(fiber.HeaderIfMatch, `"4"`)
+	req.Header.Set("X-User-Role", "admin")
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal(`"5"`, resp.Header.Get(fiber.HeaderETag))
+}
+
+func (s *BookHandlerSuite) TestMergeBook2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Merge Book Precondition Failed", 31)
+
+	// Setup Mock
+	canonicalID, duplicateID := uuid.New(), uuid.New()
+	s.mockBookSvc.EXPECT().MergeBook(mock.Anything, canonicalID, book.MergeRequest{DuplicateID: duplicateID}, `"3"`).Return(book.Book{}, book.ErrPreconditionFailed)
+
+	// Setup Request
+	reqBody, _ := json.Marshal(book.MergeRequest{DuplicateID: duplicateID})
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/api/v1/books/%s/merge", canonicalID), bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(fiber.HeaderIfMatch, `"3"`)
+	req.Header.Set("X-User-Role", "admin")
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	s.Equal(http.StatusPreconditionFailed, resp.StatusCode)
+}
+
+func (s *BookHandlerSuite) TestGetBook4() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Get Merged Book Redirects To The Canonical Book", 34)
+
+	// Setup Mock
+	mergedID := uuid.New()
+	canonical := book.Book{ID: uuid.New(), Title: "Dune", Version: 5}
+	s.mockBookSvc.EXPECT().GetBook(mock.Anything, mergedID).Return(book.Book{}, gorm.ErrRecordNotFound)
+	s.mockBookSvc.EXPECT().ResolveBookRedirect(mock.Anything, mergedID).Return(canonical.ID, nil)
+
+	// Setup Request
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/books/%s?include=editions", mergedID), nil)
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	s.Equal(http.StatusMovedPermanently, resp.StatusCode)
+	s.Equal(fmt.Sprintf("/api/v1/books/%s?include=editions", canonical.ID), resp.Header.Get(fiber.HeaderLocation))
+}
+
+func (s *BookHandlerSuite) TestGetBook5() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Get Book Merged Twice Reaches The Last Canonical Book", 34)
+
+	// Setup Mock
+	// A was merged into B, then B into C. The merge repoints A's redirect at C, but a chain still ends at C
+	first, second := uuid.New(), uuid.New()
+	last := book.Book{ID: uuid.New(), Title: "Dune", Version: 7}
+	s.mockBookSvc.EXPECT().GetBook(mock.Anything, first).Return(book.Book{}, gorm.ErrRecordNotFound)
+	s.mockBookSvc.EXPECT().GetBook(mock.Anything, second).Return(book.Book{}, gorm.ErrRecordNotFound)
+	s.mockBookSvc.EXPECT().GetBook(mock.Anything, last.ID).Return(last, nil)
+	s.mockBookSvc.EXPECT().ResolveBookRedirect(mock.Anything, first).Return(second, nil)
+	s.mockBookSvc.EXPECT().ResolveBookRedirect(mock.Anything, second).Return(last.ID, nil)
+
+	location := fmt.Sprintf("/api/v1/books/%s", first)
+	var resp *http.Response
+	for hops := 0; hops < 3; hops++ {
+		// Setup Request
+		req, _ := http.NewRequest("GET", location, nil)
+
+		// Run Test Request
+		resp, _ = s.router.Test(req)
+		if resp.StatusCode != http.StatusMovedPermanently {
+			break
+		}
+		location = resp.Header.Get(fiber.HeaderLocation)
+	}
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal(fmt.Sprintf("/api/v1/books/%s", last.ID), location)
+}
+
+func (s *BookHandlerSuite) TestSetBookTags1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Set Book Tags Sends ETag", 34)
+
+	// Setup Mock
+	serviceResponse := book.Book{ID: uuid.New(), Title: "Dune", Version: 3}
+	s.mockBookSvc.EXPECT().SetBookTags(mock.Anything, serviceResponse.ID, book.BookTagsRequest{Tags: []string{"classic"}}).Return(serviceResponse, nil)
+
+	// Setup Request
+	reqBody, _ := json.Marshal(book.BookTagsRequest{Tags: []string{"classic"}})
+	req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/books/%s/tags", serviceResponse.ID), bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal(`"3"`, resp.Header.Get(fiber.HeaderETag))
+}
+
+func (s *BookHandlerSuite) TestSetBookWork1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Set Book Work Sends ETag", 34)
+
+	// Setup Mock
+	workID := uuid.New()
+	serviceResponse := book.Book{ID: uuid.New(), Title: "Dune", Version: 5, WorkID: &workID}
+	s.mockBookSvc.EXPECT().SetBookWork(mock.Anything, serviceResponse.ID, book.BookWorkRequest{WorkID: &workID}, `"4"`).Return(serviceResponse, nil)
+
+	// Setup Request
+	reqBody, _ := json.Marshal(book.BookWorkRequest{WorkID: &workID})
+	req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/books/%s/work", serviceResponse.ID), bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(fiber.HeaderIfMatch, `"4"`)
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal(`"5"`, resp.Header.Get(fiber.HeaderETag))
+}
+
+func (s *BookHandlerSuite) TestSetBookSeries1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Set Book Series Precondition Failed", 31)
+
+	// Setup Mock
+	bookID, seriesID := uuid.New(), uuid.New()
+	s.mockBookSvc.EXPECT().SetBookSeries(mock.Anything, bookID, book.BookSeriesRequest{SeriesID: &seriesID}, `"3"`).Return(book.Book{}, book.ErrPreconditionFailed)
+
+	// Setup Request
+	reqBody, _ := json.Marshal(book.BookSeriesRequest{SeriesID: &seriesID})
+	req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/books/%s/series", bookID), bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(fiber.HeaderIfMatch, `"3"`)
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	s.Equal(http.StatusPreconditionFailed, resp.StatusCode)
+}
+
+func (s *BookHandlerSuite) TestGetBookHistory1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Get Book History Paginated", 34)
+
+	// Setup Mock
+	bookID := uuid.New()
+	revisions := []book.BookRevision{
+		{BookID: bookID, Version: 4, Action: book.BookRevisionUpdate},
+		{BookID: bookID, Version: 3, Action: book.BookRevisionUpdate},
+	}
+	s.mockBookSvc.EXPECT().GetBookHistory(mock.Anything, bookID, book.BookHistoryFilter{Page: 2, Limit: 2}).Return(revisions, int64(5), nil)
+
+	// Setup Request
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/books/%s/history?page=2&limit=2", bookID), nil)
+	req.Header.Set("X-User-Role", "moderator")
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	var actualResp book.BookHistoryResponse
+	_ = json.NewDecoder(resp.Body).Decode(&actualResp)
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Len(actualResp.History, 2)
+	s.Equal(int64(5), actualResp.Total)
+	s.Equal(2, actualResp.Page)
+	s.Equal(2, actualResp.PerPage)
+	s.Equal(3, actualResp.TotalPages)
+}
+
+func (s *BookHandlerSuite) TestGetBookHistory2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Get Book History Forbidden For Users", 31)
+
+	// Setup Request
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/books/%s/history", uuid.New()), nil)
+	req.Header.Set("X-User-Role", "user")
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+}
+
+func (s *BookHandlerSuite) TestGetBookHistory3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Get Book History Not Found", 31)
+
+	// Setup Mock
+	bookID := uuid.New()
+	s.mockBookSvc.EXPECT().GetBookHistory(mock.Anything, bookID, book.BookHistoryFilter{Page: 1, Limit: 10}).Return(nil, int64(0), gorm.ErrRecordNotFound)
+
+	// Setup Request
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/books/%s/history", bookID), nil)
+	req.Header.Set("X-User-Role", "admin")
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+}
+
+func (s *BookHandlerSuite) TestRevertBook1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Revert Deleted Book Gone", 31)
+
+	// Setup Mock
+	bookID := uuid.New()
+	s.mockBookSvc.EXPECT().RevertBook(mock.Anything, bookID, 2, "").Return(book.Book{}, book.ErrBookDeleted)
+
+	// Setup Request
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/api/v1/books/%s/revert/2", bookID), nil)
+	req.Header.Set("X-User-Role", "admin")
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	s.Equal(http.StatusGone, resp.StatusCode)
+}
+
+func (s *BookHandlerSuite) TestCreateBook1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Create Book Ignores Server Owned Fields", 34)
+
+	// Setup Mock
+	created := book.Book{ID: uuid.New(), Title: "Dune", Author: "Frank Herbert", Version: 1}
+	s.mockBookSvc.EXPECT().CreateBook(mock.Anything, book.Book{Title: "Dune", Author: "Frank Herbert"}).Return(created, nil)
+
+	// Setup Request
+	body := `{"title":"Dune","author":"Frank Herbert","rating_average":5,"rating_count":1000,"version":42}`
+	req, _ := http.NewRequest("POST", "/api/v1/books", bytes.NewBufferString(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	s.Equal(http.StatusCreated, resp.StatusCode)
+}
+
+func (s *BookHandlerSuite) TestCreateBook2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Create Book Ignores Work And Series", 34)
+
+	// Setup Mock
+	created := book.Book{ID: uuid.New(), Title: "Dune", Author: "Frank Herbert", Version: 1}
+	s.mockBookSvc.EXPECT().CreateBook(mock.Anything, book.Book{Title: "Dune", Author: "Frank Herbert"}).Return(created, nil)
+
+	// Setup Request
+	body := fmt.Sprintf(`{"title":"Dune","author":"Frank Herbert","work_id":%q,"series_id":%q,"series_volume":1}`, uuid.New(), uuid.New())
+	req, _ := http.NewRequest("POST", "/api/v1/books", bytes.NewBufferString(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	s.Equal(http.StatusCreated, resp.StatusCode)
+}
+
+func (s *BookHandlerSuite) TestDeleteBook2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Delete Book With Copies", 31)
+
+	// Setup Mock
+	bookID := uuid.New()
+	s.mockBookSvc.EXPECT().DeleteBook(mock.Anything, bookID, "").Return(book.ErrBookHasCopies)
+
+	// Setup Request
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/api/v1/books/%s", bookID), nil)
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	s.Equal(http.StatusConflict, resp.StatusCode)
+}
+
+func (s *BookHandlerSuite) TestExportBooks1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Export Books Stream Ends With Complete Trailer", 34)
+
+	// Setup Mock
+	s.mockBookSvc.EXPECT().ExportBooks(mock.Anything, mock.Anything, book.BookFilter{Author: "Herbert"}, "csv").
+		RunAndReturn(func(ctx context.Context, w io.Writer, filter book.BookFilter, format string) error {
+			_, err := io.WriteString(w, "id,title\n1,Dune\n")
+			return err
+		})
+
+	// Setup Request
+	req, _ := http.NewRequest("GET", "/api/v1/books/export?author=Herbert", nil)
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	body, _ := io.ReadAll(resp.Body)
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal("id,title\n1,Dune\n", string(body))
+	s.Equal("complete", resp.Trailer.Get(book.ExportStatusTrailer))
+}
+
+func (s *BookHandlerSuite) TestExportBooks2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Export Books Failing Mid-Stream", 31)
+
+	// Setup Mock
+	// the header row already went out with the 200, only the trailer can tell the export is cut short
+	s.mockBookSvc.EXPECT().ExportBooks(mock.Anything, mock.Anything, book.BookFilter{}, "csv").
+		RunAndReturn(func(ctx context.Context, w io.Writer, filter book.BookFilter, format string) error {
+			if _, err := io.WriteString(w, "id,title\n"); err != nil {
+				return err
+			}
+			return errors.New("connection reset by peer")
+		})
+
+	// Setup Request
+	req, _ := http.NewRequest("GET", "/api/v1/books/export", nil)
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	body, _ := io.ReadAll(resp.Body)
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal("id,title\n", string(body))
+	s.Equal("failed", resp.Trailer.Get(book.ExportStatusTrailer))
+}
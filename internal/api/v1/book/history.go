@@ -0,0 +1,151 @@
+package book
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/pkg/patch"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func newBookSnapshot(book Book) BookSnapshot {
+	return BookSnapshot{Title: book.Title, Subtitle: book.Subtitle, Description: book.Description, Author: book.Author, ISBN: book.ISBN,
+		Language: book.Language, Publisher: book.Publisher, PageCount: book.PageCount}
+}
+
+func (s BookSnapshot) Value() (driver.Value, error) {
+	data, err := json.Marshal(s)
+	return string(data), err
+}
+
+func (s *BookSnapshot) Scan(value interface{}) error {
+	return scanJSON(value, s)
+}
+
+func (c FieldChanges) Value() (driver.Value, error) {
+	data, err := json.Marshal(c)
+	return string(data), err
+}
+
+func (c *FieldChanges) Scan(value interface{}) error {
+	return scanJSON(value, c)
+}
+
+func scanJSON(value interface{}, dest interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, dest)
+	case string:
+		return json.Unmarshal([]byte(v), dest)
+	case nil:
+		return nil
+	}
+	return fmt.Errorf("unsupported json column type %T", value)
+}
+
+// diffSnapshots lists every field that differs between two snapshots
+func diffSnapshots(before, after BookSnapshot) FieldChanges {
+	changes := FieldChanges{}
+	previous := patch.Changes(after, before)
+	for name, value := range patch.Changes(before, after) {
+		changes[name] = FieldChange{From: previous[name], To: value}
+	}
+	return changes
+}
+
+// actorID is the authenticated user behind the request, nil for the CLI and background jobs
+func actorID(c *fiber.Ctx) *uuid.UUID {
+	if c == nil {
+		return nil
+	}
+	if userID, ok := c.Locals("userID").(uuid.UUID); ok {
+		return &userID
+	}
+	return nil
+}
+
+// newBookRevision describes a write that moved the book from before to after and produced version
+func newBookRevision(c *fiber.Ctx, action BookRevisionAction, bookID uuid.UUID, version int, before, after BookSnapshot) BookRevision {
+	return BookRevision{
+		BookID:   bookID,
+		Version:  version,
+		Action:   action,
+		ActorID:  actorID(c),
+		Snapshot: after,
+		Changes:  diffSnapshots(before, after),
+	}
+}
+
+// BumpBookVersion moves a book to a new version inside tx and records the changes as a revision. It is for
+// writes that change what the book shows without going through its own columns, like the review rating.
+func BumpBookVersion(c *fiber.Ctx, tx *gorm.DB, bookID uuid.UUID, changes FieldChanges) error {
+	return bumpBookVersion(c, tx, BookRevisionUpdate, bookID, changes)
+}
+
+func bumpBookVersion(c *fiber.Ctx, tx *gorm.DB, action BookRevisionAction, bookID uuid.UUID, changes FieldChanges) error {
+	var book Book
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", bookID).First(&book).Error; err != nil {
+		return err
+	}
+
+	version := book.Version + 1
+	err := tx.Model(&Book{}).Where("id = ?", bookID).
+		UpdateColumns(map[string]interface{}{"version": version, "updated_at": time.Now()}).Error
+	if err != nil {
+		return err
+	}
+
+	snapshot := newBookSnapshot(book)
+	revision := newBookRevision(c, action, bookID, version, snapshot, snapshot)
+	revision.Changes = changes
+	return tx.Create(&revision).Error
+}
+
+// columnChanges lists the columns outside the snapshot that differ between two reads of a book
+func columnChanges(before, after Book) FieldChanges {
+	changes := FieldChanges{}
+	if !samePointee(before.WorkID, after.WorkID) {
+		changes["work_id"] = FieldChange{From: before.WorkID, To: after.WorkID}
+	}
+	if !samePointee(before.SeriesID, after.SeriesID) {
+		changes["series_id"] = FieldChange{From: before.SeriesID, To: after.SeriesID}
+	}
+	if !samePointee(before.SeriesVolume, after.SeriesVolume) {
+		changes["series_volume"] = FieldChange{From: before.SeriesVolume, To: after.SeriesVolume}
+	}
+	if before.RatingAverage != after.RatingAverage {
+		changes["rating_average"] = FieldChange{From: before.RatingAverage, To: after.RatingAverage}
+	}
+	if before.RatingCount != after.RatingCount {
+		changes["rating_count"] = FieldChange{From: before.RatingCount, To: after.RatingCount}
+	}
+	return changes
+}
+
+// translationChanges records a translation write under "translations.<locale>.<field>", an empty side is a missing translation
+func translationChanges(before, after BookTranslation) FieldChanges {
+	changes := FieldChanges{}
+	prefix := "translations." + after.Locale + "."
+	if before.Title != after.Title {
+		changes[prefix+"title"] = FieldChange{From: before.Title, To: after.Title}
+	}
+	if before.Subtitle != after.Subtitle {
+		changes[prefix+"subtitle"] = FieldChange{From: before.Subtitle, To: after.Subtitle}
+	}
+	if before.Description != after.Description {
+		changes[prefix+"description"] = FieldChange{From: before.Description, To: after.Description}
+	}
+	return changes
+}
+
+func samePointee[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
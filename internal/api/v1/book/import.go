@@ -0,0 +1,342 @@
+package book
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/author"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+)
+
+const (
+	ImportFormatCSV    = "csv"
+	ImportFormatNDJSON = "ndjson"
+
+	ImportStatusCreated = "created"
+	ImportStatusUpdated = "updated"
+	ImportStatusSkipped = "skipped"
+	ImportStatusFailed  = "failed"
+
+	importBatchSize = 500
+)
+
+var (
+	ErrInvalidImport = errors.New("invalid import file")
+)
+
+// importRecord is one decoded row of an import file before the de-duplication step
+type importRecord struct {
+	row  int
+	book ImportBookRow
+	err  error
+}
+
+// importReader yields rows one by one so large files never have to be held in memory
+type importReader interface {
+	Next() (ImportBookRow, error)
+}
+
+type csvImportReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func newCSVImportReader(r io.Reader) (*csvImportReader, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: missing header: %v", ErrInvalidImport, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, required := range []string{"title", "author"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: missing column %q", ErrInvalidImport, required)
+		}
+	}
+
+	return &csvImportReader{reader: reader, columns: columns}, nil
+}
+
+func (r *csvImportReader) Next() (ImportBookRow, error) {
+	record, err := r.reader.Read()
+	if err != nil {
+		return ImportBookRow{}, err
+	}
+
+	field := func(name string) string {
+		i, ok := r.columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return record[i]
+	}
+
+	return ImportBookRow{Title: field("title"), Author: field("author"), ISBN: field("isbn")}, nil
+}
+
+type ndjsonImportReader struct {
+	decoder *json.Decoder
+}
+
+func (r *ndjsonImportReader) Next() (ImportBookRow, error) {
+	var row ImportBookRow
+	if err := r.decoder.Decode(&row); err != nil {
+		if err == io.EOF {
+			return ImportBookRow{}, err
+		}
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			// the decoder cannot resynchronise after broken JSON, so stop here
+			return ImportBookRow{}, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+		}
+		return ImportBookRow{}, &importRowError{err: err}
+	}
+	return row, nil
+}
+
+// importRowError marks a row that failed to decode while the rest of the file is still readable
+type importRowError struct {
+	err error
+}
+
+func (e *importRowError) Error() string {
+	return e.err.Error()
+}
+
+func newImportReader(r io.Reader, format string) (importReader, error) {
+	switch format {
+	case ImportFormatCSV:
+		return newCSVImportReader(r)
+	case ImportFormatNDJSON:
+		return &ndjsonImportReader{decoder: json.NewDecoder(r)}, nil
+	}
+	return nil, fmt.Errorf("%w: unsupported format %q", ErrInvalidImport, format)
+}
+
+// normalize trims the row and validates it, returning the reason it should fail
+func (row *ImportBookRow) normalize() error {
+	row.Title = strings.TrimSpace(row.Title)
+	row.Author = strings.TrimSpace(row.Author)
+	row.ISBN = utils.NormalizeISBN(row.ISBN)
+
+	if err := utils.Validate(row); err != nil {
+		return err
+	}
+	if row.ISBN != "" && !utils.IsValidISBN(row.ISBN) {
+		return fmt.Errorf("invalid isbn: %s", row.ISBN)
+	}
+	return nil
+}
+
+func titleAuthorKey(title, author string) string {
+	return strings.ToLower(title) + "\x00" + strings.ToLower(author)
+}
+
+// importBooks streams the file, de-duplicates on ISBN or title+author and writes batches inside one transaction
+func importBooks(c *fiber.Ctx, repo BookRepository, authorRepo author.AuthorRepository, r io.Reader, format string, dryRun bool) (*ImportReport, error) {
+	reader, err := newImportReader(r, format)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ImportReport{DryRun: dryRun, Rows: []ImportRowResult{}}
+	seen := map[string]int{}
+
+	err = repo.Transaction(c, func(txRepo BookRepository) error {
+		batch := make([]importRecord, 0, importBatchSize)
+		// row numbers match file lines, for CSV the header is line 1
+		row := 0
+		if format == ImportFormatCSV {
+			row = 1
+		}
+		for {
+			book, err := reader.Next()
+			if err == io.EOF {
+				break
+			}
+			row++
+			var rowErr *importRowError
+			var parseErr *csv.ParseError
+			switch {
+			case errors.As(err, &rowErr), errors.As(err, &parseErr):
+				batch = append(batch, importRecord{row: row, err: err})
+			case err != nil:
+				return err
+			default:
+				batch = append(batch, importRecord{row: row, book: book})
+			}
+
+			if len(batch) == importBatchSize {
+				if err := processImportBatch(c, txRepo, authorRepo, batch, seen, report); err != nil {
+					return err
+				}
+				batch = batch[:0]
+			}
+		}
+		return processImportBatch(c, txRepo, authorRepo, batch, seen, report)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// processImportBatch classifies each row of the batch and, unless the report is a dry run, writes the result
+func processImportBatch(c *fiber.Ctx, repo BookRepository, authorRepo author.AuthorRepository, batch []importRecord, seen map[string]int, report *ImportReport) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	var isbns []string
+	var pairs [][]interface{}
+	for i := range batch {
+		if batch[i].err != nil {
+			continue
+		}
+		if err := batch[i].book.normalize(); err != nil {
+			batch[i].err = err
+			continue
+		}
+		if batch[i].book.ISBN != "" {
+			isbns = append(isbns, batch[i].book.ISBN)
+		}
+		pairs = append(pairs, []interface{}{strings.ToLower(batch[i].book.Title), strings.ToLower(batch[i].book.Author)})
+	}
+
+	byISBN := map[string]Book{}
+	existingByISBN, err := repo.FindBooksByISBNs(c, isbns)
+	if err != nil {
+		return err
+	}
+	for _, book := range existingByISBN {
+		byISBN[book.ISBN] = book
+	}
+
+	byTitleAuthor := map[string]Book{}
+	existingByTitleAuthor, err := repo.FindBooksByTitleAuthors(c, pairs)
+	if err != nil {
+		return err
+	}
+	for _, book := range existingByTitleAuthor {
+		byTitleAuthor[titleAuthorKey(book.Title, book.Author)] = book
+	}
+
+	var newBooks []Book
+	var newBookRows []int
+	for _, record := range batch {
+		result := ImportRowResult{Row: record.row}
+		if record.err != nil {
+			result.Status = ImportStatusFailed
+			result.Reason = record.err.Error()
+			report.add(result)
+			continue
+		}
+
+		row := record.book
+		keys := []string{"ta:" + titleAuthorKey(row.Title, row.Author)}
+		if row.ISBN != "" {
+			keys = append(keys, "isbn:"+row.ISBN)
+		}
+		duplicateOf := 0
+		for _, key := range keys {
+			if firstRow, ok := seen[key]; ok {
+				duplicateOf = firstRow
+				break
+			}
+		}
+		if duplicateOf > 0 {
+			result.Status = ImportStatusSkipped
+			result.Reason = fmt.Sprintf("duplicate of row %d", duplicateOf)
+			report.add(result)
+			continue
+		}
+		for _, key := range keys {
+			seen[key] = record.row
+		}
+
+		existing, found := byISBN[row.ISBN]
+		if !found {
+			existing, found = byTitleAuthor[titleAuthorKey(row.Title, row.Author)]
+		}
+
+		if !found {
+			newBooks = append(newBooks, Book{Title: row.Title, Author: row.Author, ISBN: row.ISBN})
+			newBookRows = append(newBookRows, len(report.Rows))
+			result.Status = ImportStatusCreated
+			report.add(result)
+			continue
+		}
+
+		bookID := existing.ID
+		result.BookID = &bookID
+		changed := existing.Title != row.Title || existing.Author != row.Author || (row.ISBN != "" && existing.ISBN != row.ISBN)
+		if !changed {
+			result.Status = ImportStatusSkipped
+			result.Reason = "book already exists"
+			report.add(result)
+			continue
+		}
+
+		existing.Title = row.Title
+		existing.Author = row.Author
+		if row.ISBN != "" {
+			existing.ISBN = row.ISBN
+		}
+		if !report.DryRun {
+			if _, err := repo.UpdateBook(c, existing); err != nil {
+				return err
+			}
+		}
+		result.Status = ImportStatusUpdated
+		report.add(result)
+	}
+
+	if report.DryRun {
+		return nil
+	}
+	if err := repo.CreateBooks(c, newBooks, importBatchSize); err != nil {
+		return err
+	}
+	for i, index := range newBookRows {
+		if newBooks[i].ID != uuid.Nil {
+			bookID := newBooks[i].ID
+			report.Rows[index].BookID = &bookID
+		}
+	}
+	// imported books are credited like books created one by one
+	for _, book := range newBooks {
+		if _, err := linkLegacyAuthors(c, repo, authorRepo, book); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *ImportReport) add(result ImportRowResult) {
+	switch result.Status {
+	case ImportStatusCreated:
+		r.Created++
+	case ImportStatusUpdated:
+		r.Updated++
+	case ImportStatusSkipped:
+		r.Skipped++
+	case ImportStatusFailed:
+		r.Failed++
+	}
+	r.Rows = append(r.Rows, result)
+}
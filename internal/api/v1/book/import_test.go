@@ -0,0 +1,164 @@
+package book_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/author"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+	"github.com/jerpsp/go-fiber-beginner/mocks"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImportSuite struct {
+	suite.Suite
+	mockRepo       *mocks.BookRepository
+	mockAuthorRepo *mocks.AuthorRepository
+	service        book.BookService
+}
+
+func TestImportSuite(t *testing.T) {
+	suite.Run(t, new(ImportSuite))
+}
+
+func (s *ImportSuite) SetupTest() {
+	s.mockRepo = mocks.NewBookRepository(s.T())
+	s.mockAuthorRepo = mocks.NewAuthorRepository(s.T())
+	s.service = book.NewBookService(&config.Config{}, s.mockRepo, s.mockAuthorRepo, nil, nil)
+
+	// the import runs in one transaction, the mock hands itself to the callback
+	s.mockRepo.EXPECT().Transaction(mock.Anything, mock.Anything).RunAndReturn(func(c *fiber.Ctx, fn func(txRepo book.BookRepository) error) error {
+		return fn(s.mockRepo)
+	}).Maybe()
+}
+
+func (s *ImportSuite) TestImportCSV1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Import CSV Dry Run With Mixed Rows", 34)
+
+	// Setup Mock
+	s.mockRepo.EXPECT().FindBooksByISBNs(mock.Anything, []string{"9780306406157", "080442957X"}).Return(nil, nil)
+	s.mockRepo.EXPECT().FindBooksByTitleAuthors(mock.Anything, mock.Anything).Return(nil, nil)
+
+	file := "\ufeffTitle,Author,ISBN\n" +
+		"Dune,Frank Herbert,978-0-306-40615-7\n" +
+		"Bad Checksum,Someone,9780306406158\n" +
+		",Nobody,\n" +
+		"Dune,frank herbert,\n" +
+		"Emma,Jane Austen,0-8044-2957-x\n"
+
+	// Call the service method
+	report, err := s.service.ImportBooks(&fiber.Ctx{}, strings.NewReader(file), book.ImportFormatCSV, true)
+
+	// Assertions
+	s.NoError(err)
+	s.True(report.DryRun)
+	s.Equal(2, report.Created)
+	s.Equal(1, report.Skipped)
+	s.Equal(2, report.Failed)
+	s.Len(report.Rows, 5)
+	s.Equal(book.ImportRowResult{Row: 2, Status: book.ImportStatusCreated}, report.Rows[0])
+	s.Equal(book.ImportRowResult{Row: 3, Status: book.ImportStatusFailed, Reason: "invalid isbn: 9780306406158"}, report.Rows[1])
+	s.Equal(4, report.Rows[2].Row)
+	s.Equal(book.ImportStatusFailed, report.Rows[2].Status)
+	s.Equal(book.ImportRowResult{Row: 5, Status: book.ImportStatusSkipped, Reason: "duplicate of row 2"}, report.Rows[3])
+	s.Equal(book.ImportRowResult{Row: 6, Status: book.ImportStatusCreated}, report.Rows[4])
+}
+
+func (s *ImportSuite) TestImportCSV2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Import CSV Creates And Updates Books", 34)
+
+	// Setup Mock
+	existing := book.Book{ID: uuid.New(), Title: "Old Title", Author: "Frank Herbert", ISBN: "9780306406157"}
+	createdID := uuid.New()
+	authorID := uuid.New()
+	s.mockRepo.EXPECT().FindBooksByISBNs(mock.Anything, []string{"9780306406157"}).Return([]book.Book{existing}, nil)
+	s.mockRepo.EXPECT().FindBooksByTitleAuthors(mock.Anything, mock.Anything).Return(nil, nil)
+	s.mockRepo.EXPECT().UpdateBook(mock.Anything, mock.MatchedBy(func(updated book.Book) bool {
+		return updated.ID == existing.ID && updated.Title == "Dune"
+	})).Return(book.Book{}, nil)
+	s.mockRepo.EXPECT().CreateBooks(mock.Anything, []book.Book{{Title: "Emma", Author: "Jane Austen"}}, mock.Anything).
+		RunAndReturn(func(c *fiber.Ctx, newBooks []book.Book, batchSize int) error {
+			newBooks[0].ID = createdID
+			return nil
+		})
+	s.mockAuthorRepo.EXPECT().FindOrCreateAuthorByName(mock.Anything, "Jane Austen").Return(author.Author{ID: authorID, Name: "Jane Austen"}, nil)
+	s.mockRepo.EXPECT().ReplaceBookAuthors(mock.Anything, createdID, []book.BookAuthor{{AuthorID: authorID, Role: book.BookAuthorRoleAuthor}}).Return(nil)
+
+	file := "title,author,isbn\nDune,Frank Herbert,978-0-306-40615-7\n Emma , Jane Austen ,\n"
+
+	// Call the service method
+	report, err := s.service.ImportBooks(&fiber.Ctx{}, strings.NewReader(file), book.ImportFormatCSV, false)
+
+	// Assertions
+	s.NoError(err)
+	s.Equal(1, report.Updated)
+	s.Equal(1, report.Created)
+	s.Equal(existing.ID, *report.Rows[0].BookID)
+	s.Equal(createdID, *report.Rows[1].BookID)
+}
+
+func (s *ImportSuite) TestImportCSV3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Import CSV Missing Column", 31)
+
+	// Call the service method
+	report, err := s.service.ImportBooks(&fiber.Ctx{}, strings.NewReader("title,isbn\nDune,\n"), book.ImportFormatCSV, true)
+
+	// Assertions
+	s.ErrorIs(err, book.ErrInvalidImport)
+	s.Nil(report)
+}
+
+func (s *ImportSuite) TestImportNDJSON1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Import NDJSON With A Bad Row", 34)
+
+	// Setup Mock
+	s.mockRepo.EXPECT().FindBooksByISBNs(mock.Anything, []string(nil)).Return(nil, nil)
+	s.mockRepo.EXPECT().FindBooksByTitleAuthors(mock.Anything, mock.Anything).Return(nil, nil)
+
+	file := `{"title":"Dune","author":"Frank Herbert"}
+{"title":5,"author":"Nobody"}
+{"title":"Emma","author":"Jane Austen"}
+`
+
+	// Call the service method
+	report, err := s.service.ImportBooks(&fiber.Ctx{}, strings.NewReader(file), book.ImportFormatNDJSON, true)
+
+	// Assertions
+	s.NoError(err)
+	s.Equal(2, report.Created)
+	s.Equal(1, report.Failed)
+	s.Equal(2, report.Rows[1].Row)
+	s.Equal(book.ImportStatusFailed, report.Rows[1].Status)
+}
+
+func (s *ImportSuite) TestImportNDJSON2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Import NDJSON Broken JSON", 31)
+
+	// Call the service method
+	report, err := s.service.ImportBooks(&fiber.Ctx{}, strings.NewReader("{\"title\":\"Dune\"]\n{\"title\":\"Emma\"}\n"), book.ImportFormatNDJSON, true)
+
+	// Assertions
+	s.ErrorIs(err, book.ErrInvalidImport)
+	s.Nil(report)
+}
+
+func (s *ImportSuite) TestImportFormat1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Import Unsupported Format", 31)
+
+	// Call the service method
+	_, err := s.service.ImportBooks(&fiber.Ctx{}, strings.NewReader(""), "xml", true)
+
+	// Assertions
+	s.ErrorIs(err, book.ErrInvalidImport)
+}
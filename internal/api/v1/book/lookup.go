@@ -0,0 +1,67 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jerpsp/go-fiber-beginner/pkg/catalogue"
+)
+
+var ErrISBNExists = errors.New("a book with this isbn already exists")
+
+// coverFetchTimeout bounds the download of a catalogue cover, the lookup itself has its own timeout
+const coverFetchTimeout = 10 * time.Second
+
+var coverClient = &http.Client{Timeout: coverFetchTimeout}
+
+// newBookFromMetadata prefills a book from catalogue metadata, multiple authors share the legacy author string
+func newBookFromMetadata(metadata *catalogue.Metadata) Book {
+	return Book{
+		Title:     metadata.Title,
+		Author:    strings.Join(metadata.Authors, " & "),
+		ISBN:      metadata.ISBN,
+		Publisher: metadata.Publisher,
+		PageCount: metadata.PageCount,
+	}
+}
+
+// fetchCover downloads a catalogue cover, refusing anything larger than an uploaded cover may be
+func fetchCover(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := coverClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxCoverSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxCoverSize {
+		return nil, fmt.Errorf("%w: file size exceeds limit", ErrInvalidCover)
+	}
+	return data, nil
+}
+
+// lookupContext is the request context, CLI callers have no request
+func lookupContext(c *fiber.Ctx) context.Context {
+	if c == nil {
+		return context.Background()
+	}
+	return c.UserContext()
+}
@@ -0,0 +1,91 @@
+package book
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidMerge = errors.New("invalid merge")
+
+// Duplicate scores, an ISBN match is certain while titles and authors are weighted 70/30
+const (
+	defaultDuplicateScore = 0.6
+	titleScoreWeight      = 0.7
+	authorScoreWeight     = 0.3
+)
+
+// duplicateCandidatesQuery pairs books whose lower-cased titles are trigram-similar or that share an ISBN,
+// the older book of each pair comes first
+func duplicateCandidatesQuery(db *gorm.DB) *gorm.DB {
+	return db.Table("books AS a").
+		Select(`a.id AS book_id, b.id AS duplicate_id,
+			similarity(LOWER(a.title), LOWER(b.title)) AS title_score,
+			similarity(LOWER(a.author), LOWER(b.author)) AS author_score,
+			(a.isbn <> '' AND a.isbn = b.isbn) AS same_isbn,
+			CASE WHEN a.isbn <> '' AND a.isbn = b.isbn THEN 1
+				ELSE ? * similarity(LOWER(a.title), LOWER(b.title)) + ? * similarity(LOWER(a.author), LOWER(b.author)) END AS score`,
+			titleScoreWeight, authorScoreWeight).
+		Joins(`JOIN books AS b ON (a.created_at, a.id) < (b.created_at, b.id)
+			AND (LOWER(a.title) % LOWER(b.title) OR (a.isbn <> '' AND a.isbn = b.isbn))`)
+}
+
+// mergeBookRecords moves what hangs off the duplicate onto the canonical book. Records the canonical book
+// already has for the same user or list stay with the duplicate and are removed with it.
+func mergeBookRecords(tx *gorm.DB, canonicalID, duplicateID uuid.UUID) error {
+	statements := []string{
+		// credits are only taken over when the canonical book has none, mixing two credit orders makes no sense
+		`INSERT INTO book_authors (book_id, author_id, position, role)
+			SELECT @canonical, author_id, position, role FROM book_authors
+			WHERE book_id = @duplicate AND NOT EXISTS (SELECT 1 FROM book_authors WHERE book_id = @canonical)`,
+		`INSERT INTO book_tags (book_id, tag_id) SELECT @canonical, tag_id FROM book_tags WHERE book_id = @duplicate
+			ON CONFLICT DO NOTHING`,
+		`INSERT INTO book_categories (book_id, category_id) SELECT @canonical, category_id FROM book_categories WHERE book_id = @duplicate
+			ON CONFLICT DO NOTHING`,
+		`UPDATE reviews SET book_id = @canonical WHERE book_id = @duplicate
+			AND user_id NOT IN (SELECT user_id FROM reviews WHERE book_id = @canonical)`,
+		`UPDATE reading_list_items SET book_id = @canonical WHERE book_id = @duplicate
+			AND list_id NOT IN (SELECT list_id FROM reading_list_items WHERE book_id = @canonical)`,
+		`UPDATE reading_progress SET book_id = @canonical WHERE book_id = @duplicate
+			AND user_id NOT IN (SELECT user_id FROM reading_progress WHERE book_id = @canonical)`,
+		`UPDATE copies SET book_id = @canonical WHERE book_id = @duplicate`,
+		`UPDATE book_files SET book_id = @canonical WHERE book_id = @duplicate
+			AND format NOT IN (SELECT format FROM book_files WHERE book_id = @canonical)`,
+		`UPDATE book_downloads SET book_id = @canonical WHERE book_id = @duplicate`,
+		`UPDATE book_translations SET book_id = @canonical WHERE book_id = @duplicate
+			AND locale NOT IN (SELECT locale FROM book_translations WHERE book_id = @canonical)`,
+		`INSERT INTO book_daily_stats (book_id, day, views, loans, favourites, reviews, updated_at)
+			SELECT @canonical, day, views, loans, favourites, reviews, NOW() FROM book_daily_stats WHERE book_id = @duplicate
+			ON CONFLICT (book_id, day) DO UPDATE SET views = book_daily_stats.views + excluded.views,
+			loans = book_daily_stats.loans + excluded.loans, favourites = book_daily_stats.favourites + excluded.favourites,
+			reviews = book_daily_stats.reviews + excluded.reviews, updated_at = excluded.updated_at`,
+		// the canonical book keeps its own work and series and only takes over the duplicate's when it has none
+		`UPDATE books SET work_id = COALESCE(books.work_id, duplicate.work_id),
+			series_volume = CASE WHEN books.series_id IS NULL THEN duplicate.series_volume ELSE books.series_volume END,
+			series_id = COALESCE(books.series_id, duplicate.series_id)
+			FROM books AS duplicate WHERE books.id = @canonical AND duplicate.id = @duplicate`,
+		// a user keeps one open hold: a waiting hold gives way to the other book's open hold, a ready one never does
+		`UPDATE holds SET status = 'cancelled', closed_at = NOW(), updated_at = NOW()
+			WHERE book_id = @duplicate AND status = 'waiting'
+			AND user_id IN (SELECT user_id FROM holds WHERE book_id = @canonical AND status IN ('waiting', 'ready'))`,
+		`UPDATE holds SET status = 'cancelled', closed_at = NOW(), updated_at = NOW()
+			WHERE book_id = @canonical AND status = 'waiting'
+			AND user_id IN (SELECT user_id FROM holds WHERE book_id = @duplicate AND status = 'ready')`,
+		`UPDATE holds SET book_id = @canonical WHERE book_id = @duplicate`,
+		`UPDATE books SET rating_count = stats.count, rating_average = stats.average
+			FROM (SELECT COUNT(*) AS count, COALESCE(AVG(rating), 0) AS average FROM reviews WHERE book_id = @canonical AND NOT hidden) AS stats
+			WHERE books.id = @canonical`,
+		// earlier merges into the duplicate now lead to the canonical book as well
+		`UPDATE book_redirects SET to_id = @canonical WHERE to_id = @duplicate`,
+		`INSERT INTO book_redirects (from_id, to_id, created_at) VALUES (@duplicate, @canonical, NOW())`,
+	}
+
+	args := map[string]interface{}{"canonical": canonicalID, "duplicate": duplicateID}
+	for _, statement := range statements {
+		if err := tx.Exec(statement, args).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
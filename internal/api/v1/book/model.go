@@ -1,15 +1,238 @@
 package book
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/author"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/category"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/series"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/work"
 )
 
+// Book is a catalogue entry. RatingAverage and RatingCount are kept in step by the review package.
 type Book struct {
-	ID        uuid.UUID `gorm:"type:uuid; default:uuid_generate_v4()" json:"id"`
-	Title     string    `gorm:"size:255" json:"title"`
-	Author    string    `gorm:"size:255" json:"author"`
+	ID            uuid.UUID           `gorm:"type:uuid; default:uuid_generate_v4()" json:"id"`
+	Title         string              `gorm:"size:255;index:idx_books_title_trgm,type:gin,expression:lower(title) gin_trgm_ops" json:"title"`
+	Subtitle      string              `gorm:"size:255" json:"subtitle"`
+	Description   string              `gorm:"type:text" json:"description"`
+	Author        string              `gorm:"size:255;index:idx_books_author_trgm,type:gin,expression:lower(author) gin_trgm_ops" json:"author"`
+	ISBN          string              `gorm:"size:13;index" json:"isbn"`
+	Language      string              `gorm:"size:35;index" json:"language"`
+	Locale        string              `gorm:"-" json:"locale,omitempty"`
+	Translations  []BookTranslation   `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE" json:"-"`
+	Publisher     string              `gorm:"size:255" json:"publisher"`
+	PageCount     int                 `json:"page_count"`
+	WorkID        *uuid.UUID          `gorm:"type:uuid;index" json:"work_id"`
+	Work          *work.Work          `gorm:"foreignKey:WorkID;constraint:OnDelete:SET NULL" json:"work,omitempty"`
+	SeriesID      *uuid.UUID          `gorm:"type:uuid;index:idx_books_series_volume,priority:1" json:"series_id"`
+	SeriesVolume  *float64            `gorm:"type:numeric(6,2);index:idx_books_series_volume,priority:2" json:"series_volume"`
+	Series        *series.Series      `gorm:"foreignKey:SeriesID;constraint:OnDelete:SET NULL" json:"series,omitempty"`
+	Editions      []Book              `gorm:"-" json:"editions,omitempty"`
+	Neighbours    *SeriesNeighbours   `gorm:"-" json:"series_neighbours,omitempty"`
+	CoverPrefix   string              `gorm:"size:255" json:"-"`
+	Covers        []CoverImage        `gorm:"-" json:"covers,omitempty"`
+	Authors       []BookAuthor        `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE" json:"authors,omitempty"`
+	Categories    []category.Category `gorm:"many2many:book_categories;constraint:OnDelete:CASCADE" json:"categories,omitempty"`
+	Tags          []Tag               `gorm:"many2many:book_tags;constraint:OnDelete:CASCADE" json:"tags,omitempty"`
+	RatingAverage float64             `gorm:"type:numeric(3,2);not null;default:0" json:"rating_average"`
+	RatingCount   int                 `gorm:"not null;default:0" json:"rating_count"`
+	Version       int                 `gorm:"not null;default:1" json:"version"`
+	CreatedAt     time.Time           `json:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+
+	// textLocales lists the translations Localize took text from, a localized read has its own ETag
+	textLocales []string
+}
+
+// ETag is a strong validator derived from the version, which is bumped on every write including translations.
+// A localized book adds the locales its text came from, so each representation has its own tag.
+func (b Book) ETag() string {
+	if len(b.textLocales) > 0 {
+		return fmt.Sprintf(`"%d-%s"`, b.Version, strings.Join(b.textLocales, "+"))
+	}
+	return fmt.Sprintf(`"%d"`, b.Version)
+}
+
+// MatchesIfMatch compares an If-Match header with the strong comparison RFC 9110 requires, a weak tag never matches
+func (b Book) MatchesIfMatch(header string) bool {
+	return b.matchesETag(header, false)
+}
+
+// MatchesIfNoneMatch compares an If-None-Match header with the weak comparison, so W/"3" matches "3"
+func (b Book) MatchesIfNoneMatch(header string) bool {
+	return b.matchesETag(header, true)
+}
+
+func (b Book) matchesETag(header string, weak bool) bool {
+	etag := b.ETag()
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if weak {
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+type BookAuthorRole string
+
+const (
+	BookAuthorRoleAuthor     BookAuthorRole = "author"
+	BookAuthorRoleEditor     BookAuthorRole = "editor"
+	BookAuthorRoleTranslator BookAuthorRole = "translator"
+)
+
+// BookAuthor credits an author on a book, Position orders the credits as they appear on the cover
+type BookAuthor struct {
+	BookID   uuid.UUID      `gorm:"type:uuid;primaryKey" json:"-"`
+	AuthorID uuid.UUID      `gorm:"type:uuid;primaryKey;index" json:"author_id"`
+	Position int            `gorm:"not null;default:0" json:"position"`
+	Role     BookAuthorRole `gorm:"size:16;not null;default:'author'" json:"role"`
+	Author   author.Author  `gorm:"foreignKey:AuthorID;constraint:OnDelete:RESTRICT" json:"author"`
+}
+
+// Tag is a free-form label, names are stored lower-case so "SciFi" and "scifi" are one tag
+type Tag struct {
+	ID   uuid.UUID `gorm:"type:uuid; default:uuid_generate_v4()" json:"-"`
+	Name string    `gorm:"size:64;not null;uniqueIndex" json:"name"`
+}
+
+// MarshalJSON renders a tag as its bare name
+func (t Tag) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Name)
+}
+
+// CoverImage is a single resized rendition of a book cover
+type CoverImage struct {
+	Size   int    `json:"size"`
+	Format string `json:"format"`
+	URL    string `json:"url"`
+}
+
+type ExportJobStatus string
+
+const (
+	ExportJobPending   ExportJobStatus = "pending"
+	ExportJobCompleted ExportJobStatus = "completed"
+	ExportJobFailed    ExportJobStatus = "failed"
+)
+
+// ExportJob tracks a background export, it lives in Redis until the download link expires
+type ExportJob struct {
+	ID          uuid.UUID       `json:"id"`
+	Format      string          `json:"format"`
+	Status      ExportJobStatus `json:"status"`
+	URL         string          `json:"url,omitempty"`
+	Error       string          `json:"error,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+}
+
+type BookRevisionAction string
+
+const (
+	BookRevisionCreate BookRevisionAction = "create"
+	BookRevisionUpdate BookRevisionAction = "update"
+	BookRevisionDelete BookRevisionAction = "delete"
+	BookRevisionRevert BookRevisionAction = "revert"
+	BookRevisionMerge  BookRevisionAction = "merge"
+)
+
+// BookRevision is an immutable record of one write to a book, numbered by the version the write produced
+type BookRevision struct {
+	ID           uuid.UUID          `gorm:"type:uuid; default:uuid_generate_v4()" json:"id"`
+	BookID       uuid.UUID          `gorm:"type:uuid;not null;uniqueIndex:idx_book_revisions_book_version" json:"book_id"`
+	Version      int                `gorm:"not null;uniqueIndex:idx_book_revisions_book_version" json:"version"`
+	Action       BookRevisionAction `gorm:"size:16;not null" json:"action"`
+	ActorID      *uuid.UUID         `gorm:"type:uuid" json:"actor_id"`
+	RevertedFrom *int               `json:"reverted_from,omitempty"`
+	Snapshot     BookSnapshot       `gorm:"type:jsonb;not null" json:"snapshot"`
+	Changes      FieldChanges       `gorm:"type:jsonb;not null" json:"changes"`
+	CreatedAt    time.Time          `json:"created_at"`
+}
+
+// BookSnapshot holds the editable fields of a book as they were after a revision
+type BookSnapshot struct {
+	Title       string `json:"title"`
+	Subtitle    string `json:"subtitle"`
+	Description string `json:"description"`
+	Author      string `json:"author"`
+	ISBN        string `json:"isbn"`
+	Language    string `json:"language"`
+	Publisher   string `json:"publisher"`
+	PageCount   int    `json:"page_count"`
+}
+
+// FieldChange is the before and after value of a single field
+type FieldChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+type FieldChanges map[string]FieldChange
+
+// BookRedirect points the ID of a book merged away to the book it was merged into
+type BookRedirect struct {
+	FromID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"from_id"`
+	ToID      uuid.UUID `gorm:"type:uuid;not null;index" json:"to_id"`
 	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SeriesNeighbours are the volumes right before and after a book in its series
+type SeriesNeighbours struct {
+	Previous *Book `json:"previous"`
+	Next     *Book `json:"next"`
+}
+
+const (
+	BookFormatEPUB = "epub"
+	BookFormatPDF  = "pdf"
+)
+
+// BookFile is the e-book of a book in one format, stored in the private bucket and only handed out through
+// short-lived download links. A new upload of the same format replaces the object but keeps the row.
+type BookFile struct {
+	ID            uuid.UUID  `gorm:"type:uuid; default:uuid_generate_v4()" json:"id"`
+	BookID        uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_book_files_book_format" json:"book_id"`
+	Format        string     `gorm:"size:8;not null;uniqueIndex:idx_book_files_book_format" json:"format"`
+	ObjectKey     string     `gorm:"size:255;not null" json:"-"`
+	ContentType   string     `gorm:"size:64;not null" json:"content_type"`
+	Size          int64      `gorm:"not null" json:"size"`
+	Checksum      string     `gorm:"size:64;not null" json:"checksum"`
+	UploadedBy    *uuid.UUID `gorm:"type:uuid" json:"uploaded_by,omitempty"`
+	DownloadCount int64      `gorm:"->;-:migration" json:"download_count"`
+	Book          *Book      `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// BookDownload records one download link handed to a user, it outlives the file so the counts stay intact
+type BookDownload struct {
+	ID        uuid.UUID  `gorm:"type:uuid; default:uuid_generate_v4()" json:"id"`
+	BookID    uuid.UUID  `gorm:"type:uuid;not null;index:idx_book_downloads_book_user,priority:1" json:"book_id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index:idx_book_downloads_book_user,priority:2" json:"user_id"`
+	FileID    *uuid.UUID `gorm:"type:uuid;index" json:"file_id"`
+	Format    string     `gorm:"size:8;not null" json:"format"`
+	Book      *Book      `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE" json:"-"`
+	File      *BookFile  `gorm:"foreignKey:FileID;constraint:OnDelete:SET NULL" json:"-"`
+	CreatedAt time.Time  `gorm:"index" json:"created_at"`
+}
+
+// BookTranslation is the title, subtitle and description of a book in another locale than its own language.
+// Empty fields fall back to the next locale a reader asked for and finally to the book itself.
+type BookTranslation struct {
+	BookID      uuid.UUID `gorm:"type:uuid;primaryKey" json:"-"`
+	Locale      string    `gorm:"size:35;primaryKey" json:"locale"`
+	Title       string    `gorm:"size:255;not null;index:idx_book_translations_title_trgm,type:gin,expression:lower(title) gin_trgm_ops" json:"title"`
+	Subtitle    string    `gorm:"size:255" json:"subtitle"`
+	Description string    `gorm:"type:text" json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
 }
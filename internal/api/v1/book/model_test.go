@@ -0,0 +1,55 @@
+package book_test
+
+import (
+	"testing"
+
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type ModelSuite struct {
+	suite.Suite
+}
+
+func TestModelSuite(t *testing.T) {
+	suite.Run(t, new(ModelSuite))
+}
+
+func (s *ModelSuite) TestETag1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: ETag From Version", 34)
+
+	s.Equal(`"3"`, book.Book{Version: 3}.ETag())
+}
+
+func (s *ModelSuite) TestMatchesIfMatch1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: If-Match Strong Comparison", 34)
+
+	b := book.Book{Version: 3}
+	s.True(b.MatchesIfMatch(`"3"`))
+	s.True(b.MatchesIfMatch(`"1", "3"`))
+	s.True(b.MatchesIfMatch(`*`))
+	s.False(b.MatchesIfMatch(`"2"`))
+	s.False(b.MatchesIfMatch(`3`))
+}
+
+func (s *ModelSuite) TestMatchesIfMatch2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: If-Match Rejects Weak Tags", 31)
+
+	s.False(book.Book{Version: 3}.MatchesIfMatch(`W/"3"`))
+}
+
+func (s *ModelSuite) TestMatchesIfNoneMatch1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: If-None-Match Weak Comparison", 34)
+
+	b := book.Book{Version: 3}
+	s.True(b.MatchesIfNoneMatch(`"3"`))
+	s.True(b.MatchesIfNoneMatch(`W/"3"`))
+	s.True(b.MatchesIfNoneMatch(`W/"1", W/"3"`))
+	s.True(b.MatchesIfNoneMatch(`*`))
+	s.False(b.MatchesIfNoneMatch(`W/"2"`))
+}
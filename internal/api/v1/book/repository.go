@@ -1,65 +1,822 @@
 package book
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/category"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/series"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/work"
 	"github.com/jerpsp/go-fiber-beginner/pkg/database"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type BookRepository interface {
-	FindAllBooks(c *fiber.Ctx) ([]Book, error)
+	FindAllBooks(c *fiber.Ctx, filter BookFilter) ([]Book, error)
+	StreamBooks(ctx context.Context, filter BookFilter, batchSize int, fn func(books []Book) error) error
 	FindBookByID(c *fiber.Ctx, bookID uuid.UUID) (Book, error)
 	CreateBook(c *fiber.Ctx, newBook Book) (Book, error)
 	UpdateBook(c *fiber.Ctx, updatedBook Book) (Book, error)
-	DeleteBook(c *fiber.Ctx, bookID uuid.UUID) error
+	PatchBook(c *fiber.Ctx, book Book, fields map[string]interface{}) (Book, error)
+	RevertBook(c *fiber.Ctx, book Book, revision BookRevision) (Book, error)
+	DeleteBook(c *fiber.Ctx, bookID uuid.UUID, version int) error
+	FindBooksByISBNs(c *fiber.Ctx, isbns []string) ([]Book, error)
+	FindBooksByTitleAuthors(c *fiber.Ctx, pairs [][]interface{}) ([]Book, error)
+	CreateBooks(c *fiber.Ctx, newBooks []Book, batchSize int) error
+	FindBookRevisions(c *fiber.Ctx, bookID uuid.UUID, page, perPage int) ([]BookRevision, int64, error)
+	ReplaceBookAuthors(c *fiber.Ctx, bookID uuid.UUID, credits []BookAuthor) error
+	FindBooksByAuthor(c *fiber.Ctx, authorID uuid.UUID) ([]Book, error)
+	FindBooksWithoutAuthors(c *fiber.Ctx, afterID uuid.UUID, limit int) ([]Book, error)
+	ReplaceBookTags(c *fiber.Ctx, bookID uuid.UUID, names []string) error
+	ReplaceBookCategories(c *fiber.Ctx, bookID uuid.UUID, categoryIDs []uuid.UUID) error
+	FindBookFacets(c *fiber.Ctx, filter BookFilter) (BookFacets, error)
+	FindBookRevision(c *fiber.Ctx, bookID uuid.UUID, version int) (BookRevision, error)
+	Transaction(c *fiber.Ctx, fn func(txRepo BookRepository) error) error
+	SaveExportJob(ctx context.Context, job ExportJob, ttl time.Duration) error
+	FindExportJob(c *fiber.Ctx, jobID uuid.UUID) (ExportJob, error)
+	FindDuplicateCandidates(c *fiber.Ctx, minScore float64, page, perPage int) ([]DuplicateCandidate, int64, error)
+	MergeBooks(c *fiber.Ctx, canonicalID, duplicateID uuid.UUID, version int) error
+	FindBookRedirect(c *fiber.Ctx, bookID uuid.UUID) (BookRedirect, error)
+	UpdateBookWork(c *fiber.Ctx, bookID uuid.UUID, workID *uuid.UUID, version int) error
+	UpdateBookSeries(c *fiber.Ctx, bookID uuid.UUID, seriesID *uuid.UUID, volume *float64, version int) error
+	FindBooksByWork(c *fiber.Ctx, workID uuid.UUID) ([]Book, error)
+	FindBooksBySeries(c *fiber.Ctx, seriesID uuid.UUID) ([]Book, error)
+	FindSeriesNeighbours(c *fiber.Ctx, book Book) (SeriesNeighbours, error)
+	FindBookFiles(c *fiber.Ctx, bookID uuid.UUID) ([]BookFile, error)
+	FindBookFile(c *fiber.Ctx, bookID uuid.UUID, format string) (BookFile, error)
+	SaveBookFile(c *fiber.Ctx, file BookFile) (BookFile, error)
+	DeleteBookFile(c *fiber.Ctx, file BookFile) error
+	HasOpenLoan(c *fiber.Ctx, bookID, userID uuid.UUID) (bool, error)
+	CreateBookDownload(c *fiber.Ctx, download BookDownload) error
+	FindDownloadStats(c *fiber.Ctx, bookID uuid.UUID, page, perPage int) (DownloadStatsResponse, error)
+	FindBookTranslations(c *fiber.Ctx, bookID uuid.UUID) ([]BookTranslation, error)
+	SaveBookTranslation(c *fiber.Ctx, translation BookTranslation) (BookTranslation, error)
+	DeleteBookTranslation(c *fiber.Ctx, bookID uuid.UUID, locale string) error
 }
 
 type bookRepository struct {
 	config *config.Config
 	db     *database.GormDB
+	redis  *database.RedisDB
 }
 
-func NewBookRepository(cfg *config.Config, db *database.GormDB) BookRepository {
-	return &bookRepository{config: cfg, db: db}
+func NewBookRepository(cfg *config.Config, db *database.GormDB, redis *database.RedisDB) BookRepository {
+	return &bookRepository{config: cfg, db: db, redis: redis}
 }
 
 // Repository methods
 
-func (r *bookRepository) FindAllBooks(c *fiber.Ctx) ([]Book, error) {
+func (r *bookRepository) FindAllBooks(c *fiber.Ctx, filter BookFilter) ([]Book, error) {
 	var books []Book
-	if err := r.db.DB.Find(&books).Error; err != nil {
+	if err := applyBookFilter(preloadRelations(r.db.DB), filter).Find(&books).Error; err != nil {
 		return nil, err
 	}
 	return books, nil
 }
 
+// StreamBooks hands matching books to fn one batch at a time instead of loading the whole table. It takes a
+// context rather than the fiber context because exports stream after the handler returned or in the background.
+func (r *bookRepository) StreamBooks(ctx context.Context, filter BookFilter, batchSize int, fn func(books []Book) error) error {
+	var books []Book
+	return applyBookFilter(r.db.DB.WithContext(ctx).Model(&Book{}), filter).FindInBatches(&books, batchSize, func(tx *gorm.DB, batch int) error {
+		return fn(books)
+	}).Error
+}
+
+// applyBookFilter narrows a books query, the text searches match a book in its own language or any translation
+func applyBookFilter(db *gorm.DB, filter BookFilter) *gorm.DB {
+	if filter.Query != "" {
+		query := "%" + strings.TrimSpace(filter.Query) + "%"
+		db = db.Where(`title ILIKE @query OR subtitle ILIKE @query OR author ILIKE @query OR books.id IN (
+			SELECT book_id FROM book_translations WHERE title ILIKE @query OR subtitle ILIKE @query)`,
+			map[string]interface{}{"query": query})
+	}
+	if filter.Title != "" {
+		title := "%" + strings.TrimSpace(filter.Title) + "%"
+		db = db.Where("title ILIKE @title OR books.id IN (SELECT book_id FROM book_translations WHERE title ILIKE @title)",
+			map[string]interface{}{"title": title})
+	}
+	if filter.Author != "" {
+		db = db.Where("author ILIKE ?", "%"+strings.TrimSpace(filter.Author)+"%")
+	}
+	if filter.ISBN != "" {
+		db = db.Where("isbn = ?", utils.NormalizeISBN(filter.ISBN))
+	}
+	if filter.Category != "" {
+		categoryKey := strings.TrimSpace(filter.Category)
+		db = db.Where("books.id IN (SELECT book_id FROM book_categories WHERE category_id IN ("+category.DescendantsQuery+"))", categoryKey, categoryKey)
+	}
+	if filter.Tag != "" {
+		db = db.Where("books.id IN (SELECT book_tags.book_id FROM book_tags JOIN tags ON tags.id = book_tags.tag_id WHERE tags.name = ?)", normalizeTag(filter.Tag))
+	}
+	if filter.Language != "" {
+		db = db.Where("LOWER(language) = LOWER(?)", strings.TrimSpace(filter.Language))
+	}
+	return db
+}
+
 func (r *bookRepository) FindBookByID(c *fiber.Ctx, bookID uuid.UUID) (Book, error) {
 	var book Book
-	if err := r.db.DB.Where("id = ?", bookID).First(&book).Error; err != nil {
+	if err := preloadRelations(r.db.DB).Where("id = ?", bookID).First(&book).Error; err != nil {
 		return Book{}, err
 	}
 	return book, nil
 }
 
+// preloadRelations loads the credits of each book in cover order together with the author records, its categories, tags,
+// work, series and translations
+func preloadRelations(db *gorm.DB) *gorm.DB {
+	return db.Preload("Authors", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	}).Preload("Authors.Author").
+		Preload("Categories", func(db *gorm.DB) *gorm.DB {
+			return db.Order("name")
+		}).
+		Preload("Tags", func(db *gorm.DB) *gorm.DB {
+			return db.Order("name")
+		}).
+		Preload("Work").
+		Preload("Series").
+		Preload("Translations")
+}
+
 func (r *bookRepository) CreateBook(c *fiber.Ctx, newBook Book) (Book, error) {
-	if err := r.db.DB.Create(&newBook).Error; err != nil {
+	err := r.db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(&newBook).Error; err != nil {
+			return err
+		}
+		revision := newBookRevision(c, BookRevisionCreate, newBook.ID, newBook.Version, BookSnapshot{}, newBookSnapshot(newBook))
+		return tx.Create(&revision).Error
+	})
+	if err != nil {
 		return Book{}, err
 	}
 	return newBook, nil
 }
 
+// UpdateBook writes the book only if nobody changed it since it was read, i.e. UPDATE ... WHERE version = ?
 func (r *bookRepository) UpdateBook(c *fiber.Ctx, updatedBook Book) (Book, error) {
-	if err := r.db.DB.Save(&updatedBook).Error; err != nil {
+	readVersion := updatedBook.Version
+	updatedBook.Version = readVersion + 1
+
+	err := r.db.DB.Transaction(func(tx *gorm.DB) error {
+		var before Book
+		if err := tx.Where("id = ?", updatedBook.ID).First(&before).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&Book{}).
+			Where("id = ? AND version = ?", updatedBook.ID, readVersion).
+			Select("*").Omit("id", "created_at", "rating_average", "rating_count", clause.Associations).
+			Updates(&updatedBook)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrVersionConflict
+		}
+
+		revision := newBookRevision(c, BookRevisionUpdate, updatedBook.ID, updatedBook.Version, newBookSnapshot(before), newBookSnapshot(updatedBook))
+		return tx.Create(&revision).Error
+	})
+	if err != nil {
+		return Book{}, err
+	}
+
+	return updatedBook, nil
+}
+
+// PatchBook writes only the given columns, including zero values, under the same version check as UpdateBook
+func (r *bookRepository) PatchBook(c *fiber.Ctx, book Book, fields map[string]interface{}) (Book, error) {
+	return r.patchBook(c, book, fields, BookRevisionUpdate, nil)
+}
+
+// RevertBook writes the snapshot of an earlier revision back as a new version
+func (r *bookRepository) RevertBook(c *fiber.Ctx, book Book, revision BookRevision) (Book, error) {
+	fields := map[string]interface{}{
+		"title": revision.Snapshot.Title, "subtitle": revision.Snapshot.Subtitle, "description": revision.Snapshot.Description,
+		"author": revision.Snapshot.Author, "isbn": revision.Snapshot.ISBN,
+		"language": revision.Snapshot.Language, "publisher": revision.Snapshot.Publisher, "page_count": revision.Snapshot.PageCount,
+	}
+	return r.patchBook(c, book, fields, BookRevisionRevert, &revision.Version)
+}
+
+func (r *bookRepository) patchBook(c *fiber.Ctx, book Book, fields map[string]interface{}, action BookRevisionAction, revertedFrom *int) (Book, error) {
+	fields["version"] = book.Version + 1
+	fields["updated_at"] = time.Now()
+
+	var updatedBook Book
+	err := r.db.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Book{}).
+			Where("id = ? AND version = ?", book.ID, book.Version).
+			Updates(fields)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrVersionConflict
+		}
+
+		if err := preloadRelations(tx).Where("id = ?", book.ID).First(&updatedBook).Error; err != nil {
+			return err
+		}
+		revision := newBookRevision(c, action, book.ID, updatedBook.Version, newBookSnapshot(book), newBookSnapshot(updatedBook))
+		revision.RevertedFrom = revertedFrom
+		return tx.Create(&revision).Error
+	})
+	if err != nil {
 		return Book{}, err
 	}
 
 	return updatedBook, nil
 }
 
-func (r *bookRepository) DeleteBook(c *fiber.Ctx, bookID uuid.UUID) error {
-	if err := r.db.DB.Delete(&Book{}, bookID).Error; err != nil {
+// DeleteBook removes the book, a non-zero version makes the delete conditional on it. Books with copies stay,
+// the copies and their loans belong to the circulation package and would otherwise go with the book.
+func (r *bookRepository) DeleteBook(c *fiber.Ctx, bookID uuid.UUID, version int) error {
+	return r.db.DB.Transaction(func(tx *gorm.DB) error {
+		var before Book
+		if err := tx.Where("id = ?", bookID).First(&before).Error; err != nil {
+			return err
+		}
+
+		var copies int64
+		if err := tx.Table("copies").Where("book_id = ?", bookID).Count(&copies).Error; err != nil {
+			return err
+		}
+		if copies > 0 {
+			return ErrBookHasCopies
+		}
+
+		query := tx.Where("id = ?", bookID)
+		if version != 0 {
+			query = query.Where("version = ?", version)
+		}
+
+		result := query.Delete(&Book{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrVersionConflict
+		}
+
+		// the snapshot keeps the last state so a deleted book can still be inspected
+		revision := newBookRevision(c, BookRevisionDelete, bookID, before.Version+1, newBookSnapshot(before), BookSnapshot{})
+		revision.Snapshot = newBookSnapshot(before)
+		return tx.Create(&revision).Error
+	})
+}
+
+func (r *bookRepository) FindBooksByISBNs(c *fiber.Ctx, isbns []string) ([]Book, error) {
+	var books []Book
+	if len(isbns) == 0 {
+		return books, nil
+	}
+	if err := r.db.DB.Where("isbn IN ?", isbns).Find(&books).Error; err != nil {
+		return nil, err
+	}
+	return books, nil
+}
+
+// FindBooksByTitleAuthors matches case-insensitive (title, author) pairs, each pair being {title, author} in lower case
+func (r *bookRepository) FindBooksByTitleAuthors(c *fiber.Ctx, pairs [][]interface{}) ([]Book, error) {
+	var books []Book
+	if len(pairs) == 0 {
+		return books, nil
+	}
+	if err := r.db.DB.Where("(LOWER(title), LOWER(author)) IN ?", pairs).Find(&books).Error; err != nil {
+		return nil, err
+	}
+	return books, nil
+}
+
+func (r *bookRepository) CreateBooks(c *fiber.Ctx, newBooks []Book, batchSize int) error {
+	if len(newBooks) == 0 {
+		return nil
+	}
+	return r.db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.CreateInBatches(&newBooks, batchSize).Error; err != nil {
+			return err
+		}
+
+		revisions := make([]BookRevision, 0, len(newBooks))
+		for _, book := range newBooks {
+			revisions = append(revisions, newBookRevision(c, BookRevisionCreate, book.ID, book.Version, BookSnapshot{}, newBookSnapshot(book)))
+		}
+		return tx.CreateInBatches(&revisions, batchSize).Error
+	})
+}
+
+// FindBookRevisions pages through the history of a book, newest first. It keeps working after the book was deleted.
+func (r *bookRepository) FindBookRevisions(c *fiber.Ctx, bookID uuid.UUID, page, perPage int) ([]BookRevision, int64, error) {
+	var revisions []BookRevision
+	var total int64
+
+	query := r.db.DB.Model(&BookRevision{}).Where("book_id = ?", bookID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * perPage
+	if err := query.Order("version DESC").Offset(offset).Limit(perPage).Find(&revisions).Error; err != nil {
+		return nil, 0, err
+	}
+	return revisions, total, nil
+}
+
+func (r *bookRepository) FindBookRevision(c *fiber.Ctx, bookID uuid.UUID, version int) (BookRevision, error) {
+	var revision BookRevision
+	if err := r.db.DB.Where("book_id = ? AND version = ?", bookID, version).First(&revision).Error; err != nil {
+		return BookRevision{}, err
+	}
+	return revision, nil
+}
+
+// ReplaceBookAuthors swaps the full credit list of a book in one transaction
+func (r *bookRepository) ReplaceBookAuthors(c *fiber.Ctx, bookID uuid.UUID, credits []BookAuthor) error {
+	return r.db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("book_id = ?", bookID).Delete(&BookAuthor{}).Error; err != nil {
+			return err
+		}
+		if len(credits) == 0 {
+			return nil
+		}
+		for i := range credits {
+			credits[i].BookID = bookID
+		}
+		return tx.Omit(clause.Associations).Create(&credits).Error
+	})
+}
+
+func (r *bookRepository) FindBooksByAuthor(c *fiber.Ctx, authorID uuid.UUID) ([]Book, error) {
+	var books []Book
+	err := preloadRelations(r.db.DB).
+		Where("id IN (?)", r.db.DB.Model(&BookAuthor{}).Select("book_id").Where("author_id = ?", authorID)).
+		Order("title").
+		Find(&books).Error
+	if err != nil {
+		return nil, err
+	}
+	return books, nil
+}
+
+// FindBooksWithoutAuthors pages by ID through books that still only have the legacy author string
+func (r *bookRepository) FindBooksWithoutAuthors(c *fiber.Ctx, afterID uuid.UUID, limit int) ([]Book, error) {
+	var books []Book
+	err := r.db.DB.
+		Where("id > ?", afterID).
+		Where("NOT EXISTS (?)", r.db.DB.Model(&BookAuthor{}).Select("1").Where("book_authors.book_id = books.id")).
+		Order("id").
+		Limit(limit).
+		Find(&books).Error
+	if err != nil {
+		return nil, err
+	}
+	return books, nil
+}
+
+// ReplaceBookTags sets the tags of a book, creating tags that do not exist yet
+func (r *bookRepository) ReplaceBookTags(c *fiber.Ctx, bookID uuid.UUID, names []string) error {
+	return r.db.DB.Transaction(func(tx *gorm.DB) error {
+		tags := make([]Tag, 0, len(names))
+		for _, name := range names {
+			tag := Tag{Name: name}
+			if err := tx.Where("name = ?", name).FirstOrCreate(&tag).Error; err != nil {
+				return err
+			}
+			tags = append(tags, tag)
+		}
+		return tx.Model(&Book{ID: bookID}).Association("Tags").Replace(tags)
+	})
+}
+
+// ReplaceBookCategories sets the categories of a book, every category must exist
+func (r *bookRepository) ReplaceBookCategories(c *fiber.Ctx, bookID uuid.UUID, categoryIDs []uuid.UUID) error {
+	return r.db.DB.Transaction(func(tx *gorm.DB) error {
+		var categories []category.Category
+		if len(categoryIDs) > 0 {
+			if err := tx.Where("id IN ?", categoryIDs).Find(&categories).Error; err != nil {
+				return err
+			}
+		}
+		if len(categories) != len(categoryIDs) {
+			return fmt.Errorf("%w: category not found", ErrInvalidCategories)
+		}
+		return tx.Model(&Book{ID: bookID}).Association("Categories").Replace(categories)
+	})
+}
+
+// FindBookFacets counts the books matching the filter per category, tag, language and author
+func (r *bookRepository) FindBookFacets(c *fiber.Ctx, filter BookFilter) (BookFacets, error) {
+	matching := applyBookFilter(r.db.DB.Model(&Book{}), filter).Select("books.id")
+	facets := BookFacets{Categories: []FacetCount{}, Tags: []FacetCount{}, Languages: []FacetCount{}, Authors: []FacetCount{}}
+
+	err := r.db.DB.Table("book_categories").
+		Select("categories.id AS id, categories.slug AS value, categories.name AS label, COUNT(*) AS count").
+		Joins("JOIN categories ON categories.id = book_categories.category_id").
+		Where("book_categories.book_id IN (?)", matching).
+		Group("categories.id, categories.slug, categories.name").
+		Order("count DESC, label").
+		Scan(&facets.Categories).Error
+	if err != nil {
+		return BookFacets{}, err
+	}
+
+	err = r.db.DB.Table("book_tags").
+		Select("tags.name AS value, COUNT(*) AS count").
+		Joins("JOIN tags ON tags.id = book_tags.tag_id").
+		Where("book_tags.book_id IN (?)", matching).
+		Group("tags.name").
+		Order("count DESC, value").
+		Scan(&facets.Tags).Error
+	if err != nil {
+		return BookFacets{}, err
+	}
+
+	err = r.db.DB.Model(&Book{}).
+		Select("language AS value, COUNT(*) AS count").
+		Where("id IN (?)", matching).
+		Where("language <> ''").
+		Group("language").
+		Order("count DESC, value").
+		Scan(&facets.Languages).Error
+	if err != nil {
+		return BookFacets{}, err
+	}
+
+	err = r.db.DB.Table("book_authors").
+		Select("authors.id AS id, authors.name AS value, COUNT(*) AS count").
+		Joins("JOIN authors ON authors.id = book_authors.author_id").
+		Where("book_authors.book_id IN (?)", matching).
+		Group("authors.id, authors.name").
+		Order("count DESC, value").
+		Scan(&facets.Authors).Error
+	if err != nil {
+		return BookFacets{}, err
+	}
+
+	return facets, nil
+}
+
+// Transaction runs fn with a repository bound to a single database transaction
+func (r *bookRepository) Transaction(c *fiber.Ctx, fn func(txRepo BookRepository) error) error {
+	return r.db.DB.Transaction(func(tx *gorm.DB) error {
+		return fn(&bookRepository{config: r.config, db: &database.GormDB{DB: tx, Config: r.db.Config}, redis: r.redis})
+	})
+}
+
+func (r *bookRepository) SaveExportJob(ctx context.Context, job ExportJob, ttl time.Duration) error {
+	jobData, err := json.Marshal(job)
+	if err != nil {
 		return err
 	}
-	return nil
+
+	jobKey := fmt.Sprintf("book_export_job:%s", job.ID.String())
+	return r.redis.Client.Set(ctx, jobKey, jobData, ttl).Err()
+}
+
+func (r *bookRepository) FindExportJob(c *fiber.Ctx, jobID uuid.UUID) (ExportJob, error) {
+	jobKey := fmt.Sprintf("book_export_job:%s", jobID.String())
+	jobData, err := r.redis.Client.Get(context.Background(), jobKey).Bytes()
+	if err != nil {
+		return ExportJob{}, err
+	}
+
+	var job ExportJob
+	if err := json.Unmarshal(jobData, &job); err != nil {
+		return ExportJob{}, err
+	}
+	return job, nil
+}
+
+func (r *bookRepository) FindDuplicateCandidates(c *fiber.Ctx, minScore float64, page, perPage int) ([]DuplicateCandidate, int64, error) {
+	var candidates []DuplicateCandidate
+	var total int64
+
+	query := r.db.DB.Table("(?) AS candidates", duplicateCandidatesQuery(r.db.DB)).Where("score >= ?", minScore)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * perPage
+	if err := query.Order("score DESC, book_id, duplicate_id").Offset(offset).Limit(perPage).Scan(&candidates).Error; err != nil {
+		return nil, 0, err
+	}
+	if len(candidates) == 0 {
+		return []DuplicateCandidate{}, total, nil
+	}
+
+	bookIDs := make([]uuid.UUID, 0, len(candidates)*2)
+	for _, candidate := range candidates {
+		bookIDs = append(bookIDs, candidate.BookID, candidate.DuplicateID)
+	}
+	var books []Book
+	if err := preloadRelations(r.db.DB).Where("id IN ?", bookIDs).Find(&books).Error; err != nil {
+		return nil, 0, err
+	}
+	byID := make(map[uuid.UUID]Book, len(books))
+	for _, book := range books {
+		byID[book.ID] = book
+	}
+	for i := range candidates {
+		candidates[i].Book = byID[candidates[i].BookID]
+		candidates[i].Duplicate = byID[candidates[i].DuplicateID]
+	}
+	return candidates, total, nil
+}
+
+// MergeBooks folds the duplicate into the canonical book and deletes it, leaving a redirect behind. A version
+// other than 0 must still be the canonical book's, as in DeleteBook.
+func (r *bookRepository) MergeBooks(c *fiber.Ctx, canonicalID, duplicateID uuid.UUID, version int) error {
+	return r.db.DB.Transaction(func(tx *gorm.DB) error {
+		var books []Book
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ?", []uuid.UUID{canonicalID, duplicateID}).
+			Order("id").Find(&books).Error; err != nil {
+			return err
+		}
+		if len(books) != 2 {
+			return gorm.ErrRecordNotFound
+		}
+		canonical, duplicate := books[0], books[1]
+		if duplicate.ID != duplicateID {
+			canonical, duplicate = duplicate, canonical
+		}
+		if version != 0 && canonical.Version != version {
+			return ErrVersionConflict
+		}
+
+		if err := mergeBookRecords(tx, canonicalID, duplicateID); err != nil {
+			return err
+		}
+
+		// the canonical book now shows what it took over, so the merge is a new version of it too
+		var merged Book
+		if err := tx.Where("id = ?", canonicalID).First(&merged).Error; err != nil {
+			return err
+		}
+		changes := columnChanges(canonical, merged)
+		changes["merged_from"] = FieldChange{To: duplicateID}
+		if err := bumpBookVersion(c, tx, BookRevisionMerge, canonicalID, changes); err != nil {
+			return err
+		}
+
+		revision := newBookRevision(c, BookRevisionMerge, duplicateID, duplicate.Version+1, newBookSnapshot(duplicate), BookSnapshot{})
+		revision.Snapshot = newBookSnapshot(duplicate)
+		if err := tx.Create(&revision).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&Book{}, "id = ?", duplicateID).Error
+	})
+}
+
+func (r *bookRepository) FindBookRedirect(c *fiber.Ctx, bookID uuid.UUID) (BookRedirect, error) {
+	var redirect BookRedirect
+	if err := r.db.DB.Where("from_id = ?", bookID).First(&redirect).Error; err != nil {
+		return BookRedirect{}, err
+	}
+	return redirect, nil
+}
+
+func (r *bookRepository) UpdateBookWork(c *fiber.Ctx, bookID uuid.UUID, workID *uuid.UUID, version int) error {
+	if workID != nil {
+		if err := findWork(r.db.DB, *workID); err != nil {
+			return err
+		}
+	}
+	return r.updateBookColumns(c, bookID, version, map[string]interface{}{"work_id": workID})
+}
+
+func (r *bookRepository) UpdateBookSeries(c *fiber.Ctx, bookID uuid.UUID, seriesID *uuid.UUID, volume *float64, version int) error {
+	if seriesID != nil {
+		if err := findSeries(r.db.DB, *seriesID); err != nil {
+			return err
+		}
+	}
+	return r.updateBookColumns(c, bookID, version, map[string]interface{}{"series_id": seriesID, "series_volume": volume})
+}
+
+// updateBookColumns writes grouping columns, a change to them is a new version of the book. A version other
+// than 0 must still be the book's, as in DeleteBook.
+func (r *bookRepository) updateBookColumns(c *fiber.Ctx, bookID uuid.UUID, version int, fields map[string]interface{}) error {
+	return r.db.DB.Transaction(func(tx *gorm.DB) error {
+		var before, after Book
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", bookID).First(&before).Error; err != nil {
+			return err
+		}
+		if version != 0 && before.Version != version {
+			return ErrVersionConflict
+		}
+		if err := tx.Model(&Book{}).Where("id = ?", bookID).UpdateColumns(fields).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("id = ?", bookID).First(&after).Error; err != nil {
+			return err
+		}
+
+		changes := columnChanges(before, after)
+		if len(changes) == 0 {
+			return nil
+		}
+		return bumpBookVersion(c, tx, BookRevisionUpdate, bookID, changes)
+	})
+}
+
+func (r *bookRepository) FindBooksByWork(c *fiber.Ctx, workID uuid.UUID) ([]Book, error) {
+	if err := r.db.DB.Select("id").Where("id = ?", workID).First(&work.Work{}).Error; err != nil {
+		return nil, err
+	}
+
+	var books []Book
+	if err := preloadRelations(r.db.DB).Where("work_id = ?", workID).Order("language, title, created_at").Find(&books).Error; err != nil {
+		return nil, err
+	}
+	return books, nil
+}
+
+// FindBooksBySeries lists a series in volume order, books without a volume number come last
+func (r *bookRepository) FindBooksBySeries(c *fiber.Ctx, seriesID uuid.UUID) ([]Book, error) {
+	if err := r.db.DB.Select("id").Where("id = ?", seriesID).First(&series.Series{}).Error; err != nil {
+		return nil, err
+	}
+
+	var books []Book
+	if err := preloadRelations(r.db.DB).Where("series_id = ?", seriesID).Order("series_volume NULLS LAST, title").Find(&books).Error; err != nil {
+		return nil, err
+	}
+	return books, nil
+}
+
+// FindSeriesNeighbours finds the closest lower and higher volume of the book's series,
+// several editions of one volume resolve to the oldest
+func (r *bookRepository) FindSeriesNeighbours(c *fiber.Ctx, book Book) (SeriesNeighbours, error) {
+	var neighbours SeriesNeighbours
+	if book.SeriesID == nil || book.SeriesVolume == nil {
+		return neighbours, nil
+	}
+
+	var previous, next []Book
+	err := r.db.DB.Preload("Translations").Where("series_id = ? AND series_volume < ?", *book.SeriesID, *book.SeriesVolume).
+		Order("series_volume DESC, created_at").Limit(1).Find(&previous).Error
+	if err != nil {
+		return SeriesNeighbours{}, err
+	}
+	err = r.db.DB.Preload("Translations").Where("series_id = ? AND series_volume > ?", *book.SeriesID, *book.SeriesVolume).
+		Order("series_volume, created_at").Limit(1).Find(&next).Error
+	if err != nil {
+		return SeriesNeighbours{}, err
+	}
+
+	if len(previous) > 0 {
+		neighbours.Previous = &previous[0]
+	}
+	if len(next) > 0 {
+		neighbours.Next = &next[0]
+	}
+	return neighbours, nil
+}
+
+// bookFilesQuery selects book files along with how often each was downloaded
+func bookFilesQuery(db *gorm.DB) *gorm.DB {
+	return db.Model(&BookFile{}).
+		Select("book_files.*, (SELECT COUNT(*) FROM book_downloads WHERE book_downloads.file_id = book_files.id) AS download_count")
+}
+
+func (r *bookRepository) FindBookFiles(c *fiber.Ctx, bookID uuid.UUID) ([]BookFile, error) {
+	var files []BookFile
+	if err := bookFilesQuery(r.db.DB).Where("book_id = ?", bookID).Order("format").Find(&files).Error; err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func (r *bookRepository) FindBookFile(c *fiber.Ctx, bookID uuid.UUID, format string) (BookFile, error) {
+	var file BookFile
+	if err := bookFilesQuery(r.db.DB).Where("book_id = ? AND format = ?", bookID, format).First(&file).Error; err != nil {
+		return BookFile{}, err
+	}
+	return file, nil
+}
+
+// SaveBookFile inserts a new file or overwrites the existing row of a replaced one
+func (r *bookRepository) SaveBookFile(c *fiber.Ctx, file BookFile) (BookFile, error) {
+	if err := r.db.DB.Omit("Book").Save(&file).Error; err != nil {
+		return BookFile{}, err
+	}
+	return file, nil
+}
+
+func (r *bookRepository) DeleteBookFile(c *fiber.Ctx, file BookFile) error {
+	return r.db.DB.Delete(&BookFile{}, "id = ?", file.ID).Error
+}
+
+// HasOpenLoan reports whether the user currently has a copy of the book checked out, the loans and copies
+// tables belong to the circulation package
+func (r *bookRepository) HasOpenLoan(c *fiber.Ctx, bookID, userID uuid.UUID) (bool, error) {
+	var open bool
+	err := r.db.DB.Raw(`SELECT EXISTS (
+		SELECT 1 FROM loans JOIN copies ON copies.id = loans.copy_id
+		WHERE copies.book_id = ? AND loans.user_id = ? AND loans.returned_at IS NULL)`, bookID, userID).
+		Scan(&open).Error
+	return open, err
+}
+
+func (r *bookRepository) CreateBookDownload(c *fiber.Ctx, download BookDownload) error {
+	return r.db.DB.Omit("Book", "File").Create(&download).Error
+}
+
+// FindDownloadStats totals the downloads of a book per format and per user, busiest users first
+func (r *bookRepository) FindDownloadStats(c *fiber.Ctx, bookID uuid.UUID, page, perPage int) (DownloadStatsResponse, error) {
+	stats := DownloadStatsResponse{BookID: bookID, Formats: map[string]int64{}, Users: []UserDownloads{}}
+
+	var formats []struct {
+		Format    string
+		Downloads int64
+	}
+	err := r.db.DB.Model(&BookDownload{}).Select("format, COUNT(*) AS downloads").
+		Where("book_id = ?", bookID).Group("format").Scan(&formats).Error
+	if err != nil {
+		return DownloadStatsResponse{}, err
+	}
+	for _, format := range formats {
+		stats.Formats[format.Format] = format.Downloads
+		stats.Downloads += format.Downloads
+	}
+
+	users := r.db.DB.Model(&BookDownload{}).Where("book_id = ?", bookID)
+	if err := users.Distinct("user_id").Count(&stats.Total).Error; err != nil {
+		return DownloadStatsResponse{}, err
+	}
+
+	offset := (page - 1) * perPage
+	err = r.db.DB.Model(&BookDownload{}).
+		Select("user_id, COUNT(*) AS downloads, MAX(created_at) AS last_downloaded_at").
+		Where("book_id = ?", bookID).Group("user_id").
+		Order("downloads DESC, last_downloaded_at DESC").Offset(offset).Limit(perPage).
+		Scan(&stats.Users).Error
+	if err != nil {
+		return DownloadStatsResponse{}, err
+	}
+	return stats, nil
+}
+
+func (r *bookRepository) FindBookTranslations(c *fiber.Ctx, bookID uuid.UUID) ([]BookTranslation, error) {
+	var translations []BookTranslation
+	if err := r.db.DB.Where("book_id = ?", bookID).Order("locale").Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// SaveBookTranslation inserts the translation or overwrites the one the book already has in that locale
+func (r *bookRepository) SaveBookTranslation(c *fiber.Ctx, translation BookTranslation) (BookTranslation, error) {
+	// localized reads are tagged with the book's version, so a translation write bumps it
+	err := r.db.DB.Transaction(func(tx *gorm.DB) error {
+		var before BookTranslation
+		if err := tx.Where("book_id = ? AND locale = ?", translation.BookID, translation.Locale).Limit(1).Find(&before).Error; err != nil {
+			return err
+		}
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "book_id"}, {Name: "locale"}},
+			DoUpdates: clause.AssignmentColumns([]string{"title", "subtitle", "description", "updated_at"}),
+		}).Create(&translation).Error
+		if err != nil {
+			return err
+		}
+		return bumpBookVersion(c, tx, BookRevisionUpdate, translation.BookID, translationChanges(before, translation))
+	})
+	if err != nil {
+		return BookTranslation{}, err
+	}
+	return r.findBookTranslation(translation.BookID, translation.Locale)
+}
+
+// findBookTranslation reads the row back, after an upsert the created_at in memory is not the stored one
+func (r *bookRepository) findBookTranslation(bookID uuid.UUID, locale string) (BookTranslation, error) {
+	var translation BookTranslation
+	if err := r.db.DB.Where("book_id = ? AND locale = ?", bookID, locale).First(&translation).Error; err != nil {
+		return BookTranslation{}, err
+	}
+	return translation, nil
+}
+
+func (r *bookRepository) DeleteBookTranslation(c *fiber.Ctx, bookID uuid.UUID, locale string) error {
+	return r.db.DB.Transaction(func(tx *gorm.DB) error {
+		var before BookTranslation
+		if err := tx.Where("book_id = ? AND locale = ?", bookID, locale).First(&before).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&BookTranslation{}, "book_id = ? AND locale = ?", bookID, locale).Error; err != nil {
+			return err
+		}
+		return bumpBookVersion(c, tx, BookRevisionUpdate, bookID, translationChanges(before, BookTranslation{Locale: locale}))
+	})
 }
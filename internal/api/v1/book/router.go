@@ -9,17 +9,55 @@ import (
 func RegisterRoutes(cfg *config.Config, router fiber.Router, handler *BookHandler) {
 	bookGroup := router.Group("/books")
 	{
+		// Moderator or Admin exports and lookups - registered before "/:id" so "export" is not parsed as a book ID
+		bookGroup.Get("/export", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.ExportBooks)
+		bookGroup.Get("/export/jobs/:jobID", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.GetExportJob)
+		bookGroup.Post("/lookup", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.LookupBook)
+		bookGroup.Get("/duplicates", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.GetDuplicates)
+		// change feed over server-sent events, public like the listing it keeps fresh
+		bookGroup.Get("/events", handler.StreamBookEvents)
+
 		// Public routes - anyone can access
 		bookGroup.Get("", handler.GetBooks)
 		bookGroup.Get("/:id", handler.GetBook)
+		bookGroup.Get("/:id/translations", handler.GetBookTranslations)
 
 		// User authenticated routes - any authenticated user can access
 		bookGroup.Post("", middleware.JWTMiddleware(cfg), handler.CreateBook)
+		// each operation is checked against the role rules of its single route
+		bookGroup.Post("/batch", middleware.JWTMiddleware(cfg), handler.BatchBooks)
+		bookGroup.Get("/:id/files", middleware.JWTMiddleware(cfg), handler.GetBookFiles)
+		// borrowers need an open loan of the book, checked by the service
+		bookGroup.Get("/:id/download", middleware.JWTMiddleware(cfg), handler.DownloadBook)
 
 		// Moderator or Admin routes - only moderators and admins can update books
+		bookGroup.Post("/import", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.ImportBooks)
 		bookGroup.Patch("/:id", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.UpdateBook)
+		bookGroup.Put("/:id/authors", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.SetBookAuthors)
+		bookGroup.Put("/:id/tags", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.SetBookTags)
+		bookGroup.Put("/:id/categories", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.SetBookCategories)
+		bookGroup.Put("/:id/work", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.SetBookWork)
+		bookGroup.Put("/:id/series", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.SetBookSeries)
+		bookGroup.Put("/:id/translations/:locale", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.SetBookTranslation)
+		bookGroup.Delete("/:id/translations/:locale", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.DeleteBookTranslation)
+		bookGroup.Put("/:id/cover", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.UploadCover)
+		// e-book uploads are the largest bodies, leave some room for the multipart framing around the file
+		uploadLimit := middleware.BodyLimit(middleware.BodyLimitConfig{Limit: int(cfg.BookFiles.MaxSize) + 1024*1024})
+		bookGroup.Post("/:id/files", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), uploadLimit, handler.UploadBookFile)
+		bookGroup.Delete("/:id/files/:format", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.DeleteBookFile)
+		bookGroup.Get("/:id/downloads", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.GetDownloadStats)
+		// the history names the users behind each write
+		bookGroup.Get("/:id/history", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.GetBookHistory)
+		bookGroup.Get("/:id/history/:version", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.GetBookRevision)
+		bookGroup.Post("/:id/revert/:version", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.RevertBook)
 
-		// Admin only routes - only admins can delete books
+		// Admin only routes - only admins can delete books, a merge deletes the duplicate
 		bookGroup.Delete("/:id", middleware.JWTMiddleware(cfg), middleware.AdminOnly(), handler.DeleteBook)
+		bookGroup.Post("/:id/merge", middleware.JWTMiddleware(cfg), middleware.AdminOnly(), handler.MergeBook)
 	}
+
+	// Books of an author, work or series live here because the book package owns those relations
+	router.Get("/authors/:id/books", handler.GetAuthorBooks)
+	router.Get("/works/:id/books", handler.GetWorkBooks)
+	router.Get("/series/:id/books", handler.GetSeriesBooks)
 }
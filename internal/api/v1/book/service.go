@@ -1,38 +1,108 @@
 package book
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
 	"github.com/google/uuid"
 	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/author"
+	"github.com/jerpsp/go-fiber-beginner/middleware"
+	"github.com/jerpsp/go-fiber-beginner/pkg/catalogue"
+	"github.com/jerpsp/go-fiber-beginner/pkg/export"
+	"github.com/jerpsp/go-fiber-beginner/pkg/imaging"
+	"github.com/jerpsp/go-fiber-beginner/pkg/patch"
+	"github.com/jerpsp/go-fiber-beginner/pkg/storage"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidCover       = errors.New("invalid cover image")
+	ErrVersionConflict    = errors.New("book was modified by another request")
+	ErrPreconditionFailed = errors.New("book does not match If-Match")
+	ErrBookDeleted        = errors.New("book was deleted and cannot be reverted")
+	ErrBookHasCopies      = errors.New("book still has copies, remove or withdraw them in circulation first")
+	ErrInvalidBook        = errors.New("invalid book")
 )
 
 // Setup
 type BookService interface {
-	GetBooks(c *fiber.Ctx) ([]Book, error)
+	GetBooks(c *fiber.Ctx, filter BookFilter) ([]Book, error)
 	GetBook(c *fiber.Ctx, bookID uuid.UUID) (Book, error)
 	CreateBook(c *fiber.Ctx, newBook Book) (Book, error)
-	UpdateBook(c *fiber.Ctx, bookID uuid.UUID, updatedBook BookRequest) (Book, error)
-	DeleteBook(c *fiber.Ctx, bookID uuid.UUID) error
+	UpdateBook(c *fiber.Ctx, bookID uuid.UUID, contentType string, body []byte, ifMatch string) (Book, error)
+	DeleteBook(c *fiber.Ctx, bookID uuid.UUID, ifMatch string) error
+	GetBookHistory(c *fiber.Ctx, bookID uuid.UUID, filter BookHistoryFilter) ([]BookRevision, int64, error)
+	GetBookRevision(c *fiber.Ctx, bookID uuid.UUID, version int) (BookRevision, error)
+	RevertBook(c *fiber.Ctx, bookID uuid.UUID, version int, ifMatch string) (Book, error)
+	SetBookAuthors(c *fiber.Ctx, bookID uuid.UUID, request BookAuthorsRequest) (Book, error)
+	GetAuthorBooks(c *fiber.Ctx, authorID uuid.UUID) ([]Book, error)
+	SetBookTags(c *fiber.Ctx, bookID uuid.UUID, request BookTagsRequest) (Book, error)
+	SetBookCategories(c *fiber.Ctx, bookID uuid.UUID, request BookCategoriesRequest) (Book, error)
+	GetBookFacets(c *fiber.Ctx, filter BookFilter) (BookFacets, error)
+	MigrateLegacyAuthors(c *fiber.Ctx) (int, error)
+	UploadCover(c *fiber.Ctx, bookID uuid.UUID, file *multipart.FileHeader) (Book, error)
+	ImportBooks(c *fiber.Ctx, r io.Reader, format string, dryRun bool) (*ImportReport, error)
+	ExportBooks(ctx context.Context, w io.Writer, filter BookFilter, format string) error
+	StartExportJob(c *fiber.Ctx, filter BookFilter, format string) (ExportJob, error)
+	GetExportJob(c *fiber.Ctx, jobID uuid.UUID) (ExportJob, error)
+	LookupISBN(c *fiber.Ctx, isbn string, create bool) (BookLookup, error)
+	BatchBooks(c *fiber.Ctx, request BatchRequest, role string) (BatchResponse, error)
+	GetDuplicates(c *fiber.Ctx, filter DuplicateFilter) ([]DuplicateCandidate, int64, error)
+	MergeBook(c *fiber.Ctx, canonicalID uuid.UUID, request MergeRequest, ifMatch string) (Book, error)
+	ResolveBookRedirect(c *fiber.Ctx, bookID uuid.UUID) (uuid.UUID, error)
+	IncludeRelated(c *fiber.Ctx, book Book, includes BookIncludes) (Book, error)
+	SetBookWork(c *fiber.Ctx, bookID uuid.UUID, request BookWorkRequest, ifMatch string) (Book, error)
+	SetBookSeries(c *fiber.Ctx, bookID uuid.UUID, request BookSeriesRequest, ifMatch string) (Book, error)
+	GetWorkBooks(c *fiber.Ctx, workID uuid.UUID) ([]Book, error)
+	GetSeriesBooks(c *fiber.Ctx, seriesID uuid.UUID) ([]Book, error)
+	GetBookFiles(c *fiber.Ctx, bookID uuid.UUID) ([]BookFile, error)
+	UploadBookFile(c *fiber.Ctx, bookID uuid.UUID, file *multipart.FileHeader, checksum string) (BookFile, error)
+	DeleteBookFile(c *fiber.Ctx, bookID uuid.UUID, format string) error
+	GetDownloadLink(c *fiber.Ctx, bookID, userID uuid.UUID, role, format string) (BookDownloadLink, error)
+	GetDownloadStats(c *fiber.Ctx, bookID uuid.UUID, filter DownloadStatsFilter) (DownloadStatsResponse, error)
+	GetBookTranslations(c *fiber.Ctx, bookID uuid.UUID) ([]BookTranslation, error)
+	SetBookTranslation(c *fiber.Ctx, bookID uuid.UUID, locale string, request BookTranslationRequest) (BookTranslation, error)
+	DeleteBookTranslation(c *fiber.Ctx, bookID uuid.UUID, locale string) error
 }
 
 type bookService struct {
-	config *config.Config
-	repo   BookRepository
+	config     *config.Config
+	repo       BookRepository
+	authorRepo author.AuthorRepository
+	s3Repo     storage.S3Repository
+	catalogue  catalogue.Provider
+	// cleanups collects storage deletes made inside a transaction, they run once it committed
+	cleanups *[]func()
 }
 
-func NewBookService(config *config.Config, repo BookRepository) BookService {
-	return &bookService{config: config, repo: repo}
+func NewBookService(config *config.Config, repo BookRepository, authorRepo author.AuthorRepository, s3Repo storage.S3Repository,
+	catalogueProvider catalogue.Provider) BookService {
+	return &bookService{config: config, repo: repo, authorRepo: authorRepo, s3Repo: s3Repo, catalogue: catalogueProvider}
 }
 
 // Service methods
-func (s *bookService) GetBooks(c *fiber.Ctx) ([]Book, error) {
-	books, err := s.repo.FindAllBooks(c)
+func (s *bookService) GetBooks(c *fiber.Ctx, filter BookFilter) ([]Book, error) {
+	books, err := s.repo.FindAllBooks(c, filter)
 	if err != nil {
 		return nil, err
 	}
 
+	for i := range books {
+		books[i] = s.withCovers(books[i])
+	}
+
 	return books, nil
 }
 
@@ -42,35 +112,1089 @@ func (s *bookService) GetBook(c *fiber.Ctx, bookID uuid.UUID) (Book, error) {
 		return Book{}, err
 	}
 
-	return book, nil
+	return s.withCovers(book), nil
 }
 
 func (s *bookService) CreateBook(c *fiber.Ctx, newBook Book) (Book, error) {
-	newBook, err := s.repo.CreateBook(c, newBook)
+	newBook.ISBN = utils.NormalizeISBN(newBook.ISBN)
+	if newBook.ISBN != "" && !utils.IsValidISBN(newBook.ISBN) {
+		return Book{}, fmt.Errorf("invalid isbn: %s", newBook.ISBN)
+	}
+	newBook.Language = strings.TrimSpace(newBook.Language)
+	if newBook.Language != "" && utils.GetValidator().Var(newBook.Language, "bcp47_language_tag") != nil {
+		return Book{}, fmt.Errorf("invalid language: %s", newBook.Language)
+	}
+
+	err := s.repo.Transaction(c, func(txRepo BookRepository) error {
+		created, err := txRepo.CreateBook(c, newBook)
+		if err != nil {
+			return err
+		}
+		newBook = created
+
+		// credit the author string right away, moderators can refine the credits later
+		_, err = linkLegacyAuthors(c, txRepo, s.authorRepo, newBook)
+		return err
+	})
+	if err != nil {
+		return Book{}, err
+	}
+
+	return s.GetBook(c, newBook.ID)
+}
+
+// UpdateBook applies a merge patch or JSON patch to the book and writes only the columns it changed
+func (s *bookService) UpdateBook(c *fiber.Ctx, bookID uuid.UUID, contentType string, body []byte, ifMatch string) (Book, error) {
+	book, err := s.repo.FindBookByID(c, bookID)
+	if err != nil {
+		return Book{}, err
+	}
+	if ifMatch != "" && !book.MatchesIfMatch(ifMatch) {
+		return Book{}, ErrPreconditionFailed
+	}
+
+	current := BookRequest{Title: book.Title, Subtitle: book.Subtitle, Description: book.Description, Author: book.Author,
+		ISBN: book.ISBN, Language: book.Language, Publisher: book.Publisher, PageCount: book.PageCount}
+	patched := current
+	if err := patch.Apply(&patched, contentType, body); err != nil {
+		return Book{}, err
+	}
+	patched.Title = strings.TrimSpace(patched.Title)
+	patched.Subtitle = strings.TrimSpace(patched.Subtitle)
+	patched.Description = strings.TrimSpace(patched.Description)
+	patched.Author = strings.TrimSpace(patched.Author)
+	patched.ISBN = utils.NormalizeISBN(patched.ISBN)
+	patched.Language = strings.TrimSpace(patched.Language)
+	patched.Publisher = strings.TrimSpace(patched.Publisher)
+	if err := utils.Validate(&patched); err != nil {
+		return Book{}, fmt.Errorf("%w: %v", ErrInvalidBook, err)
+	}
+	if patched.ISBN != "" && !utils.IsValidISBN(patched.ISBN) {
+		return Book{}, fmt.Errorf("%w: invalid isbn: %s", ErrInvalidBook, patched.ISBN)
+	}
+
+	changes := patch.Changes(current, patched)
+	if len(changes) == 0 {
+		return s.withCovers(book), nil
+	}
+
+	updatedBook, err := s.repo.PatchBook(c, book, changes)
+	if err != nil {
+		if ifMatch != "" && errors.Is(err, ErrVersionConflict) {
+			return Book{}, ErrPreconditionFailed
+		}
+		return Book{}, err
+	}
+
+	return s.withCovers(updatedBook), nil
+}
+
+// DeleteBook removes the book, its cover and e-book objects are removed from storage once the delete succeeded
+func (s *bookService) DeleteBook(c *fiber.Ctx, bookID uuid.UUID, ifMatch string) error {
+	book, err := s.repo.FindBookByID(c, bookID)
+	if err != nil {
+		return err
+	}
+	version := 0
+	if ifMatch != "" {
+		if !book.MatchesIfMatch(ifMatch) {
+			return ErrPreconditionFailed
+		}
+		version = book.Version
+	}
+	files, err := s.repo.FindBookFiles(c, bookID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteBook(c, bookID, version); err != nil {
+		if ifMatch != "" && errors.Is(err, ErrVersionConflict) {
+			return ErrPreconditionFailed
+		}
+		return err
+	}
+
+	s.afterCommit(func() {
+		if book.CoverPrefix != "" {
+			s.deleteCover(book.CoverPrefix)
+		}
+		for _, file := range files {
+			s.deleteBookFileObject(file.ObjectKey)
+		}
+	})
+	return nil
+}
+
+func (s *bookService) GetBookHistory(c *fiber.Ctx, bookID uuid.UUID, filter BookHistoryFilter) ([]BookRevision, int64, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 || filter.Limit > 100 {
+		filter.Limit = 10
+	}
+
+	revisions, total, err := s.repo.FindBookRevisions(c, bookID, filter.Page, filter.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return nil, 0, gorm.ErrRecordNotFound
+	}
+	return revisions, total, nil
+}
+
+func (s *bookService) GetBookRevision(c *fiber.Ctx, bookID uuid.UUID, version int) (BookRevision, error) {
+	return s.repo.FindBookRevision(c, bookID, version)
+}
+
+// RevertBook restores the catalogue fields from an earlier revision, the revert itself becomes a new revision.
+// A deleted or merged book keeps its history but is gone, so it cannot be reverted back into existence.
+func (s *bookService) RevertBook(c *fiber.Ctx, bookID uuid.UUID, version int, ifMatch string) (Book, error) {
+	revision, err := s.repo.FindBookRevision(c, bookID, version)
+	if err != nil {
+		return Book{}, err
+	}
+	book, err := s.repo.FindBookByID(c, bookID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Book{}, ErrBookDeleted
+		}
+		return Book{}, err
+	}
+	if ifMatch != "" && !book.MatchesIfMatch(ifMatch) {
+		return Book{}, ErrPreconditionFailed
+	}
+
+	revertedBook, err := s.repo.RevertBook(c, book, revision)
+	if err != nil {
+		if ifMatch != "" && errors.Is(err, ErrVersionConflict) {
+			return Book{}, ErrPreconditionFailed
+		}
+		return Book{}, err
+	}
+
+	return s.withCovers(revertedBook), nil
+}
+
+// SetBookAuthors replaces the credits of a book and keeps the display author string in step with them
+func (s *bookService) SetBookAuthors(c *fiber.Ctx, bookID uuid.UUID, request BookAuthorsRequest) (Book, error) {
+	if err := utils.Validate(&request); err != nil {
+		return Book{}, fmt.Errorf("%w: %v", ErrInvalidAuthors, err)
+	}
+
+	book, err := s.repo.FindBookByID(c, bookID)
+	if err != nil {
+		return Book{}, err
+	}
+
+	credits, err := creditsFromRequest(c, s.authorRepo, request)
+	if err != nil {
+		return Book{}, err
+	}
+
+	fields := map[string]interface{}{}
+	if name := byline(credits); name != "" && name != book.Author {
+		fields["author"] = name
+	}
+	err = s.repo.Transaction(c, func(txRepo BookRepository) error {
+		if err := txRepo.ReplaceBookAuthors(c, bookID, credits); err != nil {
+			return err
+		}
+		// the credits are part of the book, new credits are a new version even when the byline stays the same
+		_, err := txRepo.PatchBook(c, book, fields)
+		return err
+	})
+	if err != nil {
+		return Book{}, err
+	}
+
+	return s.GetBook(c, bookID)
+}
+
+func (s *bookService) GetAuthorBooks(c *fiber.Ctx, authorID uuid.UUID) ([]Book, error) {
+	if _, err := s.authorRepo.FindAuthorByID(c, authorID); err != nil {
+		return nil, err
+	}
+
+	books, err := s.repo.FindBooksByAuthor(c, authorID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range books {
+		books[i] = s.withCovers(books[i])
+	}
+	return books, nil
+}
+
+// MigrateLegacyAuthors credits every book that has no author records yet, running it twice is harmless
+func (s *bookService) MigrateLegacyAuthors(c *fiber.Ctx) (int, error) {
+	linked := 0
+	afterID := uuid.Nil
+	for {
+		books, err := s.repo.FindBooksWithoutAuthors(c, afterID, legacyAuthorBatchSize)
+		if err != nil {
+			return linked, err
+		}
+		if len(books) == 0 {
+			return linked, nil
+		}
+
+		for _, book := range books {
+			ok := false
+			err := s.repo.Transaction(c, func(txRepo BookRepository) error {
+				var err error
+				ok, err = linkLegacyAuthors(c, txRepo, s.authorRepo, book)
+				if err != nil || !ok {
+					return err
+				}
+				// the new credits show up in the book, so they get a version of their own
+				_, err = txRepo.PatchBook(c, book, map[string]interface{}{})
+				return err
+			})
+			if err != nil {
+				return linked, err
+			}
+			if ok {
+				linked++
+			}
+		}
+		afterID = books[len(books)-1].ID
+	}
+}
+
+func (s *bookService) SetBookTags(c *fiber.Ctx, bookID uuid.UUID, request BookTagsRequest) (Book, error) {
+	request.Tags = normalizeTags(request.Tags)
+	if err := utils.Validate(&request); err != nil {
+		return Book{}, fmt.Errorf("%w: %v", ErrInvalidTags, err)
+	}
+	book, err := s.repo.FindBookByID(c, bookID)
+	if err != nil {
+		return Book{}, err
+	}
+
+	err = s.repo.Transaction(c, func(txRepo BookRepository) error {
+		if err := txRepo.ReplaceBookTags(c, bookID, request.Tags); err != nil {
+			return err
+		}
+		// the tags are part of the book, so they get a version and revision of their own
+		_, err := txRepo.PatchBook(c, book, map[string]interface{}{})
+		return err
+	})
+	if err != nil {
+		return Book{}, err
+	}
+	return s.GetBook(c, bookID)
+}
+
+func (s *bookService) SetBookCategories(c *fiber.Ctx, bookID uuid.UUID, request BookCategoriesRequest) (Book, error) {
+	book, err := s.repo.FindBookByID(c, bookID)
 	if err != nil {
 		return Book{}, err
 	}
 
-	return newBook, nil
+	categoryIDs := make([]uuid.UUID, 0, len(request.CategoryIDs))
+	for _, categoryID := range request.CategoryIDs {
+		if !slices.Contains(categoryIDs, categoryID) {
+			categoryIDs = append(categoryIDs, categoryID)
+		}
+	}
+	err = s.repo.Transaction(c, func(txRepo BookRepository) error {
+		if err := txRepo.ReplaceBookCategories(c, bookID, categoryIDs); err != nil {
+			return err
+		}
+		_, err := txRepo.PatchBook(c, book, map[string]interface{}{})
+		return err
+	})
+	if err != nil {
+		return Book{}, err
+	}
+	return s.GetBook(c, bookID)
 }
 
-func (s *bookService) UpdateBook(c *fiber.Ctx, bookID uuid.UUID, updateBook BookRequest) (Book, error) {
+func (s *bookService) GetBookFacets(c *fiber.Ctx, filter BookFilter) (BookFacets, error) {
+	return s.repo.FindBookFacets(c, filter)
+}
+
+func (s *bookService) UploadCover(c *fiber.Ctx, bookID uuid.UUID, file *multipart.FileHeader) (Book, error) {
 	book, err := s.repo.FindBookByID(c, bookID)
 	if err != nil {
 		return Book{}, err
 	}
-	book.Title = updateBook.Title
-	book.Author = updateBook.Author
+
+	//validate file size
+	if file.Size > maxCoverSize {
+		return Book{}, fmt.Errorf("%w: file size exceeds limit: %d", ErrInvalidCover, file.Size)
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return Book{}, err
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return Book{}, err
+	}
+
+	return s.storeCover(c, book, data)
+}
+
+// storeCover generates the cover thumbnails from an uploaded image and replaces the current cover of the book
+func (s *bookService) storeCover(c *fiber.Ctx, book Book, data []byte) (Book, error) {
+	// validate file content, the extension is ignored on purpose
+	img, err := imaging.Decode(data)
+	if err != nil {
+		return Book{}, fmt.Errorf("%w: %v", ErrInvalidCover, err)
+	}
+
+	thumbnails, err := imaging.GenerateThumbnails(img, coverSizes, coverFormats)
+	if err != nil {
+		return Book{}, err
+	}
+
+	prefix := newCoverPrefix(book.ID)
+	for _, thumbnail := range thumbnails {
+		key := coverKey(prefix, thumbnail.Size, thumbnail.Format)
+		if err := s.s3Repo.UploadPublicObject(thumbnail.Data, key, thumbnail.ContentType); err != nil {
+			s.deleteCover(prefix)
+			return Book{}, err
+		}
+	}
+
+	previousPrefix := book.CoverPrefix
+	book.CoverPrefix = prefix
 	book.UpdatedAt = time.Now()
 
 	updatedBook, err := s.repo.UpdateBook(c, book)
 	if err != nil {
+		s.deleteCover(prefix)
 		return Book{}, err
 	}
 
-	return updatedBook, nil
+	if previousPrefix != "" {
+		s.deleteCover(previousPrefix)
+	}
+
+	return s.withCovers(updatedBook), nil
 }
 
-func (s *bookService) DeleteBook(c *fiber.Ctx, bookID uuid.UUID) error {
-	return s.repo.DeleteBook(c, bookID)
+// LookupISBN prefills a book from the external catalogue and optionally creates it with the catalogue cover
+func (s *bookService) LookupISBN(c *fiber.Ctx, isbn string, create bool) (BookLookup, error) {
+	isbn = utils.NormalizeISBN(isbn)
+	if !utils.IsValidISBN(isbn) {
+		return BookLookup{}, fmt.Errorf("%w: invalid isbn: %s", ErrInvalidBook, isbn)
+	}
+
+	ctx := lookupContext(c)
+	metadata, err := s.catalogue.LookupISBN(ctx, isbn)
+	if err != nil {
+		return BookLookup{}, err
+	}
+
+	lookup := BookLookup{Metadata: metadata}
+	if !create {
+		return lookup, nil
+	}
+
+	existing, err := s.repo.FindBooksByISBNs(c, []string{isbn})
+	if err != nil {
+		return BookLookup{}, err
+	}
+	if len(existing) > 0 {
+		return BookLookup{}, fmt.Errorf("%w: %s", ErrISBNExists, existing[0].ID)
+	}
+
+	newBook, err := s.CreateBook(c, newBookFromMetadata(metadata))
+	if err != nil {
+		return BookLookup{}, err
+	}
+
+	// the book is kept without a cover when the cover can't be fetched, it can still be uploaded by hand
+	if metadata.CoverURL != "" {
+		data, err := fetchCover(ctx, metadata.CoverURL)
+		if err == nil {
+			var withCover Book
+			if withCover, err = s.storeCover(c, newBook, data); err == nil {
+				newBook = withCover
+			}
+		}
+		if err != nil {
+			log.Errorf("Error storing catalogue cover: %v", err)
+		}
+	}
+
+	lookup.Book = &newBook
+	return lookup, nil
+}
+
+// BatchBooks runs each operation as its single route would, an atomic batch stops and rolls back at the first failure.
+// Authors created for new books are kept on rollback, they are looked up by name again on the next try.
+func (s *bookService) BatchBooks(c *fiber.Ctx, request BatchRequest, role string) (BatchResponse, error) {
+	if err := utils.Validate(&request); err != nil {
+		return BatchResponse{}, fmt.Errorf("%w: %v", ErrInvalidBatch, err)
+	}
+	if limit := s.config.BookBatch.MaxOperations; len(request.Operations) > limit {
+		return BatchResponse{}, fmt.Errorf("%w: %d operations exceed the limit of %d", ErrInvalidBatch, len(request.Operations), limit)
+	}
+
+	results := make([]BatchResult, len(request.Operations))
+	if !request.Atomic {
+		for i, operation := range request.Operations {
+			results[i] = s.runBatchOperation(c, i, operation, role)
+		}
+		return newBatchResponse(false, results), nil
+	}
+
+	var cleanups []func()
+	err := s.repo.Transaction(c, func(txRepo BookRepository) error {
+		txService := &bookService{config: s.config, repo: txRepo, authorRepo: s.authorRepo, s3Repo: s.s3Repo, catalogue: s.catalogue,
+			cleanups: &cleanups}
+		for i, operation := range request.Operations {
+			results[i] = txService.runBatchOperation(c, i, operation, role)
+			if results[i].Error != "" {
+				return errBatchFailed
+			}
+		}
+		return nil
+	})
+	switch {
+	case errors.Is(err, errBatchFailed):
+		rollBackResults(results, request.Operations)
+	case err != nil:
+		return BatchResponse{}, err
+	default:
+		for _, cleanup := range cleanups {
+			cleanup()
+		}
+	}
+
+	return newBatchResponse(true, results), nil
+}
+
+func (s *bookService) runBatchOperation(c *fiber.Ctx, index int, operation BatchOperation, role string) BatchResult {
+	result := BatchResult{Index: index, Op: operation.Op, ID: operation.ID}
+	if !batchAllowed(role, operation.Op) {
+		result.Status = fiber.StatusForbidden
+		result.Error = "Access denied: insufficient permissions"
+		return result
+	}
+
+	var book Book
+	var err error
+	switch operation.Op {
+	case BatchOpCreate:
+		var bookParams BookRequest
+		if err := json.Unmarshal(operation.Body, &bookParams); err != nil {
+			result.Status = fiber.StatusBadRequest
+			result.Error = "Invalid input"
+			return result
+		}
+		if book, err = s.CreateBook(c, bookParams.Book()); err != nil {
+			// same as the create route, which reports every failure as bad input
+			result.Status = fiber.StatusBadRequest
+			result.Error = err.Error()
+			return result
+		}
+		result.ID = &book.ID
+		result.Status = fiber.StatusCreated
+	case BatchOpPatch:
+		book, err = s.UpdateBook(c, *operation.ID, operation.ContentType, operation.Body, operation.IfMatch)
+		result.Status = fiber.StatusOK
+	case BatchOpDelete:
+		err = s.DeleteBook(c, *operation.ID, operation.IfMatch)
+		result.Status = fiber.StatusNoContent
+	}
+	if err != nil {
+		result.Status = batchErrorStatus(err)
+		result.Error = err.Error()
+		return result
+	}
+
+	if operation.Op != BatchOpDelete {
+		result.Book = &book
+	}
+	return result
+}
+
+func (s *bookService) GetDuplicates(c *fiber.Ctx, filter DuplicateFilter) ([]DuplicateCandidate, int64, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 || filter.Limit > 100 {
+		filter.Limit = 10
+	}
+	if filter.MinScore <= 0 || filter.MinScore > 1 {
+		filter.MinScore = defaultDuplicateScore
+	}
+
+	candidates, total, err := s.repo.FindDuplicateCandidates(c, filter.MinScore, filter.Page, filter.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range candidates {
+		candidates[i].Book = s.withCovers(candidates[i].Book)
+		candidates[i].Duplicate = s.withCovers(candidates[i].Duplicate)
+	}
+	return candidates, total, nil
+}
+
+// MergeBook folds the duplicate into the canonical book, the duplicate's cover is removed with it. A non-empty
+// ifMatch must match the canonical book, whose version the merge raises.
+func (s *bookService) MergeBook(c *fiber.Ctx, canonicalID uuid.UUID, request MergeRequest, ifMatch string) (Book, error) {
+	if err := utils.Validate(&request); err != nil {
+		return Book{}, fmt.Errorf("%w: %v", ErrInvalidMerge, err)
+	}
+	if request.DuplicateID == canonicalID {
+		return Book{}, fmt.Errorf("%w: a book can't be merged into itself", ErrInvalidMerge)
+	}
+
+	canonical, err := s.repo.FindBookByID(c, canonicalID)
+	if err != nil {
+		return Book{}, err
+	}
+	version := 0
+	if ifMatch != "" {
+		if !canonical.MatchesIfMatch(ifMatch) {
+			return Book{}, ErrPreconditionFailed
+		}
+		version = canonical.Version
+	}
+	duplicate, err := s.repo.FindBookByID(c, request.DuplicateID)
+	if err != nil {
+		return Book{}, err
+	}
+	// the canonical book keeps its own e-books, the duplicate's files of the same formats are dropped
+	droppedFiles, err := s.overlappingFiles(c, canonicalID, request.DuplicateID)
+	if err != nil {
+		return Book{}, err
+	}
+	if err := s.repo.MergeBooks(c, canonicalID, request.DuplicateID, version); err != nil {
+		if ifMatch != "" && errors.Is(err, ErrVersionConflict) {
+			return Book{}, ErrPreconditionFailed
+		}
+		return Book{}, err
+	}
+
+	s.afterCommit(func() {
+		if duplicate.CoverPrefix != "" {
+			s.deleteCover(duplicate.CoverPrefix)
+		}
+		for _, file := range droppedFiles {
+			s.deleteBookFileObject(file.ObjectKey)
+		}
+	})
+
+	return s.GetBook(c, canonicalID)
+}
+
+// overlappingFiles lists the duplicate's files whose format the canonical book already has
+func (s *bookService) overlappingFiles(c *fiber.Ctx, canonicalID, duplicateID uuid.UUID) ([]BookFile, error) {
+	canonicalFiles, err := s.repo.FindBookFiles(c, canonicalID)
+	if err != nil {
+		return nil, err
+	}
+	duplicateFiles, err := s.repo.FindBookFiles(c, duplicateID)
+	if err != nil {
+		return nil, err
+	}
+
+	var overlapping []BookFile
+	for _, file := range duplicateFiles {
+		if slices.ContainsFunc(canonicalFiles, func(f BookFile) bool { return f.Format == file.Format }) {
+			overlapping = append(overlapping, file)
+		}
+	}
+	return overlapping, nil
+}
+
+// ResolveBookRedirect returns the book a merged book ID now points to
+func (s *bookService) ResolveBookRedirect(c *fiber.Ctx, bookID uuid.UUID) (uuid.UUID, error) {
+	redirect, err := s.repo.FindBookRedirect(c, bookID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return redirect.ToID, nil
+}
+
+// IncludeRelated embeds the other editions of the book's work and its neighbouring series volumes
+func (s *bookService) IncludeRelated(c *fiber.Ctx, book Book, includes BookIncludes) (Book, error) {
+	if includes.Editions && book.WorkID != nil {
+		editions, err := s.repo.FindBooksByWork(c, *book.WorkID)
+		if err != nil {
+			return Book{}, err
+		}
+		book.Editions = make([]Book, 0, len(editions))
+		for _, edition := range editions {
+			if edition.ID != book.ID {
+				book.Editions = append(book.Editions, s.withCovers(edition))
+			}
+		}
+	}
+
+	if includes.Series {
+		neighbours, err := s.repo.FindSeriesNeighbours(c, book)
+		if err != nil {
+			return Book{}, err
+		}
+		if neighbours.Previous != nil {
+			previous := s.withCovers(*neighbours.Previous)
+			neighbours.Previous = &previous
+		}
+		if neighbours.Next != nil {
+			next := s.withCovers(*neighbours.Next)
+			neighbours.Next = &next
+		}
+		book.Neighbours = &neighbours
+	}
+
+	return book, nil
+}
+
+func (s *bookService) SetBookWork(c *fiber.Ctx, bookID uuid.UUID, request BookWorkRequest, ifMatch string) (Book, error) {
+	version, err := s.ifMatchVersion(c, bookID, ifMatch)
+	if err != nil {
+		return Book{}, err
+	}
+	if err := s.repo.UpdateBookWork(c, bookID, request.WorkID, version); err != nil {
+		if ifMatch != "" && errors.Is(err, ErrVersionConflict) {
+			return Book{}, ErrPreconditionFailed
+		}
+		return Book{}, err
+	}
+	return s.GetBook(c, bookID)
+}
+
+func (s *bookService) SetBookSeries(c *fiber.Ctx, bookID uuid.UUID, request BookSeriesRequest, ifMatch string) (Book, error) {
+	if err := utils.Validate(&request); err != nil {
+		return Book{}, fmt.Errorf("%w: %v", ErrInvalidSeries, err)
+	}
+	if request.SeriesID == nil && request.Volume != nil {
+		return Book{}, fmt.Errorf("%w: a volume needs a series", ErrInvalidSeries)
+	}
+
+	version, err := s.ifMatchVersion(c, bookID, ifMatch)
+	if err != nil {
+		return Book{}, err
+	}
+	if err := s.repo.UpdateBookSeries(c, bookID, request.SeriesID, request.Volume, version); err != nil {
+		if ifMatch != "" && errors.Is(err, ErrVersionConflict) {
+			return Book{}, ErrPreconditionFailed
+		}
+		return Book{}, err
+	}
+	return s.GetBook(c, bookID)
+}
+
+// ifMatchVersion returns the version a write must still find, 0 when there is no If-Match to hold it to
+func (s *bookService) ifMatchVersion(c *fiber.Ctx, bookID uuid.UUID, ifMatch string) (int, error) {
+	if ifMatch == "" {
+		return 0, nil
+	}
+	book, err := s.repo.FindBookByID(c, bookID)
+	if err != nil {
+		return 0, err
+	}
+	if !book.MatchesIfMatch(ifMatch) {
+		return 0, ErrPreconditionFailed
+	}
+	return book.Version, nil
+}
+
+func (s *bookService) GetWorkBooks(c *fiber.Ctx, workID uuid.UUID) ([]Book, error) {
+	books, err := s.repo.FindBooksByWork(c, workID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range books {
+		books[i] = s.withCovers(books[i])
+	}
+	return books, nil
+}
+
+func (s *bookService) GetSeriesBooks(c *fiber.Ctx, seriesID uuid.UUID) ([]Book, error) {
+	books, err := s.repo.FindBooksBySeries(c, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range books {
+		books[i] = s.withCovers(books[i])
+	}
+	return books, nil
+}
+
+func (s *bookService) ImportBooks(c *fiber.Ctx, r io.Reader, format string, dryRun bool) (*ImportReport, error) {
+	return importBooks(c, s.repo, s.authorRepo, r, format, dryRun)
+}
+
+// ExportBooks writes the books matching filter to w. It runs after the handler returned, while the response is
+// sent, or in a background job, so it takes a context rather than the fiber context.
+func (s *bookService) ExportBooks(ctx context.Context, w io.Writer, filter BookFilter, format string) error {
+	writer, err := export.NewRowWriter(w, format)
+	if err != nil {
+		return err
+	}
+
+	if err := writer.WriteHeader(bookExportColumns); err != nil {
+		return err
+	}
+
+	err = s.repo.StreamBooks(ctx, filter, exportBatchSize, func(books []Book) error {
+		for _, book := range books {
+			if err := writer.WriteRow(bookExportRow(book)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+// StartExportJob runs the export in the background, uploads it to the private bucket and stores a presigned link on the job
+func (s *bookService) StartExportJob(c *fiber.Ctx, filter BookFilter, format string) (ExportJob, error) {
+	if err := export.ValidateFormat(format); err != nil {
+		return ExportJob{}, err
+	}
+
+	job := ExportJob{ID: uuid.New(), Format: format, Status: ExportJobPending, CreatedAt: time.Now().UTC()}
+	if err := s.repo.SaveExportJob(c.UserContext(), job, exportJobTTL); err != nil {
+		return ExportJob{}, err
+	}
+
+	// the job outlives the request, so it doesn't run under the request's context
+	go func(ctx context.Context, job ExportJob) {
+		url, err := s.runExportJob(ctx, job, filter)
+		completedAt := time.Now().UTC()
+		job.CompletedAt = &completedAt
+		if err != nil {
+			log.Errorf("book export job %s failed: %v", job.ID, err)
+			job.Status = ExportJobFailed
+			job.Error = err.Error()
+		} else {
+			job.Status = ExportJobCompleted
+			job.URL = url
+		}
+
+		if err := s.repo.SaveExportJob(ctx, job, exportJobTTL); err != nil {
+			log.Errorf("failed to save book export job %s: %v", job.ID, err)
+		}
+	}(context.Background(), job)
+
+	return job, nil
+}
+
+// runExportJob uploads the export and returns its link. An object that was uploaded for a job that failed after
+// all is deleted again, so a failed job leaves nothing behind.
+func (s *bookService) runExportJob(ctx context.Context, job ExportJob, filter BookFilter) (string, error) {
+	// the export is spooled to disk because the S3 client needs a seekable body
+	file, err := os.CreateTemp("", "book-export-*."+job.Format)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	if err := s.ExportBooks(ctx, file, filter, job.Format); err != nil {
+		return "", err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	objKey := exportObjectKey(job.ID, job.Format)
+	url, err := s.uploadExport(file, objKey, job.Format)
+	if err != nil {
+		if err := s.s3Repo.DeletePrivateFile(objKey); err != nil {
+			log.Errorf("failed to delete book export %s: %v", objKey, err)
+		}
+		return "", err
+	}
+	return url, nil
+}
+
+func (s *bookService) uploadExport(file io.Reader, objKey, format string) (string, error) {
+	if err := s.s3Repo.UploadPrivateFile(file, objKey, export.ContentType(format)); err != nil {
+		return "", err
+	}
+	return s.s3Repo.GetURLFile(objKey)
+}
+
+func (s *bookService) GetExportJob(c *fiber.Ctx, jobID uuid.UUID) (ExportJob, error) {
+	return s.repo.FindExportJob(c, jobID)
+}
+
+// withCovers resolves the stored cover prefix into public URLs for the response
+func (s *bookService) withCovers(book Book) Book {
+	return withCoverURLs(s.s3Repo, book)
+}
+
+// withCoverURLs lists the public URL of every size and format of the book's cover
+func withCoverURLs(s3Repo storage.S3Repository, book Book) Book {
+	if book.CoverPrefix == "" {
+		return book
+	}
+
+	book.Covers = make([]CoverImage, 0, len(coverSizes)*len(coverFormats))
+	for _, size := range coverSizes {
+		for _, format := range coverFormats {
+			book.Covers = append(book.Covers, CoverImage{
+				Size:   size,
+				Format: format,
+				URL:    s3Repo.GetPublicURLFile(coverKey(book.CoverPrefix, size, format)),
+			})
+		}
+	}
+	return book
+}
+
+func (s *bookService) GetBookFiles(c *fiber.Ctx, bookID uuid.UUID) ([]BookFile, error) {
+	if _, err := s.repo.FindBookByID(c, bookID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindBookFiles(c, bookID)
+}
+
+// UploadBookFile stores an EPUB or PDF in the private bucket, replacing the book's file of the same format.
+// A checksum sent by the client must match the SHA-256 of what arrived.
+func (s *bookService) UploadBookFile(c *fiber.Ctx, bookID uuid.UUID, file *multipart.FileHeader, checksum string) (BookFile, error) {
+	if _, err := s.repo.FindBookByID(c, bookID); err != nil {
+		return BookFile{}, err
+	}
+
+	if file.Size == 0 {
+		return BookFile{}, fmt.Errorf("%w: file is empty", ErrInvalidBookFile)
+	}
+	if file.Size > s.config.BookFiles.MaxSize {
+		return BookFile{}, fmt.Errorf("%w: file size exceeds limit: %d", ErrInvalidBookFile, file.Size)
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return BookFile{}, err
+	}
+	defer src.Close()
+
+	head := make([]byte, 64)
+	n, err := io.ReadFull(src, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return BookFile{}, err
+	}
+	format, ok := detectBookFormat(head[:n])
+	if !ok {
+		return BookFile{}, fmt.Errorf("%w: only EPUB and PDF files are accepted", ErrInvalidBookFile)
+	}
+	if err := checkDeclaredContentType(file.Header.Get(fiber.HeaderContentType), format); err != nil {
+		return BookFile{}, err
+	}
+
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return BookFile{}, err
+	}
+	sum, err := fileChecksum(src)
+	if err != nil {
+		return BookFile{}, err
+	}
+	if checksum != "" && !strings.EqualFold(checksum, sum) {
+		return BookFile{}, fmt.Errorf("%w: checksum mismatch, received sha256 %s", ErrInvalidBookFile, sum)
+	}
+
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return BookFile{}, err
+	}
+	contentType := bookFormatContentTypes[format]
+	key := newBookFileKey(bookID, format)
+	if err := s.s3Repo.UploadPrivateFile(src, key, contentType); err != nil {
+		return BookFile{}, err
+	}
+
+	bookFile, err := s.repo.FindBookFile(c, bookID, format)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		s.deleteBookFileObject(key)
+		return BookFile{}, err
+	}
+	previousKey := bookFile.ObjectKey
+
+	bookFile.BookID = bookID
+	bookFile.Format = format
+	bookFile.ObjectKey = key
+	bookFile.ContentType = contentType
+	bookFile.Size = file.Size
+	bookFile.Checksum = sum
+	bookFile.UploadedBy = actorID(c)
+	savedFile, err := s.repo.SaveBookFile(c, bookFile)
+	if err != nil {
+		s.deleteBookFileObject(key)
+		return BookFile{}, err
+	}
+
+	if previousKey != "" {
+		s.deleteBookFileObject(previousKey)
+	}
+	return savedFile, nil
+}
+
+func (s *bookService) DeleteBookFile(c *fiber.Ctx, bookID uuid.UUID, format string) error {
+	file, err := s.repo.FindBookFile(c, bookID, format)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteBookFile(c, file); err != nil {
+		return err
+	}
+	s.deleteBookFileObject(file.ObjectKey)
+	return nil
+}
+
+// GetDownloadLink hands out a short-lived link to the e-book. Borrowers need an open loan of the book, staff
+// can always download. Every link handed out counts as a download.
+func (s *bookService) GetDownloadLink(c *fiber.Ctx, bookID, userID uuid.UUID, role, format string) (BookDownloadLink, error) {
+	book, err := s.repo.FindBookByID(c, bookID)
+	if err != nil {
+		return BookDownloadLink{}, err
+	}
+
+	file, err := s.findDownloadFile(c, bookID, format)
+	if err != nil {
+		return BookDownloadLink{}, err
+	}
+
+	if middleware.UserRole(role) != middleware.RoleAdmin && middleware.UserRole(role) != middleware.RoleModerator {
+		entitled, err := s.repo.HasOpenLoan(c, bookID, userID)
+		if err != nil {
+			return BookDownloadLink{}, err
+		}
+		if !entitled {
+			return BookDownloadLink{}, ErrNotEntitled
+		}
+	}
+
+	expiresAt := time.Now().Add(s.config.BookFiles.DownloadTTL)
+	url, err := s.s3Repo.GetDownloadURLFile(file.ObjectKey, downloadFileName(book.Title, file.Format), s.config.BookFiles.DownloadTTL)
+	if err != nil {
+		return BookDownloadLink{}, err
+	}
+
+	download := BookDownload{BookID: bookID, UserID: userID, FileID: &file.ID, Format: file.Format}
+	if err := s.repo.CreateBookDownload(c, download); err != nil {
+		return BookDownloadLink{}, err
+	}
+
+	return BookDownloadLink{Format: file.Format, URL: url, ExpiresAt: expiresAt, Size: file.Size, Checksum: file.Checksum}, nil
+}
+
+// findDownloadFile picks the requested format, or the first available one in bookFormats order
+func (s *bookService) findDownloadFile(c *fiber.Ctx, bookID uuid.UUID, format string) (BookFile, error) {
+	if format != "" {
+		if !slices.Contains(bookFormats, format) {
+			return BookFile{}, fmt.Errorf("%w: unknown format %s", ErrInvalidBookFile, format)
+		}
+		return s.repo.FindBookFile(c, bookID, format)
+	}
+
+	files, err := s.repo.FindBookFiles(c, bookID)
+	if err != nil {
+		return BookFile{}, err
+	}
+	for _, preferred := range bookFormats {
+		for _, file := range files {
+			if file.Format == preferred {
+				return file, nil
+			}
+		}
+	}
+	return BookFile{}, gorm.ErrRecordNotFound
+}
+
+func (s *bookService) GetDownloadStats(c *fiber.Ctx, bookID uuid.UUID, filter DownloadStatsFilter) (DownloadStatsResponse, error) {
+	if _, err := s.repo.FindBookByID(c, bookID); err != nil {
+		return DownloadStatsResponse{}, err
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 || filter.Limit > 100 {
+		filter.Limit = 10
+	}
+
+	stats, err := s.repo.FindDownloadStats(c, bookID, filter.Page, filter.Limit)
+	if err != nil {
+		return DownloadStatsResponse{}, err
+	}
+	stats.Page = filter.Page
+	stats.PerPage = filter.Limit
+	return stats, nil
+}
+
+// afterCommit runs fn now, or once the surrounding transaction committed so a rollback keeps the objects
+func (s *bookService) afterCommit(fn func()) {
+	if s.cleanups != nil {
+		*s.cleanups = append(*s.cleanups, fn)
+		return
+	}
+	fn()
+}
+
+func (s *bookService) deleteBookFileObject(key string) {
+	if err := s.s3Repo.DeletePrivateFile(key); err != nil {
+		log.Errorf("failed to delete book file object %s: %v", key, err)
+	}
+}
+
+func (s *bookService) GetBookTranslations(c *fiber.Ctx, bookID uuid.UUID) ([]BookTranslation, error) {
+	if _, err := s.repo.FindBookByID(c, bookID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindBookTranslations(c, bookID)
+}
+
+// SetBookTranslation adds the translation of a book in a locale or replaces the one it has
+func (s *bookService) SetBookTranslation(c *fiber.Ctx, bookID uuid.UUID, locale string, request BookTranslationRequest) (BookTranslation, error) {
+	locale, err := CanonicalLocale(locale)
+	if err != nil {
+		return BookTranslation{}, err
+	}
+	request.Title = strings.TrimSpace(request.Title)
+	request.Subtitle = strings.TrimSpace(request.Subtitle)
+	request.Description = strings.TrimSpace(request.Description)
+	if err := utils.Validate(&request); err != nil {
+		return BookTranslation{}, fmt.Errorf("%w: %v", ErrInvalidTranslation, err)
+	}
+
+	book, err := s.repo.FindBookByID(c, bookID)
+	if err != nil {
+		return BookTranslation{}, err
+	}
+	// the book's own fields are its text in its language, a translation there would never be read
+	if strings.EqualFold(locale, book.Language) {
+		return BookTranslation{}, fmt.Errorf("%w: the book is written in %s, update the book instead", ErrInvalidTranslation, book.Language)
+	}
+
+	return s.repo.SaveBookTranslation(c, BookTranslation{
+		BookID:      bookID,
+		Locale:      locale,
+		Title:       request.Title,
+		Subtitle:    request.Subtitle,
+		Description: request.Description,
+	})
+}
+
+func (s *bookService) DeleteBookTranslation(c *fiber.Ctx, bookID uuid.UUID, locale string) error {
+	locale, err := CanonicalLocale(locale)
+	if err != nil {
+		return err
+	}
+	return s.repo.DeleteBookTranslation(c, bookID, locale)
+}
+
+// deleteCover removes every rendition under the prefix, a failed delete only leaves an orphan object behind
+func (s *bookService) deleteCover(prefix string) {
+	for _, key := range coverKeys(prefix) {
+		if err := s.s3Repo.DeletePublicFile(key); err != nil {
+			log.Errorf("failed to delete cover object %s: %v", key, err)
+		}
+	}
 }
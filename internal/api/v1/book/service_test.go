@@ -0,0 +1,538 @@
+package book_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/author"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+	"github.com/jerpsp/go-fiber-beginner/mocks"
+	"github.com/jerpsp/go-fiber-beginner/pkg/patch"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type BookServiceSuite struct {
+	suite.Suite
+	mockRepo       *mocks.BookRepository
+	mockAuthorRepo *mocks.AuthorRepository
+	mockStorage    *mocks.S3Repository
+	service        book.BookService
+}
+
+func TestBookServiceSuite(t *testing.T) {
+	suite.Run(t, new(BookServiceSuite))
+}
+
+func (s *BookServiceSuite) SetupTest() {
+	s.mockRepo = mocks.NewBookRepository(s.T())
+	s.mockAuthorRepo = mocks.NewAuthorRepository(s.T())
+	s.mockStorage = mocks.NewS3Repository(s.T())
+	s.service = book.NewBookService(&config.Config{}, s.mockRepo, s.mockAuthorRepo, s.mockStorage, nil)
+
+	// writes that span several statements run in one transaction, the mock hands itself to the callback
+	s.mockRepo.EXPECT().Transaction(mock.Anything, mock.Anything).RunAndReturn(func(c *fiber.Ctx, fn func(txRepo book.BookRepository) error) error {
+		return fn(s.mockRepo)
+	}).Maybe()
+}
+
+func (s *BookServiceSuite) TestCreateBook1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Create Book Credits Authors", 34)
+
+	// Setup Mock
+	created := book.Book{ID: uuid.New(), Title: "Good Omens", Author: "Terry Pratchett & Neil Gaiman", Version: 1}
+	pratchett := author.Author{ID: uuid.New(), Name: "Terry Pratchett"}
+	gaiman := author.Author{ID: uuid.New(), Name: "Neil Gaiman"}
+	s.mockRepo.EXPECT().CreateBook(mock.Anything, book.Book{Title: created.Title, Author: created.Author}).Return(created, nil)
+	s.mockAuthorRepo.EXPECT().FindOrCreateAuthorByName(mock.Anything, "Terry Pratchett").Return(pratchett, nil)
+	s.mockAuthorRepo.EXPECT().FindOrCreateAuthorByName(mock.Anything, "Neil Gaiman").Return(gaiman, nil)
+	s.mockRepo.EXPECT().ReplaceBookAuthors(mock.Anything, created.ID, []book.BookAuthor{
+		{AuthorID: pratchett.ID, Position: 0, Role: book.BookAuthorRoleAuthor},
+		{AuthorID: gaiman.ID, Position: 1, Role: book.BookAuthorRoleAuthor},
+	}).Return(nil)
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, created.ID).Return(created, nil)
+
+	// Call the service method
+	result, err := s.service.CreateBook(&fiber.Ctx{}, book.Book{Title: created.Title, Author: created.Author})
+
+	// Assertions
+	s.NoError(err)
+	s.Equal(created.ID, result.ID)
+	s.mockRepo.AssertNumberOfCalls(s.T(), "Transaction", 1)
+}
+
+func (s *BookServiceSuite) TestCreateBook2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Create Book Fails When Crediting Fails", 31)
+
+	// Setup Mock
+	created := book.Book{ID: uuid.New(), Title: "Dune", Author: "Frank Herbert", Version: 1}
+	s.mockRepo.EXPECT().CreateBook(mock.Anything, book.Book{Title: created.Title, Author: created.Author}).Return(created, nil)
+	s.mockAuthorRepo.EXPECT().FindOrCreateAuthorByName(mock.Anything, "Frank Herbert").Return(author.Author{}, errors.New("connection reset"))
+
+	// Call the service method
+	_, err := s.service.CreateBook(&fiber.Ctx{}, book.Book{Title: created.Title, Author: created.Author})
+
+	// Assertions
+	s.Error(err)
+}
+
+func (s *BookServiceSuite) TestSetBookAuthors1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Set Book Authors Rewrites Byline", 34)
+
+	// Setup Mock
+	current := book.Book{ID: uuid.New(), Title: "Dune", Author: "F. Herbert", Version: 2}
+	herbert := author.Author{ID: uuid.New(), Name: "Frank Herbert"}
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).Return(current, nil).Twice()
+	s.mockAuthorRepo.EXPECT().FindAuthorsByIDs(mock.Anything, []uuid.UUID{herbert.ID}).Return([]author.Author{herbert}, nil)
+	s.mockRepo.EXPECT().ReplaceBookAuthors(mock.Anything, current.ID, mock.Anything).Return(nil)
+	s.mockRepo.EXPECT().PatchBook(mock.Anything, current, map[string]interface{}{"author": "Frank Herbert"}).Return(current, nil)
+
+	// Call the service method
+	_, err := s.service.SetBookAuthors(&fiber.Ctx{}, current.ID, book.BookAuthorsRequest{Authors: []book.BookAuthorRequest{{AuthorID: herbert.ID}}})
+
+	// Assertions
+	s.NoError(err)
+}
+
+func (s *BookServiceSuite) TestSetBookAuthors2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Set Book Authors Same Byline Still Bumps Version", 34)
+
+	// Setup Mock
+	current := book.Book{ID: uuid.New(), Title: "Dune", Author: "Frank Herbert", Version: 2}
+	herbert := author.Author{ID: uuid.New(), Name: "Frank Herbert"}
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).Return(current, nil).Twice()
+	s.mockAuthorRepo.EXPECT().FindAuthorsByIDs(mock.Anything, []uuid.UUID{herbert.ID}).Return([]author.Author{herbert}, nil)
+	s.mockRepo.EXPECT().ReplaceBookAuthors(mock.Anything, current.ID, mock.Anything).Return(nil)
+	s.mockRepo.EXPECT().PatchBook(mock.Anything, current, map[string]interface{}{}).Return(current, nil)
+
+	// Call the service method
+	_, err := s.service.SetBookAuthors(&fiber.Ctx{}, current.ID, book.BookAuthorsRequest{Authors: []book.BookAuthorRequest{{AuthorID: herbert.ID}}})
+
+	// Assertions
+	s.NoError(err)
+}
+
+func (s *BookServiceSuite) TestSetBookAuthors3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Set Book Authors Concurrent Edit", 31)
+
+	// Setup Mock
+	current := book.Book{ID: uuid.New(), Title: "Dune", Author: "Frank Herbert", Version: 2}
+	herbert := author.Author{ID: uuid.New(), Name: "Frank Herbert"}
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).Return(current, nil)
+	s.mockAuthorRepo.EXPECT().FindAuthorsByIDs(mock.Anything, []uuid.UUID{herbert.ID}).Return([]author.Author{herbert}, nil)
+	s.mockRepo.EXPECT().ReplaceBookAuthors(mock.Anything, current.ID, mock.Anything).Return(nil)
+	s.mockRepo.EXPECT().PatchBook(mock.Anything, current, mock.Anything).Return(book.Book{}, book.ErrVersionConflict)
+
+	// Call the service method
+	_, err := s.service.SetBookAuthors(&fiber.Ctx{}, current.ID, book.BookAuthorsRequest{Authors: []book.BookAuthorRequest{{AuthorID: herbert.ID}}})
+
+	// Assertions
+	s.ErrorIs(err, book.ErrVersionConflict)
+}
+
+func (s *BookServiceSuite) TestUpdateBook1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Update Book With Matching If-Match", 34)
+
+	// Setup Mock
+	current := book.Book{ID: uuid.New(), Title: "Dune", Author: "Frank Herbert", Version: 2}
+	updated := current
+	updated.Title = "Dune Messiah"
+	updated.Version = 3
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).Return(current, nil)
+	s.mockRepo.EXPECT().PatchBook(mock.Anything, current, map[string]interface{}{"title": "Dune Messiah"}).Return(updated, nil)
+
+	// Call the service method
+	result, err := s.service.UpdateBook(&fiber.Ctx{}, current.ID, patch.ContentTypeMergePatch, []byte(`{"title":"Dune Messiah"}`), `"2"`)
+
+	// Assertions
+	s.NoError(err)
+	s.Equal(3, result.Version)
+}
+
+func (s *BookServiceSuite) TestUpdateBook2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Update Book Stale If-Match", 31)
+
+	// Setup Mock
+	current := book.Book{ID: uuid.New(), Title: "Dune", Author: "Frank Herbert", Version: 2}
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).Return(current, nil)
+
+	// Call the service method
+	_, err := s.service.UpdateBook(&fiber.Ctx{}, current.ID, patch.ContentTypeMergePatch, []byte(`{"title":"Dune Messiah"}`), `"1"`)
+
+	// Assertions
+	s.ErrorIs(err, book.ErrPreconditionFailed)
+}
+
+func (s *BookServiceSuite) TestUpdateBook3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Update Book Weak If-Match", 31)
+
+	// Setup Mock
+	current := book.Book{ID: uuid.New(), Title: "Dune", Author: "Frank Herbert", Version: 2}
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).Return(current, nil)
+
+	// Call the service method
+	_, err := s.service.UpdateBook(&fiber.Ctx{}, current.ID, patch.ContentTypeMergePatch, []byte(`{"title":"Dune Messiah"}`), `W/"2"`)
+
+	// Assertions
+	s.ErrorIs(err, book.ErrPreconditionFailed)
+}
+
+func (s *BookServiceSuite) TestUpdateBook4() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Update Book Lost Race Without If-Match", 31)
+
+	// Setup Mock
+	current := book.Book{ID: uuid.New(), Title: "Dune", Author: "Frank Herbert", Version: 2}
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).Return(current, nil)
+	s.mockRepo.EXPECT().PatchBook(mock.Anything, current, mock.Anything).Return(book.Book{}, book.ErrVersionConflict)
+
+	// Call the service method
+	_, err := s.service.UpdateBook(&fiber.Ctx{}, current.ID, patch.ContentTypeMergePatch, []byte(`{"title":"Dune Messiah"}`), "")
+
+	// Assertions
+	s.ErrorIs(err, book.ErrVersionConflict)
+}
+
+func (s *BookServiceSuite) TestUpdateBook5() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Update Book Lost Race With If-Match", 31)
+
+	// Setup Mock
+	current := book.Book{ID: uuid.New(), Title: "Dune", Author: "Frank Herbert", Version: 2}
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).Return(current, nil)
+	s.mockRepo.EXPECT().PatchBook(mock.Anything, current, mock.Anything).Return(book.Book{}, book.ErrVersionConflict)
+
+	// Call the service method
+	_, err := s.service.UpdateBook(&fiber.Ctx{}, current.ID, patch.ContentTypeMergePatch, []byte(`{"title":"Dune Messiah"}`), `"2"`)
+
+	// Assertions
+	s.ErrorIs(err, book.ErrPreconditionFailed)
+}
+
+func (s *BookServiceSuite) TestDeleteBook1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Delete Book Stale If-Match", 31)
+
+	// Setup Mock
+	current := book.Book{ID: uuid.New(), Title: "Dune", Version: 4}
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).Return(current, nil)
+
+	// Call the service method
+	err := s.service.DeleteBook(&fiber.Ctx{}, current.ID, `"3"`)
+
+	// Assertions
+	s.ErrorIs(err, book.ErrPreconditionFailed)
+}
+
+func (s *BookServiceSuite) TestDeleteBook2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Delete Book Lost Race With If-Match", 31)
+
+	// Setup Mock
+	current := book.Book{ID: uuid.New(), Title: "Dune", Version: 4}
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).Return(current, nil)
+	s.mockRepo.EXPECT().FindBookFiles(mock.Anything, current.ID).Return(nil, nil)
+	s.mockRepo.EXPECT().DeleteBook(mock.Anything, current.ID, 4).Return(book.ErrVersionConflict)
+
+	// Call the service method
+	err := s.service.DeleteBook(&fiber.Ctx{}, current.ID, `"4"`)
+
+	// Assertions
+	s.ErrorIs(err, book.ErrPreconditionFailed)
+}
+
+func (s *BookServiceSuite) TestDeleteBook3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Delete Book Removes Cover And E-Book Objects", 34)
+
+	// Setup Mock
+	current := book.Book{ID: uuid.New(), Title: "Dune", Version: 4, CoverPrefix: "covers/dune"}
+	files := []book.BookFile{
+		{BookID: current.ID, Format: book.BookFormatEPUB, ObjectKey: "books/dune.epub"},
+		{BookID: current.ID, Format: book.BookFormatPDF, ObjectKey: "books/dune.pdf"},
+	}
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).Return(current, nil)
+	s.mockRepo.EXPECT().FindBookFiles(mock.Anything, current.ID).Return(files, nil)
+	s.mockRepo.EXPECT().DeleteBook(mock.Anything, current.ID, 0).Return(nil)
+	s.mockStorage.EXPECT().DeletePublicFile(mock.MatchedBy(func(key string) bool {
+		return strings.HasPrefix(key, current.CoverPrefix)
+	})).Return(nil)
+	s.mockStorage.EXPECT().DeletePrivateFile("books/dune.epub").Return(nil)
+	s.mockStorage.EXPECT().DeletePrivateFile("books/dune.pdf").Return(nil)
+
+	// Call the service method
+	err := s.service.DeleteBook(&fiber.Ctx{}, current.ID, "")
+
+	// Assertions
+	s.NoError(err)
+}
+
+func (s *BookServiceSuite) TestDeleteBook4() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Delete Book With Copies Keeps Its Objects", 31)
+
+	// Setup Mock
+	current := book.Book{ID: uuid.New(), Title: "Dune", Version: 4, CoverPrefix: "covers/dune"}
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).Return(current, nil)
+	s.mockRepo.EXPECT().FindBookFiles(mock.Anything, current.ID).Return([]book.BookFile{{ObjectKey: "books/dune.epub"}}, nil)
+	s.mockRepo.EXPECT().DeleteBook(mock.Anything, current.ID, 0).Return(book.ErrBookHasCopies)
+
+	// Call the service method
+	err := s.service.DeleteBook(&fiber.Ctx{}, current.ID, "")
+
+	// Assertions
+	s.ErrorIs(err, book.ErrBookHasCopies)
+}
+
+func (s *BookServiceSuite) TestMergeBook1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Merge Book Removes The Duplicate's Cover And Overlapping Files", 34)
+
+	// Setup Mock
+	// the canonical book keeps its EPUB, the duplicate's EPUB is dropped and its PDF moves over
+	canonical := book.Book{ID: uuid.New(), Title: "Dune", Version: 4}
+	duplicate := book.Book{ID: uuid.New(), Title: "Dune", Version: 1, CoverPrefix: "covers/dune-copy"}
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, canonical.ID).Return(canonical, nil)
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, duplicate.ID).Return(duplicate, nil)
+	s.mockRepo.EXPECT().FindBookFiles(mock.Anything, canonical.ID).Return([]book.BookFile{
+		{BookID: canonical.ID, Format: book.BookFormatEPUB, ObjectKey: "books/dune.epub"},
+	}, nil)
+	s.mockRepo.EXPECT().FindBookFiles(mock.Anything, duplicate.ID).Return([]book.BookFile{
+		{BookID: duplicate.ID, Format: book.BookFormatEPUB, ObjectKey: "books/dune-copy.epub"},
+		{BookID: duplicate.ID, Format: book.BookFormatPDF, ObjectKey: "books/dune-copy.pdf"},
+	}, nil)
+	s.mockRepo.EXPECT().MergeBooks(mock.Anything, canonical.ID, duplicate.ID, 4).Return(nil)
+	s.mockStorage.EXPECT().DeletePublicFile(mock.MatchedBy(func(key string) bool {
+		return strings.HasPrefix(key, duplicate.CoverPrefix)
+	})).Return(nil)
+	s.mockStorage.EXPECT().DeletePrivateFile("books/dune-copy.epub").Return(nil)
+
+	// Call the service method
+	merged, err := s.service.MergeBook(&fiber.Ctx{}, canonical.ID, book.MergeRequest{DuplicateID: duplicate.ID}, `"4"`)
+
+	// Assertions
+	s.NoError(err)
+	s.Equal(canonical.ID, merged.ID)
+}
+
+func (s *BookServiceSuite) TestMergeBook2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Merge Book Stale If-Match", 31)
+
+	// Setup Mock
+	canonical := book.Book{ID: uuid.New(), Title: "Dune", Version: 4}
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, canonical.ID).Return(canonical, nil)
+
+	// Call the service method
+	_, err := s.service.MergeBook(&fiber.Ctx{}, canonical.ID, book.MergeRequest{DuplicateID: uuid.New()}, `"3"`)
+
+	// Assertions
+	s.ErrorIs(err, book.ErrPreconditionFailed)
+}
+
+func (s *BookServiceSuite) TestMergeBook3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Merge Book Lost Race With If-Match Keeps The Duplicate's Objects", 31)
+
+	// Setup Mock
+	canonical := book.Book{ID: uuid.New(), Title: "Dune", Version: 4}
+	duplicate := book.Book{ID: uuid.New(), Title: "Dune", Version: 1, CoverPrefix: "covers/dune-copy"}
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, canonical.ID).Return(canonical, nil)
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, duplicate.ID).Return(duplicate, nil)
+	s.mockRepo.EXPECT().FindBookFiles(mock.Anything, mock.Anything).Return(nil, nil)
+	s.mockRepo.EXPECT().MergeBooks(mock.Anything, canonical.ID, duplicate.ID, 4).Return(book.ErrVersionConflict)
+
+	// Call the service method
+	_, err := s.service.MergeBook(&fiber.Ctx{}, canonical.ID, book.MergeRequest{DuplicateID: duplicate.ID}, `"4"`)
+
+	// Assertions
+	s.ErrorIs(err, book.ErrPreconditionFailed)
+}
+
+func (s *BookServiceSuite) TestGetBookHistory1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Get Book History Default Page", 34)
+
+	// Setup Mock
+	bookID := uuid.New()
+	revisions := []book.BookRevision{{BookID: bookID, Version: 1, Action: book.BookRevisionCreate}}
+	s.mockRepo.EXPECT().FindBookRevisions(mock.Anything, bookID, 1, 10).Return(revisions, int64(1), nil)
+
+	// Call the service method
+	result, total, err := s.service.GetBookHistory(&fiber.Ctx{}, bookID, book.BookHistoryFilter{Limit: 500})
+
+	// Assertions
+	s.NoError(err)
+	s.Equal(int64(1), total)
+	s.Equal(revisions, result)
+}
+
+func (s *BookServiceSuite) TestGetBookHistory2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Get Book History Unknown Book", 31)
+
+	// Setup Mock
+	bookID := uuid.New()
+	s.mockRepo.EXPECT().FindBookRevisions(mock.Anything, bookID, 1, 10).Return([]book.BookRevision{}, int64(0), nil)
+
+	// Call the service method
+	_, _, err := s.service.GetBookHistory(&fiber.Ctx{}, bookID, book.BookHistoryFilter{})
+
+	// Assertions
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func (s *BookServiceSuite) TestRevertBook1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Revert Book", 34)
+
+	// Setup Mock
+	current := book.Book{ID: uuid.New(), Title: "Dune Messiah", Version: 3}
+	revision := book.BookRevision{BookID: current.ID, Version: 1, Snapshot: book.BookSnapshot{Title: "Dune"}}
+	reverted := book.Book{ID: current.ID, Title: "Dune", Version: 4}
+	s.mockRepo.EXPECT().FindBookRevision(mock.Anything, current.ID, 1).Return(revision, nil)
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).Return(current, nil)
+	s.mockRepo.EXPECT().RevertBook(mock.Anything, current, revision).Return(reverted, nil)
+
+	// Call the service method
+	result, err := s.service.RevertBook(&fiber.Ctx{}, current.ID, 1, `"3"`)
+
+	// Assertions
+	s.NoError(err)
+	s.Equal(reverted, result)
+}
+
+func (s *BookServiceSuite) TestRevertBook2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Revert Deleted Book", 31)
+
+	// Setup Mock
+	bookID := uuid.New()
+	revision := book.BookRevision{BookID: bookID, Version: 1, Snapshot: book.BookSnapshot{Title: "Dune"}}
+	s.mockRepo.EXPECT().FindBookRevision(mock.Anything, bookID, 1).Return(revision, nil)
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, bookID).Return(book.Book{}, gorm.ErrRecordNotFound)
+
+	// Call the service method
+	_, err := s.service.RevertBook(&fiber.Ctx{}, bookID, 1, "")
+
+	// Assertions
+	s.ErrorIs(err, book.ErrBookDeleted)
+}
+
+func (s *BookServiceSuite) TestSetBookTags1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Set Book Tags Bumps Version", 34)
+
+	// Setup Mock
+	current := book.Book{ID: uuid.New(), Title: "Dune", Version: 2}
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).Return(current, nil).Twice()
+	s.mockRepo.EXPECT().ReplaceBookTags(mock.Anything, current.ID, []string{"classic"}).Return(nil)
+	s.mockRepo.EXPECT().PatchBook(mock.Anything, current, map[string]interface{}{}).Return(current, nil)
+
+	// Call the service method
+	_, err := s.service.SetBookTags(&fiber.Ctx{}, current.ID, book.BookTagsRequest{Tags: []string{"classic"}})
+
+	// Assertions
+	s.NoError(err)
+	s.mockRepo.AssertNumberOfCalls(s.T(), "Transaction", 1)
+}
+
+func (s *BookServiceSuite) TestSetBookTags2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Set Book Tags Concurrent Edit", 31)
+
+	// Setup Mock
+	current := book.Book{ID: uuid.New(), Title: "Dune", Version: 2}
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).Return(current, nil)
+	s.mockRepo.EXPECT().ReplaceBookTags(mock.Anything, current.ID, []string{"classic"}).Return(nil)
+	s.mockRepo.EXPECT().PatchBook(mock.Anything, current, map[string]interface{}{}).Return(book.Book{}, book.ErrVersionConflict)
+
+	// Call the service method
+	_, err := s.service.SetBookTags(&fiber.Ctx{}, current.ID, book.BookTagsRequest{Tags: []string{"classic"}})
+
+	// Assertions
+	s.ErrorIs(err, book.ErrVersionConflict)
+}
+
+func (s *BookServiceSuite) TestSetBookCategories1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Set Book Categories Bumps Version", 34)
+
+	// Setup Mock
+	current := book.Book{ID: uuid.New(), Title: "Dune", Version: 2}
+	categoryID := uuid.New()
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).Return(current, nil).Twice()
+	s.mockRepo.EXPECT().ReplaceBookCategories(mock.Anything, current.ID, []uuid.UUID{categoryID}).Return(nil)
+	s.mockRepo.EXPECT().PatchBook(mock.Anything, current, map[string]interface{}{}).Return(current, nil)
+
+	// Call the service method
+	_, err := s.service.SetBookCategories(&fiber.Ctx{}, current.ID, book.BookCategoriesRequest{CategoryIDs: []uuid.UUID{categoryID, categoryID}})
+
+	// Assertions
+	s.NoError(err)
+	s.mockRepo.AssertNumberOfCalls(s.T(), "Transaction", 1)
+}
+
+func (s *BookServiceSuite) TestSetBookWork1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Set Book Work Stale If-Match", 31)
+
+	// Setup Mock
+	current := book.Book{ID: uuid.New(), Title: "Dune", Version: 4}
+	workID := uuid.New()
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).Return(current, nil)
+
+	// Call the service method
+	_, err := s.service.SetBookWork(&fiber.Ctx{}, current.ID, book.BookWorkRequest{WorkID: &workID}, `"3"`)
+
+	// Assertions
+	s.ErrorIs(err, book.ErrPreconditionFailed)
+}
+
+func (s *BookServiceSuite) TestSetBookWork2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Set Book Work Holds The Repository To The If-Match Version", 34)
+
+	// Setup Mock
+	current := book.Book{ID: uuid.New(), Title: "Dune", Version: 4}
+	workID := uuid.New()
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).Return(current, nil).Twice()
+	s.mockRepo.EXPECT().UpdateBookWork(mock.Anything, current.ID, &workID, 4).Return(nil)
+
+	// Call the service method
+	_, err := s.service.SetBookWork(&fiber.Ctx{}, current.ID, book.BookWorkRequest{WorkID: &workID}, `"4"`)
+
+	// Assertions
+	s.NoError(err)
+}
+
+func (s *BookServiceSuite) TestSetBookSeries1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Set Book Series Lost Race With If-Match", 31)
+
+	// Setup Mock
+	current := book.Book{ID: uuid.New(), Title: "Dune", Version: 4}
+	seriesID, volume := uuid.New(), 1.0
+	s.mockRepo.EXPECT().FindBookByID(mock.Anything, current.ID).Return(current, nil)
+	s.mockRepo.EXPECT().UpdateBookSeries(mock.Anything, current.ID, &seriesID, &volume, 4).Return(book.ErrVersionConflict)
+
+	// Call the service method
+	_, err := s.service.SetBookSeries(&fiber.Ctx{}, current.ID, book.BookSeriesRequest{SeriesID: &seriesID, Volume: &volume}, `"4"`)
+
+	// Assertions
+	s.ErrorIs(err, book.ErrPreconditionFailed)
+}
@@ -0,0 +1,30 @@
+package book
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidTags       = errors.New("invalid book tags")
+	ErrInvalidCategories = errors.New("invalid book categories")
+)
+
+func normalizeTag(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// normalizeTags lower-cases and de-duplicates tag names, keeping the first occurrence order
+func normalizeTags(names []string) []string {
+	tags := make([]string, 0, len(names))
+	seen := map[string]bool{}
+	for _, name := range names {
+		tag := normalizeTag(name)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
@@ -0,0 +1,125 @@
+package book
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+var (
+	ErrInvalidLocale      = errors.New("invalid locale")
+	ErrInvalidTranslation = errors.New("invalid book translation")
+)
+
+// CanonicalLocale validates a BCP 47 tag and returns it in canonical form, so "PT-br" and "pt-BR" are one locale
+func CanonicalLocale(value string) (string, error) {
+	tag, err := language.Parse(strings.TrimSpace(value))
+	if err != nil || tag == language.Und {
+		return "", fmt.Errorf("%w: %s", ErrInvalidLocale, value)
+	}
+	return tag.String(), nil
+}
+
+// LocaleChain lists the locales a reader asked for in the order to try them. A ?lang parameter wins over
+// Accept-Language, a malformed header is ignored, and every locale is followed by its less specific forms
+// so "pt-BR" falls back to "pt".
+func LocaleChain(lang, acceptLanguage string) ([]string, error) {
+	var preferred []string
+	if strings.TrimSpace(lang) != "" {
+		locale, err := CanonicalLocale(lang)
+		if err != nil {
+			return nil, err
+		}
+		preferred = append(preferred, locale)
+	} else if tags, _, err := language.ParseAcceptLanguage(acceptLanguage); err == nil {
+		for _, tag := range tags {
+			// "*" is parsed as "mul", neither it nor "und" names a translation
+			if tag != language.Und && tag != language.MustParse("mul") {
+				preferred = append(preferred, tag.String())
+			}
+		}
+	}
+
+	var chain []string
+	for _, locale := range preferred {
+		for ; locale != ""; locale = parentLocale(locale) {
+			if !slices.ContainsFunc(chain, func(known string) bool { return strings.EqualFold(known, locale) }) {
+				chain = append(chain, locale)
+			}
+		}
+	}
+	return chain, nil
+}
+
+// parentLocale drops the last subtag of a locale together with a singleton left in front of it, like RFC 4647 lookup
+func parentLocale(locale string) string {
+	i := strings.LastIndex(locale, "-")
+	if i < 0 {
+		return ""
+	}
+	locale = locale[:i]
+	if j := strings.LastIndex(locale, "-"); j >= 0 && j == len(locale)-2 {
+		return locale[:j]
+	}
+	return locale
+}
+
+// Localize swaps the title, subtitle and description of the book for those of the first locale in the chain
+// that has them, field by field, and sets Locale to the locale of the title. Reaching the book's own language
+// ends the search. Embedded editions and series volumes are localized the same way.
+func Localize(book Book, chain []string) Book {
+	book.Locale = book.Language
+	book.textLocales = nil
+	var title, subtitle, description string
+	for _, locale := range chain {
+		if strings.EqualFold(locale, book.Language) {
+			break
+		}
+		for _, translation := range book.Translations {
+			if !strings.EqualFold(translation.Locale, locale) {
+				continue
+			}
+			used := title == "" || (subtitle == "" && translation.Subtitle != "") || (description == "" && translation.Description != "")
+			if title == "" {
+				title = translation.Title
+				book.Locale = translation.Locale
+			}
+			subtitle = cmp.Or(subtitle, translation.Subtitle)
+			description = cmp.Or(description, translation.Description)
+			if used {
+				book.textLocales = append(book.textLocales, translation.Locale)
+			}
+		}
+	}
+	book.Title = cmp.Or(title, book.Title)
+	book.Subtitle = cmp.Or(subtitle, book.Subtitle)
+	book.Description = cmp.Or(description, book.Description)
+
+	for i := range book.Editions {
+		book.Editions[i] = Localize(book.Editions[i], chain)
+	}
+	if book.Neighbours != nil {
+		neighbours := *book.Neighbours
+		if neighbours.Previous != nil {
+			previous := Localize(*neighbours.Previous, chain)
+			neighbours.Previous = &previous
+		}
+		if neighbours.Next != nil {
+			next := Localize(*neighbours.Next, chain)
+			neighbours.Next = &next
+		}
+		book.Neighbours = &neighbours
+	}
+	return book
+}
+
+func LocalizeBooks(books []Book, chain []string) []Book {
+	for i := range books {
+		books[i] = Localize(books[i], chain)
+	}
+	return books
+}
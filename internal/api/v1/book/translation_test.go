@@ -0,0 +1,160 @@
+package book_test
+
+import (
+	"testing"
+
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type TranslationSuite struct {
+	suite.Suite
+}
+
+func TestTranslationSuite(t *testing.T) {
+	suite.Run(t, new(TranslationSuite))
+}
+
+func (s *TranslationSuite) TestLocaleChain1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Locale Chain From Lang And Accept-Language", 34)
+
+	tests := []struct {
+		lang           string
+		acceptLanguage string
+		chain          []string
+	}{
+		{"", "", nil},
+		{"fr", "de", []string{"fr"}},
+		{"PT-br", "", []string{"pt-BR", "pt"}},
+		{"", "pt-BR, en;q=0.5", []string{"pt-BR", "pt", "en"}},
+		{"", "en;q=0.5, pt-BR", []string{"pt-BR", "pt", "en"}},
+		{"", "zh-Hant-TW", []string{"zh-Hant-TW", "zh-Hant", "zh"}},
+		// a parent already listed is not repeated
+		{"", "pt-BR, pt-PT, pt", []string{"pt-BR", "pt", "pt-PT"}},
+		{"", "*, und, fr", []string{"fr"}},
+		// a malformed header is ignored rather than rejected
+		{"", "fr;q=x", nil},
+	}
+
+	for _, test := range tests {
+		// Call the service method
+		chain, err := book.LocaleChain(test.lang, test.acceptLanguage)
+
+		// Assertions
+		s.NoError(err, "%q %q", test.lang, test.acceptLanguage)
+		s.Equal(test.chain, chain, "%q %q", test.lang, test.acceptLanguage)
+	}
+}
+
+func (s *TranslationSuite) TestLocaleChain2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Locale Chain Parents Drop Singletons", 34)
+
+	tests := []struct {
+		lang  string
+		chain []string
+	}{
+		{"de-CH-1901", []string{"de-CH-1901", "de-CH", "de"}},
+		// the private use subtag goes together with the x in front of it
+		{"en-US-x-twain", []string{"en-US-x-twain", "en-US", "en"}},
+		{"sr-Latn-RS", []string{"sr-Latn-RS", "sr-Latn", "sr"}},
+	}
+
+	for _, test := range tests {
+		// Call the service method
+		chain, err := book.LocaleChain(test.lang, "")
+
+		// Assertions
+		s.NoError(err, test.lang)
+		s.Equal(test.chain, chain, test.lang)
+	}
+}
+
+func (s *TranslationSuite) TestLocaleChain3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Locale Chain Invalid Lang", 31)
+
+	for _, lang := range []string{"not a locale", "und", "x"} {
+		// Call the service method
+		_, err := book.LocaleChain(lang, "fr")
+
+		// Assertions
+		s.ErrorIs(err, book.ErrInvalidLocale, lang)
+	}
+}
+
+func (s *TranslationSuite) TestLocalize1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Localize Falls Back Field By Field", 34)
+
+	original := book.Book{
+		Title:       "Dune",
+		Subtitle:    "A Novel",
+		Description: "Desert planet",
+		Language:    "en",
+		Version:     4,
+		Translations: []book.BookTranslation{
+			{Locale: "fr", Title: "Dune", Subtitle: "Un roman", Description: "Planète désertique"},
+			{Locale: "fr-CA", Title: "Dune (CA)"},
+			{Locale: "de", Title: "Der Wüstenplanet", Description: "Wüstenplanet"},
+		},
+	}
+
+	tests := []struct {
+		chain       []string
+		title       string
+		subtitle    string
+		description string
+		locale      string
+		etag        string
+	}{
+		{nil, "Dune", "A Novel", "Desert planet", "en", `"4"`},
+		{[]string{"es"}, "Dune", "A Novel", "Desert planet", "en", `"4"`},
+		{[]string{"fr"}, "Dune", "Un roman", "Planète désertique", "fr", `"4-fr"`},
+		{[]string{"fr-CA", "fr"}, "Dune (CA)", "Un roman", "Planète désertique", "fr-CA", `"4-fr-CA+fr"`},
+		{[]string{"de", "fr"}, "Der Wüstenplanet", "Un roman", "Wüstenplanet", "de", `"4-de+fr"`},
+		// a locale that adds nothing is left out of the ETag
+		{[]string{"fr", "de"}, "Dune", "Un roman", "Planète désertique", "fr", `"4-fr"`},
+		// the book's own language ends the search
+		{[]string{"en", "fr"}, "Dune", "A Novel", "Desert planet", "en", `"4"`},
+		{[]string{"FR"}, "Dune", "Un roman", "Planète désertique", "fr", `"4-fr"`},
+	}
+
+	for _, test := range tests {
+		// Call the service method
+		result := book.Localize(original, test.chain)
+
+		// Assertions
+		s.Equal(test.title, result.Title, "%v", test.chain)
+		s.Equal(test.subtitle, result.Subtitle, "%v", test.chain)
+		s.Equal(test.description, result.Description, "%v", test.chain)
+		s.Equal(test.locale, result.Locale, "%v", test.chain)
+		s.Equal(test.etag, result.ETag(), "%v", test.chain)
+	}
+}
+
+func (s *TranslationSuite) TestLocalize2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Localize Embedded Editions And Volumes", 34)
+
+	previous := book.Book{Title: "Dune", Language: "en", Translations: []book.BookTranslation{{Locale: "fr", Title: "Dune (fr)"}}}
+	original := book.Book{
+		Title:      "Dune Messiah",
+		Language:   "en",
+		Editions:   []book.Book{{Title: "Dune Messiah", Language: "en", Translations: []book.BookTranslation{{Locale: "fr", Title: "Le Messie de Dune"}}}},
+		Neighbours: &book.SeriesNeighbours{Previous: &previous},
+	}
+
+	// Call the service method
+	result := book.Localize(original, []string{"fr"})
+
+	// Assertions
+	s.Equal("Dune Messiah", result.Title)
+	s.Equal("en", result.Locale)
+	s.Equal("Le Messie de Dune", result.Editions[0].Title)
+	s.Equal("fr", result.Editions[0].Locale)
+	s.Equal("Dune (fr)", result.Neighbours.Previous.Title)
+	s.Equal("Dune", previous.Title)
+}
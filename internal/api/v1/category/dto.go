@@ -0,0 +1,10 @@
+package category
+
+import "github.com/google/uuid"
+
+// CategoryRequest is the writable view of a category, an empty slug is derived from the name
+type CategoryRequest struct {
+	Name     string     `json:"name" validate:"required,max=100"`
+	Slug     string     `json:"slug" validate:"omitempty,max=100"`
+	ParentID *uuid.UUID `json:"parent_id"`
+}
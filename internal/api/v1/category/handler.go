@@ -0,0 +1,93 @@
+package category
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/pkg/patch"
+)
+
+type CategoryHandler struct {
+	config  *config.Config
+	service CategoryService
+}
+
+func NewCategoryHandler(config *config.Config, service CategoryService) *CategoryHandler {
+	return &CategoryHandler{config: config, service: service}
+}
+
+// Handler methods
+func (h *CategoryHandler) GetCategories(c *fiber.Ctx) error {
+	categories, err := h.service.GetCategoryTree(c)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"categories": categories})
+}
+
+func (h *CategoryHandler) GetCategory(c *fiber.Ctx) error {
+	categoryID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid category ID"})
+	}
+
+	category, err := h.service.GetCategory(c, categoryID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"category": category})
+}
+
+func (h *CategoryHandler) CreateCategory(c *fiber.Ctx) error {
+	var categoryParams CategoryRequest
+	if err := c.BodyParser(&categoryParams); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid input"})
+	}
+
+	category, err := h.service.CreateCategory(c, categoryParams)
+	if err != nil {
+		if errors.Is(err, ErrInvalidCategory) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"category": category})
+}
+
+func (h *CategoryHandler) UpdateCategory(c *fiber.Ctx) error {
+	categoryID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid category ID"})
+	}
+
+	category, err := h.service.UpdateCategory(c, categoryID, c.Get(fiber.HeaderContentType), c.Body())
+	if err != nil {
+		switch {
+		case errors.Is(err, patch.ErrUnsupportedMediaType):
+			return c.Status(fiber.StatusUnsupportedMediaType).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, patch.ErrInvalidPatch), errors.Is(err, ErrInvalidCategory):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, patch.ErrTestFailed):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"category": category})
+}
+
+func (h *CategoryHandler) DeleteCategory(c *fiber.Ctx) error {
+	categoryID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid category ID"})
+	}
+
+	if err := h.service.DeleteCategory(c, categoryID); err != nil {
+		if errors.Is(err, ErrCategoryHasChildren) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,19 @@
+package category
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Category is a node of the catalogue tree, root categories have no parent
+type Category struct {
+	ID        uuid.UUID  `gorm:"type:uuid; default:uuid_generate_v4()" json:"id"`
+	Name      string     `gorm:"size:100;not null" json:"name"`
+	Slug      string     `gorm:"size:100;not null;uniqueIndex" json:"slug"`
+	ParentID  *uuid.UUID `gorm:"type:uuid;index" json:"parent_id"`
+	Parent    *Category  `gorm:"foreignKey:ParentID;constraint:OnDelete:RESTRICT" json:"-"`
+	Children  []Category `gorm:"-" json:"children,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,114 @@
+package category
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/pkg/database"
+	"gorm.io/gorm"
+)
+
+// DescendantsQuery selects the ID of a category and all categories below it, the category is matched by ID or slug
+const DescendantsQuery = `WITH RECURSIVE category_tree AS (
+	SELECT id FROM categories WHERE id::text = ? OR slug = ?
+	UNION ALL
+	SELECT categories.id FROM categories JOIN category_tree ON categories.parent_id = category_tree.id
+) SELECT id FROM category_tree`
+
+type CategoryRepository interface {
+	FindAllCategories(c *fiber.Ctx) ([]Category, error)
+	FindCategoryByID(c *fiber.Ctx, categoryID uuid.UUID) (Category, error)
+	FindDescendantIDs(c *fiber.Ctx, categoryID uuid.UUID) ([]uuid.UUID, error)
+	CreateCategory(c *fiber.Ctx, newCategory Category) (Category, error)
+	UpdateCategoryFields(c *fiber.Ctx, categoryID uuid.UUID, fields map[string]interface{}) error
+	CountChildren(c *fiber.Ctx, categoryID uuid.UUID) (int64, error)
+	DeleteCategory(c *fiber.Ctx, categoryID uuid.UUID) error
+	FindCategoryBookIDs(c *fiber.Ctx, categoryID uuid.UUID) ([]uuid.UUID, error)
+}
+
+type categoryRepository struct {
+	config *config.Config
+	db     *database.GormDB
+}
+
+func NewCategoryRepository(cfg *config.Config, db *database.GormDB) CategoryRepository {
+	return &categoryRepository{config: cfg, db: db}
+}
+
+// Repository methods
+
+func (r *categoryRepository) FindAllCategories(c *fiber.Ctx) ([]Category, error) {
+	var categories []Category
+	if err := r.db.DB.Order("name").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+func (r *categoryRepository) FindCategoryByID(c *fiber.Ctx, categoryID uuid.UUID) (Category, error) {
+	var category Category
+	if err := r.db.DB.Where("id = ?", categoryID).First(&category).Error; err != nil {
+		return Category{}, err
+	}
+	return category, nil
+}
+
+func (r *categoryRepository) FindDescendantIDs(c *fiber.Ctx, categoryID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := r.db.DB.Raw(DescendantsQuery, categoryID.String(), categoryID.String()).Scan(&ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *categoryRepository) CreateCategory(c *fiber.Ctx, newCategory Category) (Category, error) {
+	if err := r.db.DB.Create(&newCategory).Error; err != nil {
+		return Category{}, err
+	}
+	return newCategory, nil
+}
+
+func (r *categoryRepository) UpdateCategoryFields(c *fiber.Ctx, categoryID uuid.UUID, fields map[string]interface{}) error {
+	result := r.db.DB.Model(&Category{}).Where("id = ?", categoryID).Updates(fields)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *categoryRepository) CountChildren(c *fiber.Ctx, categoryID uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.db.DB.Model(&Category{}).Where("parent_id = ?", categoryID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// DeleteCategory unlinks the category from its books before removing it
+func (r *categoryRepository) DeleteCategory(c *fiber.Ctx, categoryID uuid.UUID) error {
+	return r.db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM book_categories WHERE category_id = ?", categoryID).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&Category{}, "id = ?", categoryID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// FindCategoryBookIDs lists the books filed under the category
+func (r *categoryRepository) FindCategoryBookIDs(c *fiber.Ctx, categoryID uuid.UUID) ([]uuid.UUID, error) {
+	var bookIDs []uuid.UUID
+	if err := r.db.DB.Table("book_categories").Where("category_id = ?", categoryID).Pluck("book_id", &bookIDs).Error; err != nil {
+		return nil, err
+	}
+	return bookIDs, nil
+}
@@ -0,0 +1,21 @@
+package category
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/middleware"
+)
+
+func RegisterRoutes(cfg *config.Config, router fiber.Router, handler *CategoryHandler) {
+	categoryGroup := router.Group("/categories")
+	{
+		// Public routes - anyone can access
+		categoryGroup.Get("", handler.GetCategories)
+		categoryGroup.Get("/:id", handler.GetCategory)
+
+		// Admin only routes - only admins can change the category tree
+		categoryGroup.Post("", middleware.JWTMiddleware(cfg), middleware.AdminOnly(), handler.CreateCategory)
+		categoryGroup.Patch("/:id", middleware.JWTMiddleware(cfg), middleware.AdminOnly(), handler.UpdateCategory)
+		categoryGroup.Delete("/:id", middleware.JWTMiddleware(cfg), middleware.AdminOnly(), handler.DeleteCategory)
+	}
+}
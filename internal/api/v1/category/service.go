@@ -0,0 +1,152 @@
+package category
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/pkg/patch"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+)
+
+var (
+	ErrInvalidCategory     = errors.New("invalid category")
+	ErrCategoryHasChildren = errors.New("category still has subcategories")
+)
+
+// Setup
+type CategoryService interface {
+	GetCategoryTree(c *fiber.Ctx) ([]Category, error)
+	GetCategory(c *fiber.Ctx, categoryID uuid.UUID) (Category, error)
+	CreateCategory(c *fiber.Ctx, categoryParams CategoryRequest) (Category, error)
+	UpdateCategory(c *fiber.Ctx, categoryID uuid.UUID, contentType string, body []byte) (Category, error)
+	DeleteCategory(c *fiber.Ctx, categoryID uuid.UUID) error
+}
+
+// BookCache drops cached copies of books, it is implemented by the book package which embeds the categories in its books
+type BookCache interface {
+	InvalidateBooks(c *fiber.Ctx, bookIDs ...uuid.UUID)
+}
+
+type categoryService struct {
+	config    *config.Config
+	repo      CategoryRepository
+	bookCache BookCache
+}
+
+func NewCategoryService(config *config.Config, repo CategoryRepository, bookCache BookCache) CategoryService {
+	return &categoryService{config: config, repo: repo, bookCache: bookCache}
+}
+
+// Service methods
+func (s *categoryService) GetCategoryTree(c *fiber.Ctx) ([]Category, error) {
+	categories, err := s.repo.FindAllCategories(c)
+	if err != nil {
+		return nil, err
+	}
+	return buildTree(categories), nil
+}
+
+func (s *categoryService) GetCategory(c *fiber.Ctx, categoryID uuid.UUID) (Category, error) {
+	return s.repo.FindCategoryByID(c, categoryID)
+}
+
+func (s *categoryService) CreateCategory(c *fiber.Ctx, categoryParams CategoryRequest) (Category, error) {
+	if err := s.validate(c, uuid.Nil, &categoryParams); err != nil {
+		return Category{}, err
+	}
+
+	newCategory := Category{Name: categoryParams.Name, Slug: categoryParams.Slug, ParentID: categoryParams.ParentID}
+	return s.repo.CreateCategory(c, newCategory)
+}
+
+// UpdateCategory applies a merge patch or JSON patch, moving a category below one of its own descendants is rejected
+func (s *categoryService) UpdateCategory(c *fiber.Ctx, categoryID uuid.UUID, contentType string, body []byte) (Category, error) {
+	category, err := s.repo.FindCategoryByID(c, categoryID)
+	if err != nil {
+		return Category{}, err
+	}
+
+	current := CategoryRequest{Name: category.Name, Slug: category.Slug, ParentID: category.ParentID}
+	patched := current
+	if err := patch.Apply(&patched, contentType, body); err != nil {
+		return Category{}, err
+	}
+	if err := s.validate(c, categoryID, &patched); err != nil {
+		return Category{}, err
+	}
+
+	if changes := patch.Changes(current, patched); len(changes) > 0 {
+		bookIDs := s.findBookIDs(c, categoryID)
+		if err := s.repo.UpdateCategoryFields(c, categoryID, changes); err != nil {
+			return Category{}, err
+		}
+		s.bookCache.InvalidateBooks(c, bookIDs...)
+	}
+	return s.repo.FindCategoryByID(c, categoryID)
+}
+
+func (s *categoryService) DeleteCategory(c *fiber.Ctx, categoryID uuid.UUID) error {
+	children, err := s.repo.CountChildren(c, categoryID)
+	if err != nil {
+		return err
+	}
+	if children > 0 {
+		return fmt.Errorf("%w: %d subcategories", ErrCategoryHasChildren, children)
+	}
+
+	// the books are looked up first, the delete unlinks them
+	bookIDs := s.findBookIDs(c, categoryID)
+	if err := s.repo.DeleteCategory(c, categoryID); err != nil {
+		return err
+	}
+	s.bookCache.InvalidateBooks(c, bookIDs...)
+	return nil
+}
+
+// findBookIDs lists the books whose cached copies show the category, a failed lookup leaves them to expire
+func (s *categoryService) findBookIDs(c *fiber.Ctx, categoryID uuid.UUID) []uuid.UUID {
+	bookIDs, err := s.repo.FindCategoryBookIDs(c, categoryID)
+	if err != nil {
+		log.Errorf("failed to find the books of the category: %v", err)
+	}
+	return bookIDs
+}
+
+// validate normalises the request and checks that the parent exists and is not the category itself or below it
+func (s *categoryService) validate(c *fiber.Ctx, categoryID uuid.UUID, categoryParams *CategoryRequest) error {
+	categoryParams.Name = strings.TrimSpace(categoryParams.Name)
+	categoryParams.Slug = strings.TrimSpace(categoryParams.Slug)
+	if categoryParams.Slug == "" {
+		categoryParams.Slug = Slugify(categoryParams.Name)
+	}
+
+	if err := utils.Validate(categoryParams); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCategory, err)
+	}
+	if !slugPattern.MatchString(categoryParams.Slug) {
+		return fmt.Errorf("%w: slug must be lower-case words joined by dashes", ErrInvalidCategory)
+	}
+
+	if categoryParams.ParentID == nil {
+		return nil
+	}
+	if _, err := s.repo.FindCategoryByID(c, *categoryParams.ParentID); err != nil {
+		return fmt.Errorf("%w: parent category not found", ErrInvalidCategory)
+	}
+	if categoryID != uuid.Nil {
+		descendants, err := s.repo.FindDescendantIDs(c, categoryID)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(descendants, *categoryParams.ParentID) {
+			return fmt.Errorf("%w: a category cannot be moved below itself", ErrInvalidCategory)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package category
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	slugInvalid = regexp.MustCompile(`[^a-z0-9]+`)
+	slugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+)
+
+// Slugify lower-cases the name and joins its words with dashes, e.g. "Science Fiction" becomes "science-fiction"
+func Slugify(name string) string {
+	return strings.Trim(slugInvalid.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// buildTree nests the flat category list under their parents, ordered as given
+func buildTree(categories []Category) []Category {
+	children := map[string][]Category{}
+	for _, category := range categories {
+		parent := ""
+		if category.ParentID != nil {
+			parent = category.ParentID.String()
+		}
+		children[parent] = append(children[parent], category)
+	}
+
+	var attach func(parent string) []Category
+	attach = func(parent string) []Category {
+		nodes := children[parent]
+		for i := range nodes {
+			nodes[i].Children = attach(nodes[i].ID.String())
+		}
+		return nodes
+	}
+	return attach("")
+}
@@ -0,0 +1,32 @@
+package category_test
+
+import (
+	"testing"
+
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/category"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type SlugSuite struct {
+	suite.Suite
+}
+
+func TestSlugSuite(t *testing.T) {
+	suite.Run(t, new(SlugSuite))
+}
+
+func (s *SlugSuite) TestSlugify1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Slugify Name", 34)
+
+	s.Equal("science-fiction", category.Slugify("Science Fiction"))
+	s.Equal("art-design", category.Slugify("  Art & Design! "))
+}
+
+func (s *SlugSuite) TestSlugify2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Slugify Without Letters", 31)
+
+	s.Equal("", category.Slugify("!!!"))
+}
@@ -0,0 +1,83 @@
+package circulation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/user"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AccountLedger keeps an account from being deleted while the borrower has copies out, a copy set aside or
+// fines to pay. The loans, holds and fines themselves stay, they restrict the delete of their borrower.
+type AccountLedger struct{}
+
+func NewAccountLedger() *AccountLedger {
+	return &AccountLedger{}
+}
+
+// ReleaseAccount runs under the borrower lock AnonymiseUser takes, the one checkouts and fines take too. Holds
+// still waiting are cancelled, locked first so none of them is set a copy aside in between.
+func (l *AccountLedger) ReleaseAccount(c *fiber.Ctx, tx *gorm.DB, userID uuid.UUID) (func(), error) {
+	var holds []Hold
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ? AND status IN ?", userID, []HoldStatus{HoldWaiting, HoldReady}).
+		Find(&holds).Error; err != nil {
+		return nil, err
+	}
+	for _, hold := range holds {
+		if hold.Status == HoldReady {
+			return nil, fmt.Errorf("%w: a copy is set aside for a hold", user.ErrAccountInUse)
+		}
+	}
+
+	var openLoans int64
+	if err := tx.Model(&Loan{}).Where("user_id = ? AND returned_at IS NULL", userID).Count(&openLoans).Error; err != nil {
+		return nil, err
+	}
+	if openLoans > 0 {
+		return nil, fmt.Errorf("%w: %d copies are still on loan", user.ErrAccountInUse, openLoans)
+	}
+
+	balance, err := userBalance(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if balance > 0 {
+		return nil, fmt.Errorf("%w: %d cents of fines are unpaid", user.ErrAccountInUse, balance)
+	}
+
+	err = tx.Model(&Hold{}).Where("user_id = ? AND status = ?", userID, HoldWaiting).
+		Updates(map[string]interface{}{"status": HoldCancelled, "closed_at": time.Now()}).Error
+	return nil, err
+}
+
+// RestrictBorrowerKeys drops the foreign keys that deleted the loans, holds and fines of a deleted user, so
+// AutoMigrate creates them again with the RESTRICT they have now
+func RestrictBorrowerKeys(db *gorm.DB) error {
+	keys := []struct {
+		model interface{}
+		name  string
+	}{
+		{&Loan{}, "fk_loans_borrower"},
+		{&Hold{}, "fk_holds_holder"},
+		{&FineEntry{}, "fk_fine_entries_borrower"},
+	}
+	for _, key := range keys {
+		var rule string
+		err := db.Raw("SELECT delete_rule FROM information_schema.referential_constraints WHERE constraint_name = ?", key.name).
+			Scan(&rule).Error
+		if err != nil {
+			return err
+		}
+		if rule != "CASCADE" {
+			continue
+		}
+		if err := db.Migrator().DropConstraint(key.model, key.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
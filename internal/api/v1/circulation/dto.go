@@ -0,0 +1,38 @@
+package circulation
+
+import "github.com/google/uuid"
+
+type CopyRequest struct {
+	Barcode   string        `json:"barcode" validate:"required,max=64"`
+	Location  string        `json:"location" validate:"omitempty,max=255"`
+	Condition CopyCondition `json:"condition" validate:"omitempty,oneof=new good fair poor damaged"`
+	Status    CopyStatus    `json:"status" validate:"omitempty,oneof=available on_loan lost withdrawn"`
+}
+
+type CheckoutRequest struct {
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+}
+
+type LoanFilter struct {
+	History bool `query:"history"`
+}
+
+// FineCreditRequest records a payment or a waiver, the amount is what the borrower no longer owes
+type FineCreditRequest struct {
+	AmountCents int64      `json:"amount_cents" validate:"required,min=1"`
+	Note        string     `json:"note" validate:"omitempty,max=255"`
+	LoanID      *uuid.UUID `json:"loan_id"`
+}
+
+type FinesResponse struct {
+	BalanceCents int64       `json:"balance_cents"`
+	Entries      []FineEntry `json:"entries"`
+}
+
+// OverdueReport sums up one run of the overdue job
+type OverdueReport struct {
+	DueReminders     int   `json:"due_reminders"`
+	OverdueReminders int   `json:"overdue_reminders"`
+	OverdueLoans     int   `json:"overdue_loans"`
+	AccruedCents     int64 `json:"accrued_cents"`
+}
@@ -0,0 +1,301 @@
+package circulation
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/middleware"
+	"github.com/jerpsp/go-fiber-beginner/pkg/patch"
+	"gorm.io/gorm"
+)
+
+type CirculationHandler struct {
+	config  *config.Config
+	service CirculationService
+}
+
+func NewCirculationHandler(config *config.Config, service CirculationService) *CirculationHandler {
+	return &CirculationHandler{config: config, service: service}
+}
+
+// Handler methods
+func (h *CirculationHandler) GetBookCopies(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	copies, err := h.service.GetBookCopies(c, bookID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"copies": copies})
+}
+
+func (h *CirculationHandler) GetCopy(c *fiber.Ctx) error {
+	copyID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid copy ID"})
+	}
+
+	bookCopy, err := h.service.GetCopy(c, copyID)
+	if err != nil {
+		return circulationError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"copy": bookCopy})
+}
+
+func (h *CirculationHandler) CreateCopy(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	var copyParams CopyRequest
+	if err := c.BodyParser(&copyParams); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid input"})
+	}
+
+	bookCopy, err := h.service.CreateCopy(c, bookID, copyParams)
+	if err != nil {
+		return circulationError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"copy": bookCopy})
+}
+
+func (h *CirculationHandler) UpdateCopy(c *fiber.Ctx) error {
+	copyID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid copy ID"})
+	}
+
+	bookCopy, err := h.service.UpdateCopy(c, copyID, c.Get(fiber.HeaderContentType), c.Body())
+	if err != nil {
+		return circulationError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"copy": bookCopy})
+}
+
+func (h *CirculationHandler) DeleteCopy(c *fiber.Ctx) error {
+	copyID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid copy ID"})
+	}
+
+	if err := h.service.DeleteCopy(c, copyID); err != nil {
+		return circulationError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *CirculationHandler) CheckoutCopy(c *fiber.Ctx) error {
+	copyID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid copy ID"})
+	}
+
+	var checkoutParams CheckoutRequest
+	if err := c.BodyParser(&checkoutParams); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid input"})
+	}
+
+	staffID, _ := c.Locals("userID").(uuid.UUID)
+	loan, err := h.service.CheckoutCopy(c, copyID, staffID, checkoutParams)
+	if err != nil {
+		return circulationError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"loan": loan})
+}
+
+func (h *CirculationHandler) ReturnCopy(c *fiber.Ctx) error {
+	copyID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid copy ID"})
+	}
+
+	staffID, _ := c.Locals("userID").(uuid.UUID)
+	loan, err := h.service.ReturnCopy(c, copyID, staffID)
+	if err != nil {
+		return circulationError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"loan": loan})
+}
+
+func (h *CirculationHandler) RenewLoan(c *fiber.Ctx) error {
+	loanID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid loan ID"})
+	}
+
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	loan, err := h.service.RenewLoan(c, loanID, userID, isStaff(c))
+	if err != nil {
+		return circulationError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"loan": loan})
+}
+
+func (h *CirculationHandler) GetMyLoans(c *fiber.Ctx) error {
+	var filter LoanFilter
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid query parameters"})
+	}
+
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	loans, err := h.service.GetUserLoans(c, userID, filter.History)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"loans": loans})
+}
+
+func (h *CirculationHandler) PlaceHold(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	hold, err := h.service.PlaceHold(c, bookID, userID)
+	if err != nil {
+		return circulationError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"hold": hold})
+}
+
+func (h *CirculationHandler) GetBookHolds(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	holds, err := h.service.GetBookHolds(c, bookID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"holds": holds})
+}
+
+func (h *CirculationHandler) GetMyHolds(c *fiber.Ctx) error {
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	holds, err := h.service.GetUserHolds(c, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"holds": holds})
+}
+
+func (h *CirculationHandler) GetHold(c *fiber.Ctx) error {
+	holdID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid hold ID"})
+	}
+
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	hold, err := h.service.GetHold(c, holdID, userID, isStaff(c))
+	if err != nil {
+		return circulationError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"hold": hold})
+}
+
+func (h *CirculationHandler) CancelHold(c *fiber.Ctx) error {
+	holdID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid hold ID"})
+	}
+
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	if err := h.service.CancelHold(c, holdID, userID, isStaff(c)); err != nil {
+		return circulationError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *CirculationHandler) GetMyFines(c *fiber.Ctx) error {
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	fines, err := h.service.GetUserFines(c, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.JSON(fines)
+}
+
+func (h *CirculationHandler) GetUserFines(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid user ID"})
+	}
+
+	fines, err := h.service.GetUserFines(c, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.JSON(fines)
+}
+
+func (h *CirculationHandler) GetBalances(c *fiber.Ctx) error {
+	balances, err := h.service.GetBalances(c)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"balances": balances})
+}
+
+func (h *CirculationHandler) RecordPayment(c *fiber.Ctx) error {
+	return h.creditFines(c, FinePayment)
+}
+
+func (h *CirculationHandler) WaiveFines(c *fiber.Ctx) error {
+	return h.creditFines(c, FineWaiver)
+}
+
+func (h *CirculationHandler) creditFines(c *fiber.Ctx, kind FineKind) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid user ID"})
+	}
+
+	var creditParams FineCreditRequest
+	if err := c.BodyParser(&creditParams); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid input"})
+	}
+
+	staffID, _ := c.Locals("userID").(uuid.UUID)
+	fines, err := h.service.CreditFines(c, userID, staffID, kind, creditParams)
+	if err != nil {
+		return circulationError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(fines)
+}
+
+// isStaff reports whether the authenticated user works the lending desk
+func isStaff(c *fiber.Ctx) bool {
+	role, _ := c.Locals("role").(string)
+	return middleware.UserRole(role) == middleware.RoleAdmin || middleware.UserRole(role) == middleware.RoleModerator
+}
+
+// circulationError maps service errors to the matching HTTP status
+func circulationError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, patch.ErrUnsupportedMediaType):
+		return c.Status(fiber.StatusUnsupportedMediaType).JSON(fiber.Map{"message": err.Error()})
+	case errors.Is(err, ErrInvalidCopy), errors.Is(err, ErrInvalidFine), errors.Is(err, patch.ErrInvalidPatch):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+	case errors.Is(err, ErrNotBorrower), errors.Is(err, ErrNotHolder):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"message": err.Error()})
+	case errors.Is(err, ErrCopyUnavailable), errors.Is(err, ErrCopyOnLoan), errors.Is(err, ErrCopyHasLoans),
+		errors.Is(err, ErrNotOnLoan), errors.Is(err, ErrLoanClosed), errors.Is(err, ErrLoanChanged),
+		errors.Is(err, ErrCopyOnHold), errors.Is(err, ErrCopyAvailable), errors.Is(err, ErrAlreadyOnHold),
+		errors.Is(err, ErrHoldClosed), errors.Is(err, patch.ErrTestFailed):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": err.Error()})
+	case errors.Is(err, ErrLoanLimit), errors.Is(err, ErrRenewalLimit), errors.Is(err, ErrLoanOverdue),
+		errors.Is(err, ErrFinesOwed), errors.Is(err, ErrExceedsBalance):
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"message": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+}
@@ -0,0 +1,137 @@
+package circulation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/user"
+)
+
+type CopyCondition string
+
+const (
+	ConditionNew     CopyCondition = "new"
+	ConditionGood    CopyCondition = "good"
+	ConditionFair    CopyCondition = "fair"
+	ConditionPoor    CopyCondition = "poor"
+	ConditionDamaged CopyCondition = "damaged"
+)
+
+type CopyStatus string
+
+const (
+	StatusAvailable CopyStatus = "available"
+	StatusOnLoan    CopyStatus = "on_loan"
+	StatusOnHold    CopyStatus = "on_hold"
+	StatusLost      CopyStatus = "lost"
+	StatusWithdrawn CopyStatus = "withdrawn"
+)
+
+// Copy is one physical item of a title, identified on the shelf by its barcode
+type Copy struct {
+	ID        uuid.UUID     `gorm:"type:uuid; default:uuid_generate_v4()" json:"id"`
+	BookID    uuid.UUID     `gorm:"type:uuid;not null;index" json:"book_id"`
+	Barcode   string        `gorm:"size:64;not null;uniqueIndex" json:"barcode"`
+	Location  string        `gorm:"size:255" json:"location"`
+	Condition CopyCondition `gorm:"size:20;not null;default:'good'" json:"condition"`
+	Status    CopyStatus    `gorm:"size:20;not null;default:'available';index" json:"status"`
+	Book      *book.Book    `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE" json:"book,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+// Loan lends a copy to a borrower, the partial unique index keeps a copy on at most one open loan. Loans keep
+// their copy and their borrower from being deleted so the lending history stays intact, a deleted account is
+// anonymised instead.
+type Loan struct {
+	ID           uuid.UUID  `gorm:"type:uuid; default:uuid_generate_v4()" json:"id"`
+	CopyID       uuid.UUID  `gorm:"type:uuid;not null;index;uniqueIndex:idx_loans_open_copy,where:returned_at IS NULL" json:"copy_id"`
+	UserID       uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	CheckedOutBy uuid.UUID  `gorm:"type:uuid;not null" json:"checked_out_by"`
+	CheckedOutAt time.Time  `gorm:"not null" json:"checked_out_at"`
+	DueAt        time.Time  `gorm:"not null;index" json:"due_at"`
+	RenewalCount int        `gorm:"not null;default:0" json:"renewal_count"`
+	ReturnedAt   *time.Time `json:"returned_at"`
+	ReturnedBy   *uuid.UUID `gorm:"type:uuid" json:"returned_by,omitempty"`
+	// Reminder timestamps keep the overdue job from emailing the same notice twice
+	DueReminderAt     *time.Time `json:"-"`
+	OverdueReminderAt *time.Time `json:"-"`
+	Copy              Copy       `gorm:"foreignKey:CopyID;constraint:OnDelete:RESTRICT" json:"copy"`
+	Borrower          user.User  `gorm:"foreignKey:UserID;constraint:OnDelete:RESTRICT" json:"-"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+func (l Loan) IsOpen() bool {
+	return l.ReturnedAt == nil
+}
+
+func (l Loan) IsOverdue(now time.Time) bool {
+	return l.IsOpen() && now.After(l.DueAt)
+}
+
+type HoldStatus string
+
+const (
+	HoldWaiting   HoldStatus = "waiting"
+	HoldReady     HoldStatus = "ready"
+	HoldFulfilled HoldStatus = "fulfilled"
+	HoldCancelled HoldStatus = "cancelled"
+	HoldExpired   HoldStatus = "expired"
+)
+
+// Hold queues a user for the next free copy of a title, first come first served. A ready hold has a copy set
+// aside until PickupBy, after which it expires and the copy passes to the next hold in the queue.
+type Hold struct {
+	ID        uuid.UUID  `gorm:"type:uuid; default:uuid_generate_v4()" json:"id"`
+	BookID    uuid.UUID  `gorm:"type:uuid;not null;index:idx_holds_book_queue,priority:1;uniqueIndex:idx_holds_open_user,where:status IN ('waiting'\\,'ready')" json:"book_id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index;uniqueIndex:idx_holds_open_user" json:"user_id"`
+	Status    HoldStatus `gorm:"size:20;not null;default:'waiting';index:idx_holds_book_queue,priority:2" json:"status"`
+	CopyID    *uuid.UUID `gorm:"type:uuid;index" json:"copy_id,omitempty"`
+	Position  int64      `gorm:"->;-:migration" json:"position,omitempty"`
+	ReadyAt   *time.Time `json:"ready_at,omitempty"`
+	PickupBy  *time.Time `json:"pickup_by,omitempty"`
+	ClosedAt  *time.Time `json:"closed_at,omitempty"`
+	Book      *book.Book `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE" json:"book,omitempty"`
+	Copy      *Copy      `gorm:"foreignKey:CopyID;constraint:OnDelete:SET NULL" json:"copy,omitempty"`
+	Holder    user.User  `gorm:"foreignKey:UserID;constraint:OnDelete:RESTRICT" json:"-"`
+	CreatedAt time.Time  `gorm:"index:idx_holds_book_queue,priority:3" json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+func (h Hold) IsOpen() bool {
+	return h.Status == HoldWaiting || h.Status == HoldReady
+}
+
+type FineKind string
+
+const (
+	FineAccrued FineKind = "fine"
+	FinePayment FineKind = "payment"
+	FineWaiver  FineKind = "waiver"
+)
+
+// FineEntry is one line of a borrower's fines ledger. Fines are positive, payments and waivers negative, so the
+// balance is the sum of the amounts. Like loans the entries keep their borrower from being deleted.
+type FineEntry struct {
+	ID          uuid.UUID  `gorm:"type:uuid; default:uuid_generate_v4()" json:"id"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	LoanID      *uuid.UUID `gorm:"type:uuid;index" json:"loan_id,omitempty"`
+	Kind        FineKind   `gorm:"size:20;not null" json:"kind"`
+	AmountCents int64      `gorm:"not null" json:"amount_cents"`
+	Note        string     `gorm:"size:255" json:"note,omitempty"`
+	RecordedBy  *uuid.UUID `gorm:"type:uuid" json:"recorded_by,omitempty"`
+	Loan        *Loan      `gorm:"foreignKey:LoanID;constraint:OnDelete:SET NULL" json:"-"`
+	Borrower    user.User  `gorm:"foreignKey:UserID;constraint:OnDelete:RESTRICT" json:"-"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// FineBalance is what a borrower currently owes
+type FineBalance struct {
+	UserID       uuid.UUID `json:"user_id"`
+	FirstName    string    `json:"first_name"`
+	LastName     string    `json:"last_name"`
+	Email        string    `json:"email"`
+	BalanceCents int64     `json:"balance_cents"`
+}
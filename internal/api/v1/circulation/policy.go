@@ -0,0 +1,86 @@
+package circulation
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/user"
+)
+
+var (
+	ErrInvalidCopy     = errors.New("invalid copy")
+	ErrCopyUnavailable = errors.New("copy is not available for checkout")
+	ErrCopyOnLoan      = errors.New("copy is on loan")
+	ErrCopyHasLoans    = errors.New("copy has loan history, withdraw it instead")
+	ErrCopyOnHold      = errors.New("copy is set aside for a hold")
+	ErrCopyAvailable   = errors.New("a copy is available, no hold is needed")
+	ErrAlreadyOnHold   = errors.New("user already has a hold on this book")
+	ErrHoldClosed      = errors.New("hold is no longer active")
+	ErrNotHolder       = errors.New("only the holder or staff can manage this hold")
+	ErrNotOnLoan       = errors.New("copy is not on loan")
+	ErrLoanLimit       = errors.New("borrower has reached the loan limit")
+	ErrRenewalLimit    = errors.New("loan has reached the renewal limit")
+	ErrLoanOverdue     = errors.New("overdue loans cannot be renewed")
+	ErrLoanClosed      = errors.New("loan has already been returned")
+	ErrLoanChanged     = errors.New("loan was changed by another request")
+	ErrNotBorrower     = errors.New("only the borrower or staff can renew this loan")
+	ErrFinesOwed       = errors.New("borrower owes fines above the checkout limit")
+	ErrInvalidFine     = errors.New("invalid fine entry")
+	ErrExceedsBalance  = errors.New("amount is more than the borrower owes")
+
+	// errHoldReassigned makes a hold write start over, a copy was set aside for the hold while it ran
+	errHoldReassigned = errors.New("hold was assigned a copy concurrently")
+)
+
+// LoanPolicy is the set of lending rules that applies to a borrower
+type LoanPolicy struct {
+	LoanDays    int
+	MaxRenewals int
+	MaxLoans    int
+}
+
+// PolicyFor picks the lending rules of the borrower's role, unknown roles get the user rules
+func PolicyFor(cfg *config.Circulation, role user.UserRole) LoanPolicy {
+	switch role {
+	case user.RoleAdmin:
+		return LoanPolicy{LoanDays: cfg.AdminLoanDays, MaxRenewals: cfg.AdminMaxRenewals, MaxLoans: cfg.AdminMaxLoans}
+	case user.RoleModerator:
+		return LoanPolicy{LoanDays: cfg.ModeratorLoanDays, MaxRenewals: cfg.ModeratorMaxRenewals, MaxLoans: cfg.ModeratorMaxLoans}
+	}
+	return LoanPolicy{LoanDays: cfg.UserLoanDays, MaxRenewals: cfg.UserMaxRenewals, MaxLoans: cfg.UserMaxLoans}
+}
+
+// DueDate is the end of the loan period counted from the given time
+func (p LoanPolicy) DueDate(from time.Time) time.Time {
+	return from.AddDate(0, 0, p.LoanDays)
+}
+
+// Renew checks the loan against the policy and returns its new due date. The due date never moves backwards.
+func (p LoanPolicy) Renew(loan Loan, now time.Time) (time.Time, error) {
+	switch {
+	case !loan.IsOpen():
+		return time.Time{}, ErrLoanClosed
+	case loan.IsOverdue(now):
+		return time.Time{}, ErrLoanOverdue
+	case loan.RenewalCount >= p.MaxRenewals:
+		return time.Time{}, ErrRenewalLimit
+	}
+
+	dueAt := p.DueDate(now)
+	if dueAt.Before(loan.DueAt) {
+		dueAt = loan.DueAt
+	}
+	return dueAt, nil
+}
+
+// FineFor is the total fine of a loan due at dueAt and returned, or still out, at until. Every started day late
+// costs the daily rate, up to the cap.
+func FineFor(cfg *config.Circulation, dueAt, until time.Time) int64 {
+	if !until.After(dueAt) {
+		return 0
+	}
+	daysLate := int64(math.Ceil(until.Sub(dueAt).Hours() / 24))
+	return min(daysLate*cfg.FineDailyCents, cfg.FineCapCents)
+}
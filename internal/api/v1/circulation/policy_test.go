@@ -0,0 +1,90 @@
+package circulation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/circulation"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/user"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type PolicySuite struct {
+	suite.Suite
+	cfg *config.Circulation
+	now time.Time
+}
+
+func (s *PolicySuite) SetupTest() {
+	s.cfg = &config.Circulation{
+		UserLoanDays: 14, UserMaxRenewals: 1, UserMaxLoans: 5,
+		ModeratorLoanDays: 28, ModeratorMaxRenewals: 3, ModeratorMaxLoans: 10,
+		AdminLoanDays: 28, AdminMaxRenewals: 3, AdminMaxLoans: 10,
+	}
+	s.now = time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+}
+
+func TestPolicySuite(t *testing.T) {
+	suite.Run(t, new(PolicySuite))
+}
+
+func (s *PolicySuite) TestPolicyFor1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Policy Per Role", 34)
+
+	s.Equal(circulation.LoanPolicy{LoanDays: 14, MaxRenewals: 1, MaxLoans: 5}, circulation.PolicyFor(s.cfg, user.RoleUser))
+	s.Equal(circulation.LoanPolicy{LoanDays: 28, MaxRenewals: 3, MaxLoans: 10}, circulation.PolicyFor(s.cfg, user.RoleModerator))
+	s.Equal(circulation.LoanPolicy{LoanDays: 14, MaxRenewals: 1, MaxLoans: 5}, circulation.PolicyFor(s.cfg, "guest"))
+}
+
+func (s *PolicySuite) TestRenew1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Renew Open Loan", 34)
+
+	loan := circulation.Loan{DueAt: s.now.AddDate(0, 0, 2)}
+	dueAt, err := circulation.PolicyFor(s.cfg, user.RoleUser).Renew(loan, s.now)
+
+	s.NoError(err)
+	s.Equal(s.now.AddDate(0, 0, 14), dueAt)
+}
+
+func (s *PolicySuite) TestRenew2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Renew Past The Limit, Overdue Or Returned", 31)
+
+	policy := circulation.PolicyFor(s.cfg, user.RoleUser)
+	returnedAt := s.now
+
+	_, err := policy.Renew(circulation.Loan{DueAt: s.now.AddDate(0, 0, 2), RenewalCount: 1}, s.now)
+	s.ErrorIs(err, circulation.ErrRenewalLimit)
+
+	_, err = policy.Renew(circulation.Loan{DueAt: s.now.AddDate(0, 0, -1)}, s.now)
+	s.ErrorIs(err, circulation.ErrLoanOverdue)
+
+	_, err = policy.Renew(circulation.Loan{DueAt: s.now, ReturnedAt: &returnedAt}, s.now)
+	s.ErrorIs(err, circulation.ErrLoanClosed)
+}
+
+func (s *PolicySuite) TestFineFor1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Fine Per Started Day Late", 34)
+
+	s.cfg.FineDailyCents, s.cfg.FineCapCents = 25, 1000
+	dueAt := s.now
+
+	s.Equal(int64(0), circulation.FineFor(s.cfg, dueAt, dueAt))
+	s.Equal(int64(25), circulation.FineFor(s.cfg, dueAt, dueAt.Add(time.Hour)))
+	s.Equal(int64(75), circulation.FineFor(s.cfg, dueAt, dueAt.AddDate(0, 0, 3)))
+}
+
+func (s *PolicySuite) TestFineFor2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Fine Capped Or Returned Early", 31)
+
+	s.cfg.FineDailyCents, s.cfg.FineCapCents = 25, 1000
+
+	s.Equal(int64(1000), circulation.FineFor(s.cfg, s.now, s.now.AddDate(0, 2, 0)))
+	s.Equal(int64(0), circulation.FineFor(s.cfg, s.now, s.now.AddDate(0, 0, -1)))
+}
@@ -0,0 +1,649 @@
+package circulation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/user"
+	"github.com/jerpsp/go-fiber-beginner/pkg/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CirculationRepository interface {
+	FindBookCopies(c *fiber.Ctx, bookID uuid.UUID) ([]Copy, error)
+	FindCopyByID(c *fiber.Ctx, copyID uuid.UUID) (Copy, error)
+	FindCopyByBarcode(c *fiber.Ctx, barcode string) (Copy, error)
+	CreateCopy(c *fiber.Ctx, newCopy Copy) (Copy, error)
+	UpdateCopyFields(c *fiber.Ctx, copyID uuid.UUID, fields map[string]interface{}) error
+	DeleteCopy(c *fiber.Ctx, copyID uuid.UUID) error
+	CheckoutCopy(c *fiber.Ctx, newLoan Loan, maxLoans int, fineBlockCents int64) (Loan, error)
+	ReturnCopy(c *fiber.Ctx, copyID, staffID uuid.UUID, pickupBy time.Time) (Loan, *Hold, error)
+	FindLoanByID(c *fiber.Ctx, loanID uuid.UUID) (Loan, error)
+	RenewLoan(c *fiber.Ctx, loan Loan, dueAt time.Time) error
+	FindUserLoans(c *fiber.Ctx, userID uuid.UUID, history bool) ([]Loan, error)
+	FindHoldByID(c *fiber.Ctx, holdID uuid.UUID) (Hold, error)
+	FindUserHolds(c *fiber.Ctx, userID uuid.UUID) ([]Hold, error)
+	FindBookHolds(c *fiber.Ctx, bookID uuid.UUID) ([]Hold, error)
+	CreateHold(c *fiber.Ctx, newHold Hold) (Hold, error)
+	CancelHold(c *fiber.Ctx, holdID uuid.UUID, pickupBy time.Time) (*Hold, error)
+	AssignHold(c *fiber.Ctx, copyID uuid.UUID, pickupBy time.Time) (*Hold, error)
+	FindExpiredHolds(c *fiber.Ctx, now time.Time) ([]uuid.UUID, error)
+	ExpireHold(c *fiber.Ctx, holdID uuid.UUID, now, pickupBy time.Time) (*Hold, error)
+	FindLoansDueBetween(c *fiber.Ctx, from, to time.Time) ([]Loan, error)
+	FindOverdueLoans(c *fiber.Ctx, now time.Time) ([]Loan, error)
+	UpdateLoanFields(c *fiber.Ctx, loanID uuid.UUID, fields map[string]interface{}) error
+	AccrueFine(c *fiber.Ctx, loan Loan, totalCents int64) (int64, error)
+	FindUserBalance(c *fiber.Ctx, userID uuid.UUID) (int64, error)
+	FindUserFines(c *fiber.Ctx, userID uuid.UUID) ([]FineEntry, error)
+	FindBalances(c *fiber.Ctx) ([]FineBalance, error)
+	CreditFines(c *fiber.Ctx, entry FineEntry) error
+}
+
+type circulationRepository struct {
+	config *config.Config
+	db     *database.GormDB
+}
+
+func NewCirculationRepository(cfg *config.Config, db *database.GormDB) CirculationRepository {
+	return &circulationRepository{config: cfg, db: db}
+}
+
+// Repository methods
+func (r *circulationRepository) FindBookCopies(c *fiber.Ctx, bookID uuid.UUID) ([]Copy, error) {
+	var copies []Copy
+	if err := r.db.DB.Where("book_id = ?", bookID).Order("barcode ASC").Find(&copies).Error; err != nil {
+		return nil, err
+	}
+	return copies, nil
+}
+
+func (r *circulationRepository) FindCopyByID(c *fiber.Ctx, copyID uuid.UUID) (Copy, error) {
+	var bookCopy Copy
+	if err := r.db.DB.Preload("Book").Where("id = ?", copyID).First(&bookCopy).Error; err != nil {
+		return Copy{}, err
+	}
+	return bookCopy, nil
+}
+
+func (r *circulationRepository) FindCopyByBarcode(c *fiber.Ctx, barcode string) (Copy, error) {
+	var bookCopy Copy
+	if err := r.db.DB.Where("barcode = ?", barcode).First(&bookCopy).Error; err != nil {
+		return Copy{}, err
+	}
+	return bookCopy, nil
+}
+
+func (r *circulationRepository) CreateCopy(c *fiber.Ctx, newCopy Copy) (Copy, error) {
+	err := r.db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Select("id").Where("id = ?", newCopy.BookID).First(&book.Book{}).Error; err != nil {
+			return err
+		}
+		return tx.Omit(clause.Associations).Create(&newCopy).Error
+	})
+	if err != nil {
+		return Copy{}, err
+	}
+	return newCopy, nil
+}
+
+func (r *circulationRepository) UpdateCopyFields(c *fiber.Ctx, copyID uuid.UUID, fields map[string]interface{}) error {
+	result := r.db.DB.Model(&Copy{}).Where("id = ?", copyID).Updates(fields)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteCopy only removes copies that were never lent, anything else should be withdrawn
+func (r *circulationRepository) DeleteCopy(c *fiber.Ctx, copyID uuid.UUID) error {
+	return r.db.DB.Transaction(func(tx *gorm.DB) error {
+		if _, err := lockCopy(tx, copyID); err != nil {
+			return err
+		}
+
+		var loans int64
+		if err := tx.Model(&Loan{}).Where("copy_id = ?", copyID).Count(&loans).Error; err != nil {
+			return err
+		}
+		if loans > 0 {
+			return ErrCopyHasLoans
+		}
+		return tx.Delete(&Copy{}, "id = ?", copyID).Error
+	})
+}
+
+// CheckoutCopy lends the copy under row locks on the copy and the borrower, so the same copy can't go out twice
+// and concurrent checkouts can't push a borrower over maxLoans. The borrower lock is the one fines are recorded
+// under, so the balance can't grow past fineBlockCents between the check and the loan. A copy set aside for a
+// hold only goes to its holder.
+func (r *circulationRepository) CheckoutCopy(c *fiber.Ctx, newLoan Loan, maxLoans int, fineBlockCents int64) (Loan, error) {
+	err := r.db.DB.Transaction(func(tx *gorm.DB) error {
+		bookCopy, err := lockCopy(tx, newLoan.CopyID)
+		if err != nil {
+			return err
+		}
+		if bookCopy.Status == StatusOnHold {
+			if err := fulfilHold(tx, bookCopy, newLoan.UserID); err != nil {
+				return err
+			}
+		} else if bookCopy.Status != StatusAvailable {
+			return ErrCopyUnavailable
+		}
+
+		var borrower user.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", newLoan.UserID).First(&borrower).Error; err != nil {
+			return err
+		}
+
+		balance, err := userBalance(tx, newLoan.UserID)
+		if err != nil {
+			return err
+		}
+		if balance > fineBlockCents {
+			return ErrFinesOwed
+		}
+
+		var openLoans int64
+		if err := tx.Model(&Loan{}).Where("user_id = ? AND returned_at IS NULL", newLoan.UserID).Count(&openLoans).Error; err != nil {
+			return err
+		}
+		if int(openLoans) >= maxLoans {
+			return ErrLoanLimit
+		}
+
+		if err := tx.Omit(clause.Associations).Create(&newLoan).Error; err != nil {
+			return err
+		}
+		return tx.Model(&Copy{}).Where("id = ?", newLoan.CopyID).Update("status", StatusOnLoan).Error
+	})
+	if err != nil {
+		return Loan{}, err
+	}
+	return newLoan, nil
+}
+
+// ReturnCopy closes the open loan of the copy, records its fine and passes the copy to the next hold in the
+// queue, or back on the shelf when nobody is waiting. The fine is recorded in the same transaction, so a loan is
+// never closed without it.
+func (r *circulationRepository) ReturnCopy(c *fiber.Ctx, copyID, staffID uuid.UUID, pickupBy time.Time) (Loan, *Hold, error) {
+	var loan Loan
+	var assigned *Hold
+	err := r.db.DB.Transaction(func(tx *gorm.DB) error {
+		bookCopy, err := lockCopy(tx, copyID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("copy_id = ? AND returned_at IS NULL", copyID).First(&loan).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrNotOnLoan
+			}
+			return err
+		}
+
+		now := time.Now()
+		loan.ReturnedAt = &now
+		loan.ReturnedBy = &staffID
+		if err := tx.Model(&Loan{}).Where("id = ?", loan.ID).
+			Updates(map[string]interface{}{"returned_at": now, "returned_by": staffID}).Error; err != nil {
+			return err
+		}
+		if fine := FineFor(r.config.Circulation, loan.DueAt, now); fine > 0 {
+			if _, err := accrueFine(tx, loan, fine); err != nil {
+				return err
+			}
+		}
+
+		assigned, err = releaseCopy(tx, bookCopy, pickupBy)
+		return err
+	})
+	if err != nil {
+		return Loan{}, nil, err
+	}
+	return loan, assigned, nil
+}
+
+func (r *circulationRepository) FindLoanByID(c *fiber.Ctx, loanID uuid.UUID) (Loan, error) {
+	var loan Loan
+	if err := r.db.DB.Preload("Copy.Book").Where("id = ?", loanID).First(&loan).Error; err != nil {
+		return Loan{}, err
+	}
+	return loan, nil
+}
+
+// RenewLoan moves the due date of a loan that is still open and has not been renewed since it was read
+func (r *circulationRepository) RenewLoan(c *fiber.Ctx, loan Loan, dueAt time.Time) error {
+	result := r.db.DB.Model(&Loan{}).
+		Where("id = ? AND returned_at IS NULL AND renewal_count = ?", loan.ID, loan.RenewalCount).
+		Updates(map[string]interface{}{"due_at": dueAt, "renewal_count": loan.RenewalCount + 1})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrLoanChanged
+	}
+	return nil
+}
+
+// FindUserLoans returns the open loans of the user by due date, or every loan newest first with history
+func (r *circulationRepository) FindUserLoans(c *fiber.Ctx, userID uuid.UUID, history bool) ([]Loan, error) {
+	var loans []Loan
+	query := r.db.DB.Preload("Copy.Book").Where("user_id = ?", userID)
+	if history {
+		query = query.Order("checked_out_at DESC")
+	} else {
+		query = query.Where("returned_at IS NULL").Order("due_at ASC")
+	}
+	if err := query.Find(&loans).Error; err != nil {
+		return nil, err
+	}
+	return loans, nil
+}
+
+func (r *circulationRepository) FindHoldByID(c *fiber.Ctx, holdID uuid.UUID) (Hold, error) {
+	var hold Hold
+	if err := withQueuePosition(r.db.DB).Preload("Book").Preload("Copy").Where("holds.id = ?", holdID).First(&hold).Error; err != nil {
+		return Hold{}, err
+	}
+	return hold, nil
+}
+
+// FindUserHolds returns the open holds of the user, ready holds first
+func (r *circulationRepository) FindUserHolds(c *fiber.Ctx, userID uuid.UUID) ([]Hold, error) {
+	var holds []Hold
+	err := withQueuePosition(r.db.DB).Preload("Book").Preload("Copy").
+		Where("holds.user_id = ? AND holds.status IN ?", userID, []HoldStatus{HoldWaiting, HoldReady}).
+		Order("holds.status = 'ready' DESC").Order("holds.created_at ASC").
+		Find(&holds).Error
+	if err != nil {
+		return nil, err
+	}
+	return holds, nil
+}
+
+// FindBookHolds returns the open holds of a book in queue order
+func (r *circulationRepository) FindBookHolds(c *fiber.Ctx, bookID uuid.UUID) ([]Hold, error) {
+	var holds []Hold
+	err := withQueuePosition(r.db.DB).Preload("Copy").
+		Where("holds.book_id = ? AND holds.status IN ?", bookID, []HoldStatus{HoldWaiting, HoldReady}).
+		Order("holds.status = 'ready' DESC").Order("holds.created_at ASC").
+		Find(&holds).Error
+	if err != nil {
+		return nil, err
+	}
+	return holds, nil
+}
+
+// CreateHold queues the user for the book, holds are only taken while no copy is on the shelf. The copies of the
+// book are locked for the check, a return holds the lock on its copy while it looks for a hold, so a copy can't
+// go back on the shelf while a hold is being placed for it.
+func (r *circulationRepository) CreateHold(c *fiber.Ctx, newHold Hold) (Hold, error) {
+	err := r.db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Select("id").Where("id = ?", newHold.BookID).First(&book.Book{}).Error; err != nil {
+			return err
+		}
+
+		var copies []Copy
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("book_id = ?", newHold.BookID).Order("id").
+			Find(&copies).Error; err != nil {
+			return err
+		}
+		for _, bookCopy := range copies {
+			if bookCopy.Status == StatusAvailable {
+				return ErrCopyAvailable
+			}
+		}
+
+		var open int64
+		if err := tx.Model(&Hold{}).Where("book_id = ? AND user_id = ? AND status IN ?", newHold.BookID, newHold.UserID, []HoldStatus{HoldWaiting, HoldReady}).
+			Count(&open).Error; err != nil {
+			return err
+		}
+		if open > 0 {
+			return ErrAlreadyOnHold
+		}
+		return tx.Omit(clause.Associations).Create(&newHold).Error
+	})
+	if err != nil {
+		return Hold{}, err
+	}
+	return newHold, nil
+}
+
+// CancelHold closes an open hold, a copy that was set aside for it moves on to the next hold
+func (r *circulationRepository) CancelHold(c *fiber.Ctx, holdID uuid.UUID, pickupBy time.Time) (*Hold, error) {
+	for {
+		var assigned *Hold
+		err := r.db.DB.Transaction(func(tx *gorm.DB) error {
+			hold, bookCopy, err := lockHoldAndCopy(tx, holdID)
+			if err != nil {
+				return err
+			}
+			if _, err := closeHold(tx, holdID, HoldCancelled, time.Now()); err != nil {
+				return err
+			}
+			if hold.Status != HoldReady || bookCopy == nil {
+				return nil
+			}
+			assigned, err = releaseCopy(tx, *bookCopy, pickupBy)
+			return err
+		})
+		if !errors.Is(err, errHoldReassigned) {
+			return assigned, err
+		}
+	}
+}
+
+// AssignHold sets an available copy aside for the next hold on its book, if there is one
+func (r *circulationRepository) AssignHold(c *fiber.Ctx, copyID uuid.UUID, pickupBy time.Time) (*Hold, error) {
+	var assigned *Hold
+	err := r.db.DB.Transaction(func(tx *gorm.DB) error {
+		bookCopy, err := lockCopy(tx, copyID)
+		if err != nil {
+			return err
+		}
+		if bookCopy.Status != StatusAvailable {
+			return nil
+		}
+		assigned, err = releaseCopy(tx, bookCopy, pickupBy)
+		return err
+	})
+	return assigned, err
+}
+
+// FindExpiredHolds lists ready holds whose pickup deadline has passed
+func (r *circulationRepository) FindExpiredHolds(c *fiber.Ctx, now time.Time) ([]uuid.UUID, error) {
+	var holdIDs []uuid.UUID
+	err := r.db.DB.Model(&Hold{}).Where("status = ? AND pickup_by < ?", HoldReady, now).
+		Order("pickup_by ASC").Pluck("id", &holdIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return holdIDs, nil
+}
+
+// ExpireHold closes a ready hold that was not picked up in time and rolls its copy over to the next hold
+func (r *circulationRepository) ExpireHold(c *fiber.Ctx, holdID uuid.UUID, now, pickupBy time.Time) (*Hold, error) {
+	for {
+		var assigned *Hold
+		err := r.db.DB.Transaction(func(tx *gorm.DB) error {
+			hold, bookCopy, err := lockHoldAndCopy(tx, holdID)
+			if err != nil {
+				return err
+			}
+			if hold.Status != HoldReady || hold.PickupBy == nil || !hold.PickupBy.Before(now) {
+				return nil
+			}
+			if _, err := closeHold(tx, holdID, HoldExpired, now); err != nil {
+				return err
+			}
+			if bookCopy == nil {
+				return nil
+			}
+			assigned, err = releaseCopy(tx, *bookCopy, pickupBy)
+			return err
+		})
+		if !errors.Is(err, errHoldReassigned) {
+			return assigned, err
+		}
+	}
+}
+
+// FindLoansDueBetween returns open loans falling due in the window that have not had a due reminder yet
+func (r *circulationRepository) FindLoansDueBetween(c *fiber.Ctx, from, to time.Time) ([]Loan, error) {
+	var loans []Loan
+	err := r.db.DB.Preload("Copy.Book").
+		Where("returned_at IS NULL AND due_reminder_at IS NULL AND due_at >= ? AND due_at < ?", from, to).
+		Order("due_at ASC").Find(&loans).Error
+	if err != nil {
+		return nil, err
+	}
+	return loans, nil
+}
+
+func (r *circulationRepository) FindOverdueLoans(c *fiber.Ctx, now time.Time) ([]Loan, error) {
+	var loans []Loan
+	if err := r.db.DB.Preload("Copy.Book").Where("returned_at IS NULL AND due_at < ?", now).Order("due_at ASC").Find(&loans).Error; err != nil {
+		return nil, err
+	}
+	return loans, nil
+}
+
+func (r *circulationRepository) UpdateLoanFields(c *fiber.Ctx, loanID uuid.UUID, fields map[string]interface{}) error {
+	result := r.db.DB.Model(&Loan{}).Where("id = ?", loanID).Updates(fields)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// AccrueFine tops the fines of a loan up to totalCents and returns the amount added. Running it again for the
+// same total adds nothing.
+func (r *circulationRepository) AccrueFine(c *fiber.Ctx, loan Loan, totalCents int64) (int64, error) {
+	var added int64
+	err := r.db.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		added, err = accrueFine(tx, loan, totalCents)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return added, nil
+}
+
+func (r *circulationRepository) FindUserBalance(c *fiber.Ctx, userID uuid.UUID) (int64, error) {
+	return userBalance(r.db.DB, userID)
+}
+
+func (r *circulationRepository) FindUserFines(c *fiber.Ctx, userID uuid.UUID) ([]FineEntry, error) {
+	var entries []FineEntry
+	if err := r.db.DB.Where("user_id = ?", userID).Order("created_at DESC").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// FindBalances lists every borrower who owes something, largest balance first
+func (r *circulationRepository) FindBalances(c *fiber.Ctx) ([]FineBalance, error) {
+	var balances []FineBalance
+	err := r.db.DB.Model(&FineEntry{}).
+		Select("fine_entries.user_id, users.first_name, users.last_name, users.email, SUM(fine_entries.amount_cents) AS balance_cents").
+		Joins("JOIN users ON users.id = fine_entries.user_id").
+		Group("fine_entries.user_id, users.first_name, users.last_name, users.email").
+		Having("SUM(fine_entries.amount_cents) > 0").
+		Order("balance_cents DESC").
+		Scan(&balances).Error
+	if err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+// CreditFines records a payment or waiver under a lock on the borrower, so two credits can't both take the
+// balance below zero
+func (r *circulationRepository) CreditFines(c *fiber.Ctx, entry FineEntry) error {
+	return r.db.DB.Transaction(func(tx *gorm.DB) error {
+		var borrower user.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", entry.UserID).First(&borrower).Error; err != nil {
+			return err
+		}
+
+		balance, err := userBalance(tx, entry.UserID)
+		if err != nil {
+			return err
+		}
+		if -entry.AmountCents > balance {
+			return ErrExceedsBalance
+		}
+		return tx.Omit(clause.Associations).Create(&entry).Error
+	})
+}
+
+func userBalance(db *gorm.DB, userID uuid.UUID) (int64, error) {
+	var balance int64
+	err := db.Model(&FineEntry{}).Where("user_id = ?", userID).Select("COALESCE(SUM(amount_cents), 0)").Scan(&balance).Error
+	return balance, err
+}
+
+// accrueFine tops the fines of a loan up to totalCents in the caller's transaction. The loan is locked so the
+// overdue job and a return can't both add the same fine.
+func accrueFine(tx *gorm.DB, loan Loan, totalCents int64) (int64, error) {
+	var locked Loan
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", loan.ID).First(&locked).Error; err != nil {
+		return 0, err
+	}
+
+	var accrued int64
+	if err := tx.Model(&FineEntry{}).Where("loan_id = ? AND kind = ?", loan.ID, FineAccrued).
+		Select("COALESCE(SUM(amount_cents), 0)").Scan(&accrued).Error; err != nil {
+		return 0, err
+	}
+	if totalCents <= accrued {
+		return 0, nil
+	}
+
+	entry := FineEntry{UserID: loan.UserID, LoanID: &loan.ID, Kind: FineAccrued, AmountCents: totalCents - accrued}
+	if err := tx.Omit(clause.Associations).Create(&entry).Error; err != nil {
+		return 0, err
+	}
+	return entry.AmountCents, nil
+}
+
+// lockHoldAndCopy locks the copy set aside for a hold before the hold itself, the order CheckoutCopy takes them
+// in, so cancelling or expiring a hold can't deadlock with the checkout of its copy. errHoldReassigned means a
+// copy was set aside for the hold between the two reads, the caller retries the transaction.
+func lockHoldAndCopy(tx *gorm.DB, holdID uuid.UUID) (Hold, *Copy, error) {
+	var hold Hold
+	if err := tx.Where("id = ?", holdID).First(&hold).Error; err != nil {
+		return Hold{}, nil, err
+	}
+
+	var bookCopy *Copy
+	if hold.CopyID != nil {
+		locked, err := lockCopy(tx, *hold.CopyID)
+		if err != nil {
+			return Hold{}, nil, err
+		}
+		bookCopy = &locked
+	}
+
+	var locked Hold
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", holdID).First(&locked).Error; err != nil {
+		return Hold{}, nil, err
+	}
+	if locked.CopyID != nil && (hold.CopyID == nil || *locked.CopyID != *hold.CopyID) {
+		return Hold{}, nil, errHoldReassigned
+	}
+	return locked, bookCopy, nil
+}
+
+func lockCopy(tx *gorm.DB, copyID uuid.UUID) (Copy, error) {
+	var locked Copy
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", copyID).First(&locked).Error
+	return locked, err
+}
+
+// releaseCopy hands a copy that just came free to the oldest waiting hold on its book. Without a hold the copy
+// goes back on the shelf, unless staff took it out of circulation in the meantime.
+func releaseCopy(tx *gorm.DB, bookCopy Copy, pickupBy time.Time) (*Hold, error) {
+	if bookCopy.Status == StatusLost || bookCopy.Status == StatusWithdrawn {
+		return nil, nil
+	}
+
+	next, err := nextWaitingHold(tx, bookCopy.BookID)
+	if err != nil {
+		return nil, err
+	}
+	if next == nil {
+		return nil, tx.Model(&Copy{}).Where("id = ?", bookCopy.ID).Update("status", StatusAvailable).Error
+	}
+
+	now := time.Now()
+	next.Status = HoldReady
+	next.CopyID = &bookCopy.ID
+	next.ReadyAt = &now
+	next.PickupBy = &pickupBy
+	if err := tx.Model(&Hold{}).Where("id = ?", next.ID).Updates(map[string]interface{}{
+		"status": HoldReady, "copy_id": bookCopy.ID, "ready_at": now, "pickup_by": pickupBy,
+	}).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Model(&Copy{}).Where("id = ?", bookCopy.ID).Update("status", StatusOnHold).Error; err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+// nextWaitingHold locks the oldest hold on the book that is still waiting. A hold locked by another transaction
+// is waited for rather than skipped, and when it was cancelled or handed another copy meanwhile the next one in
+// the queue is tried. The holds are locked one by one, a single locking query with a limit would come back empty
+// once the row it waited for no longer matched.
+func nextWaitingHold(tx *gorm.DB, bookID uuid.UUID) (*Hold, error) {
+	var holdIDs []uuid.UUID
+	if err := tx.Model(&Hold{}).Where("book_id = ? AND status = ?", bookID, HoldWaiting).
+		Order("created_at ASC").Pluck("id", &holdIDs).Error; err != nil {
+		return nil, err
+	}
+
+	for _, holdID := range holdIDs {
+		var next Hold
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND status = ?", holdID, HoldWaiting).First(&next).Error
+		if err == gorm.ErrRecordNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &next, nil
+	}
+	return nil, nil
+}
+
+// fulfilHold closes the ready hold a copy was set aside for, the copy can only be lent to the holder
+func fulfilHold(tx *gorm.DB, bookCopy Copy, borrowerID uuid.UUID) error {
+	var hold Hold
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("copy_id = ? AND status = ?", bookCopy.ID, HoldReady).First(&hold).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrCopyUnavailable
+		}
+		return err
+	}
+	if hold.UserID != borrowerID {
+		return ErrCopyOnHold
+	}
+	_, err := closeHold(tx, hold.ID, HoldFulfilled, time.Now())
+	return err
+}
+
+// closeHold moves an open hold to a final status and returns the hold as it was before
+func closeHold(tx *gorm.DB, holdID uuid.UUID, status HoldStatus, now time.Time) (Hold, error) {
+	var hold Hold
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", holdID).First(&hold).Error; err != nil {
+		return Hold{}, err
+	}
+	if !hold.IsOpen() {
+		return Hold{}, ErrHoldClosed
+	}
+	err := tx.Model(&Hold{}).Where("id = ?", holdID).Updates(map[string]interface{}{"status": status, "closed_at": now}).Error
+	return hold, err
+}
+
+// withQueuePosition selects holds with their place among the waiting holds of the same book, ready holds are 0
+func withQueuePosition(db *gorm.DB) *gorm.DB {
+	return db.Model(&Hold{}).Select(`holds.*, CASE WHEN holds.status = 'waiting' THEN
+		(SELECT COUNT(*) FROM holds AS ahead WHERE ahead.book_id = holds.book_id AND ahead.status = 'waiting' AND ahead.created_at <= holds.created_at)
+		ELSE 0 END AS position`)
+}
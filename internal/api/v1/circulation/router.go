@@ -0,0 +1,45 @@
+package circulation
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/middleware"
+)
+
+func RegisterRoutes(cfg *config.Config, router fiber.Router, handler *CirculationHandler) {
+	// Copies of a title - anyone can check availability, staff manage the inventory
+	router.Get("/books/:id/copies", handler.GetBookCopies)
+	router.Post("/books/:id/copies", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.CreateCopy)
+
+	// Holds queue - any authenticated user can join, staff can see the whole queue
+	router.Post("/books/:id/holds", middleware.JWTMiddleware(cfg), handler.PlaceHold)
+	router.Get("/books/:id/holds", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.GetBookHolds)
+
+	copyGroup := router.Group("/copies")
+	{
+		// Public routes - anyone can access
+		copyGroup.Get("/:id", handler.GetCopy)
+
+		// Moderator or Admin routes - staff run the lending desk
+		copyGroup.Patch("/:id", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.UpdateCopy)
+		copyGroup.Post("/:id/checkout", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.CheckoutCopy)
+		copyGroup.Post("/:id/return", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.ReturnCopy)
+
+		// Admin only routes - only admins can delete copies
+		copyGroup.Delete("/:id", middleware.JWTMiddleware(cfg), middleware.AdminOnly(), handler.DeleteCopy)
+	}
+
+	// User authenticated routes - borrowers manage their own loans and holds, ownership is checked by the service
+	router.Post("/loans/:id/renew", middleware.JWTMiddleware(cfg), handler.RenewLoan)
+	router.Get("/holds/:id", middleware.JWTMiddleware(cfg), handler.GetHold)
+	router.Delete("/holds/:id", middleware.JWTMiddleware(cfg), handler.CancelHold)
+	router.Get("/users/me/loans", middleware.JWTMiddleware(cfg), handler.GetMyLoans)
+	router.Get("/users/me/holds", middleware.JWTMiddleware(cfg), handler.GetMyHolds)
+	router.Get("/users/me/fines", middleware.JWTMiddleware(cfg), handler.GetMyFines)
+
+	// Moderator or Admin routes - staff keep the fines ledger
+	router.Get("/fines/balances", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.GetBalances)
+	router.Get("/users/:id/fines", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.GetUserFines)
+	router.Post("/users/:id/fines/payments", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.RecordPayment)
+	router.Post("/users/:id/fines/waivers", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.WaiveFines)
+}
@@ -0,0 +1,448 @@
+package circulation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/user"
+	"github.com/jerpsp/go-fiber-beginner/pkg/activity"
+	"github.com/jerpsp/go-fiber-beginner/pkg/email"
+	"github.com/jerpsp/go-fiber-beginner/pkg/patch"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// Setup
+type CirculationService interface {
+	GetBookCopies(c *fiber.Ctx, bookID uuid.UUID) ([]Copy, error)
+	GetCopy(c *fiber.Ctx, copyID uuid.UUID) (Copy, error)
+	CreateCopy(c *fiber.Ctx, bookID uuid.UUID, copyParams CopyRequest) (Copy, error)
+	UpdateCopy(c *fiber.Ctx, copyID uuid.UUID, contentType string, body []byte) (Copy, error)
+	DeleteCopy(c *fiber.Ctx, copyID uuid.UUID) error
+	CheckoutCopy(c *fiber.Ctx, copyID, staffID uuid.UUID, checkoutParams CheckoutRequest) (Loan, error)
+	ReturnCopy(c *fiber.Ctx, copyID, staffID uuid.UUID) (Loan, error)
+	RenewLoan(c *fiber.Ctx, loanID, userID uuid.UUID, isStaff bool) (Loan, error)
+	GetUserLoans(c *fiber.Ctx, userID uuid.UUID, history bool) ([]Loan, error)
+	PlaceHold(c *fiber.Ctx, bookID, userID uuid.UUID) (Hold, error)
+	GetHold(c *fiber.Ctx, holdID, userID uuid.UUID, isStaff bool) (Hold, error)
+	GetUserHolds(c *fiber.Ctx, userID uuid.UUID) ([]Hold, error)
+	GetBookHolds(c *fiber.Ctx, bookID uuid.UUID) ([]Hold, error)
+	CancelHold(c *fiber.Ctx, holdID, userID uuid.UUID, isStaff bool) error
+	ExpireHolds(c *fiber.Ctx) (int, error)
+	ProcessOverdue(c *fiber.Ctx) (OverdueReport, error)
+	GetUserFines(c *fiber.Ctx, userID uuid.UUID) (FinesResponse, error)
+	GetBalances(c *fiber.Ctx) ([]FineBalance, error)
+	CreditFines(c *fiber.Ctx, userID, staffID uuid.UUID, kind FineKind, creditParams FineCreditRequest) (FinesResponse, error)
+}
+
+type circulationService struct {
+	config    *config.Config
+	repo      CirculationRepository
+	userRepo  user.UserRepository
+	emailRepo email.EmailRepository
+	activity  activity.Counter
+}
+
+func NewCirculationService(config *config.Config, repo CirculationRepository, userRepo user.UserRepository, emailRepo email.EmailRepository,
+	activityCounter activity.Counter) CirculationService {
+	return &circulationService{config: config, repo: repo, userRepo: userRepo, emailRepo: emailRepo, activity: activityCounter}
+}
+
+// Service methods
+func (s *circulationService) GetBookCopies(c *fiber.Ctx, bookID uuid.UUID) ([]Copy, error) {
+	return s.repo.FindBookCopies(c, bookID)
+}
+
+func (s *circulationService) GetCopy(c *fiber.Ctx, copyID uuid.UUID) (Copy, error) {
+	return s.repo.FindCopyByID(c, copyID)
+}
+
+func (s *circulationService) CreateCopy(c *fiber.Ctx, bookID uuid.UUID, copyParams CopyRequest) (Copy, error) {
+	copyParams.normalize()
+	if err := s.validate(c, uuid.Nil, &copyParams); err != nil {
+		return Copy{}, err
+	}
+	if copyParams.Status == StatusOnLoan || copyParams.Status == StatusOnHold {
+		return Copy{}, fmt.Errorf("%w: copies are put on loan or hold by the lending desk", ErrInvalidCopy)
+	}
+
+	newCopy := Copy{BookID: bookID, Barcode: copyParams.Barcode, Location: copyParams.Location, Condition: copyParams.Condition, Status: copyParams.Status}
+	createdCopy, err := s.repo.CreateCopy(c, newCopy)
+	if err != nil {
+		return Copy{}, err
+	}
+	if err := s.assignHold(c, createdCopy.ID); err != nil {
+		return Copy{}, err
+	}
+	return s.repo.FindCopyByID(c, createdCopy.ID)
+}
+
+// UpdateCopy applies a merge patch or JSON patch to the copy. Loan status is owned by checkout and return.
+func (s *circulationService) UpdateCopy(c *fiber.Ctx, copyID uuid.UUID, contentType string, body []byte) (Copy, error) {
+	bookCopy, err := s.repo.FindCopyByID(c, copyID)
+	if err != nil {
+		return Copy{}, err
+	}
+
+	current := CopyRequest{Barcode: bookCopy.Barcode, Location: bookCopy.Location, Condition: bookCopy.Condition, Status: bookCopy.Status}
+	patched := current
+	if err := patch.Apply(&patched, contentType, body); err != nil {
+		return Copy{}, err
+	}
+	patched.normalize()
+	if err := s.validate(c, copyID, &patched); err != nil {
+		return Copy{}, err
+	}
+	if patched.Status != current.Status {
+		switch {
+		case current.Status == StatusOnLoan || patched.Status == StatusOnLoan:
+			return Copy{}, ErrCopyOnLoan
+		case current.Status == StatusOnHold || patched.Status == StatusOnHold:
+			return Copy{}, ErrCopyOnHold
+		}
+	}
+
+	if changes := patch.Changes(current, patched); len(changes) > 0 {
+		if err := s.repo.UpdateCopyFields(c, copyID, changes); err != nil {
+			return Copy{}, err
+		}
+	}
+	if patched.Status == StatusAvailable && current.Status != StatusAvailable {
+		if err := s.assignHold(c, copyID); err != nil {
+			return Copy{}, err
+		}
+	}
+	return s.repo.FindCopyByID(c, copyID)
+}
+
+func (s *circulationService) DeleteCopy(c *fiber.Ctx, copyID uuid.UUID) error {
+	return s.repo.DeleteCopy(c, copyID)
+}
+
+// CheckoutCopy lends a copy to the borrower with the loan period and limit of the borrower's role
+func (s *circulationService) CheckoutCopy(c *fiber.Ctx, copyID, staffID uuid.UUID, checkoutParams CheckoutRequest) (Loan, error) {
+	if err := utils.Validate(&checkoutParams); err != nil {
+		return Loan{}, fmt.Errorf("%w: %v", ErrInvalidCopy, err)
+	}
+
+	borrower, err := s.userRepo.FindUserByID(c, checkoutParams.UserID)
+	if err != nil {
+		return Loan{}, err
+	}
+	policy := PolicyFor(s.config.Circulation, borrower.Role)
+
+	now := time.Now()
+	newLoan := Loan{
+		CopyID:       copyID,
+		UserID:       borrower.ID,
+		CheckedOutBy: staffID,
+		CheckedOutAt: now,
+		DueAt:        policy.DueDate(now),
+	}
+	createdLoan, err := s.repo.CheckoutCopy(c, newLoan, policy.MaxLoans, s.config.Circulation.FineBlockCents)
+	if err != nil {
+		return Loan{}, err
+	}
+
+	loan, err := s.repo.FindLoanByID(c, createdLoan.ID)
+	if err != nil {
+		return Loan{}, err
+	}
+	s.activity.Record(c.UserContext(), activity.EventLoan, loan.Copy.BookID)
+	return loan, nil
+}
+
+// ReturnCopy closes the loan and settles its fine, the copy goes to the next hold in the queue when there is one
+func (s *circulationService) ReturnCopy(c *fiber.Ctx, copyID, staffID uuid.UUID) (Loan, error) {
+	loan, assigned, err := s.repo.ReturnCopy(c, copyID, staffID, s.pickupDeadline(time.Now()))
+	if err != nil {
+		return Loan{}, err
+	}
+	s.notifyHoldReady(c, assigned)
+	return s.repo.FindLoanByID(c, loan.ID)
+}
+
+// RenewLoan extends an open loan, borrowers renew their own loans and staff can renew any loan
+func (s *circulationService) RenewLoan(c *fiber.Ctx, loanID, userID uuid.UUID, isStaff bool) (Loan, error) {
+	loan, err := s.repo.FindLoanByID(c, loanID)
+	if err != nil {
+		return Loan{}, err
+	}
+	if loan.UserID != userID && !isStaff {
+		return Loan{}, ErrNotBorrower
+	}
+
+	borrower, err := s.userRepo.FindUserByID(c, loan.UserID)
+	if err != nil {
+		return Loan{}, err
+	}
+	dueAt, err := PolicyFor(s.config.Circulation, borrower.Role).Renew(loan, time.Now())
+	if err != nil {
+		return Loan{}, err
+	}
+
+	if err := s.repo.RenewLoan(c, loan, dueAt); err != nil {
+		return Loan{}, err
+	}
+	return s.repo.FindLoanByID(c, loanID)
+}
+
+func (s *circulationService) GetUserLoans(c *fiber.Ctx, userID uuid.UUID, history bool) ([]Loan, error) {
+	return s.repo.FindUserLoans(c, userID, history)
+}
+
+// PlaceHold queues the user for the next copy of a book that has no copy on the shelf
+func (s *circulationService) PlaceHold(c *fiber.Ctx, bookID, userID uuid.UUID) (Hold, error) {
+	createdHold, err := s.repo.CreateHold(c, Hold{BookID: bookID, UserID: userID, Status: HoldWaiting})
+	if err != nil {
+		return Hold{}, err
+	}
+	return s.repo.FindHoldByID(c, createdHold.ID)
+}
+
+func (s *circulationService) GetHold(c *fiber.Ctx, holdID, userID uuid.UUID, isStaff bool) (Hold, error) {
+	hold, err := s.repo.FindHoldByID(c, holdID)
+	if err != nil {
+		return Hold{}, err
+	}
+	if hold.UserID != userID && !isStaff {
+		return Hold{}, ErrNotHolder
+	}
+	return hold, nil
+}
+
+func (s *circulationService) GetUserHolds(c *fiber.Ctx, userID uuid.UUID) ([]Hold, error) {
+	return s.repo.FindUserHolds(c, userID)
+}
+
+func (s *circulationService) GetBookHolds(c *fiber.Ctx, bookID uuid.UUID) ([]Hold, error) {
+	return s.repo.FindBookHolds(c, bookID)
+}
+
+// CancelHold withdraws a hold, a copy that was waiting for pickup rolls over to the next hold
+func (s *circulationService) CancelHold(c *fiber.Ctx, holdID, userID uuid.UUID, isStaff bool) error {
+	if _, err := s.GetHold(c, holdID, userID, isStaff); err != nil {
+		return err
+	}
+
+	assigned, err := s.repo.CancelHold(c, holdID, s.pickupDeadline(time.Now()))
+	if err != nil {
+		return err
+	}
+	s.notifyHoldReady(c, assigned)
+	return nil
+}
+
+// ExpireHolds closes ready holds past their pickup deadline and passes the copies on, it returns the number of
+// expired holds
+func (s *circulationService) ExpireHolds(c *fiber.Ctx) (int, error) {
+	now := time.Now()
+	holdIDs, err := s.repo.FindExpiredHolds(c, now)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, holdID := range holdIDs {
+		assigned, err := s.repo.ExpireHold(c, holdID, now, s.pickupDeadline(now))
+		if err != nil {
+			return 0, err
+		}
+		s.notifyHoldReady(c, assigned)
+	}
+	return len(holdIDs), nil
+}
+
+// ProcessOverdue is the scheduled overdue run. It reminds borrowers of loans falling due soon, accrues fines on
+// overdue loans and tells their borrowers once. Every step is idempotent, so the job can run as often as needed.
+func (s *circulationService) ProcessOverdue(c *fiber.Ctx) (OverdueReport, error) {
+	var report OverdueReport
+	now := time.Now()
+
+	dueSoon, err := s.repo.FindLoansDueBetween(c, now, now.AddDate(0, 0, s.config.Circulation.DueReminderDays))
+	if err != nil {
+		return report, err
+	}
+	for _, loan := range dueSoon {
+		if s.notifyLoan(c, loan, "Your loan is due soon", "loan_due_soon", 0) {
+			if err := s.repo.UpdateLoanFields(c, loan.ID, map[string]interface{}{"due_reminder_at": now}); err != nil {
+				return report, err
+			}
+			report.DueReminders++
+		}
+	}
+
+	overdue, err := s.repo.FindOverdueLoans(c, now)
+	if err != nil {
+		return report, err
+	}
+	report.OverdueLoans = len(overdue)
+	for _, loan := range overdue {
+		fine := FineFor(s.config.Circulation, loan.DueAt, now)
+		added, err := s.repo.AccrueFine(c, loan, fine)
+		if err != nil {
+			return report, err
+		}
+		report.AccruedCents += added
+
+		if loan.OverdueReminderAt == nil && s.notifyLoan(c, loan, "Your loan is overdue", "loan_overdue", fine) {
+			if err := s.repo.UpdateLoanFields(c, loan.ID, map[string]interface{}{"overdue_reminder_at": now}); err != nil {
+				return report, err
+			}
+			report.OverdueReminders++
+		}
+	}
+	return report, nil
+}
+
+func (s *circulationService) GetUserFines(c *fiber.Ctx, userID uuid.UUID) (FinesResponse, error) {
+	balance, err := s.repo.FindUserBalance(c, userID)
+	if err != nil {
+		return FinesResponse{}, err
+	}
+	entries, err := s.repo.FindUserFines(c, userID)
+	if err != nil {
+		return FinesResponse{}, err
+	}
+	return FinesResponse{BalanceCents: balance, Entries: entries}, nil
+}
+
+func (s *circulationService) GetBalances(c *fiber.Ctx) ([]FineBalance, error) {
+	return s.repo.FindBalances(c)
+}
+
+// CreditFines records a payment or a waiver by staff, neither can take the balance below zero
+func (s *circulationService) CreditFines(c *fiber.Ctx, userID, staffID uuid.UUID, kind FineKind, creditParams FineCreditRequest) (FinesResponse, error) {
+	if err := utils.Validate(&creditParams); err != nil {
+		return FinesResponse{}, fmt.Errorf("%w: %v", ErrInvalidFine, err)
+	}
+	if kind != FinePayment && kind != FineWaiver {
+		return FinesResponse{}, fmt.Errorf("%w: unknown kind %s", ErrInvalidFine, kind)
+	}
+
+	entry := FineEntry{
+		UserID:      userID,
+		LoanID:      creditParams.LoanID,
+		Kind:        kind,
+		AmountCents: -creditParams.AmountCents,
+		Note:        strings.TrimSpace(creditParams.Note),
+		RecordedBy:  &staffID,
+	}
+	if err := s.repo.CreditFines(c, entry); err != nil {
+		return FinesResponse{}, err
+	}
+	return s.GetUserFines(c, userID)
+}
+
+func (s *circulationService) assignHold(c *fiber.Ctx, copyID uuid.UUID) error {
+	assigned, err := s.repo.AssignHold(c, copyID, s.pickupDeadline(time.Now()))
+	if err != nil {
+		return err
+	}
+	s.notifyHoldReady(c, assigned)
+	return nil
+}
+
+func (s *circulationService) pickupDeadline(from time.Time) time.Time {
+	return from.AddDate(0, 0, s.config.Circulation.HoldPickupDays)
+}
+
+// notifyHoldReady emails the holder that a copy is waiting. The hold is already assigned, so a failed email
+// is only logged.
+func (s *circulationService) notifyHoldReady(c *fiber.Ctx, assigned *Hold) {
+	if assigned == nil {
+		return
+	}
+
+	hold, err := s.repo.FindHoldByID(c, assigned.ID)
+	if err != nil {
+		log.Errorf("failed to load hold for notification: %v", err)
+		return
+	}
+	holder, err := s.userRepo.FindUserByID(c, hold.UserID)
+	if err != nil {
+		log.Errorf("failed to load holder for notification: %v", err)
+		return
+	}
+
+	data := map[string]interface{}{
+		"FirstName": holder.FirstName,
+		"Title":     "",
+		"Location":  "",
+		"PickupBy":  hold.PickupBy.Format("Monday, 2 January 2006"),
+		"Year":      time.Now().Year(),
+	}
+	if hold.Book != nil {
+		data["Title"] = hold.Book.Title
+	}
+	if hold.Copy != nil {
+		data["Location"] = hold.Copy.Location
+	}
+	if err := s.emailRepo.SendEmail(holder.Email, "Your hold is ready for pickup", "hold_ready", data); err != nil {
+		log.Errorf("failed to send hold ready email: %v", err)
+	}
+}
+
+// notifyLoan emails the borrower about a loan and reports whether the email went out, so a failed reminder
+// is retried on the next run
+func (s *circulationService) notifyLoan(c *fiber.Ctx, loan Loan, subject, templateName string, fineCents int64) bool {
+	borrower, err := s.userRepo.FindUserByID(c, loan.UserID)
+	if err != nil {
+		log.Errorf("failed to load borrower for notification: %v", err)
+		return false
+	}
+
+	data := map[string]interface{}{
+		"FirstName": borrower.FirstName,
+		"Title":     "",
+		"DueAt":     loan.DueAt.Format("Monday, 2 January 2006"),
+		"Fine":      formatCents(fineCents),
+		"DailyFine": formatCents(s.config.Circulation.FineDailyCents),
+		"FineCap":   formatCents(s.config.Circulation.FineCapCents),
+		"Year":      time.Now().Year(),
+	}
+	if loan.Copy.Book != nil {
+		data["Title"] = loan.Copy.Book.Title
+	}
+	if err := s.emailRepo.SendEmail(borrower.Email, subject, templateName, data); err != nil {
+		log.Errorf("failed to send %s email: %v", templateName, err)
+		return false
+	}
+	return true
+}
+
+func formatCents(cents int64) string {
+	return fmt.Sprintf("%d.%02d", cents/100, cents%100)
+}
+
+// validate checks the copy fields and that no other copy uses the barcode
+func (s *circulationService) validate(c *fiber.Ctx, copyID uuid.UUID, copyParams *CopyRequest) error {
+	if err := utils.Validate(copyParams); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCopy, err)
+	}
+
+	existing, err := s.repo.FindCopyByBarcode(c, copyParams.Barcode)
+	if err == nil && existing.ID != copyID {
+		return fmt.Errorf("%w: barcode %s is already in use", ErrInvalidCopy, copyParams.Barcode)
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (r *CopyRequest) normalize() {
+	r.Barcode = strings.TrimSpace(r.Barcode)
+	r.Location = strings.TrimSpace(r.Location)
+	if r.Condition == "" {
+		r.Condition = ConditionGood
+	}
+	if r.Status == "" {
+		r.Status = StatusAvailable
+	}
+}
@@ -0,0 +1,204 @@
+package circulation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/circulation"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/user"
+	"github.com/jerpsp/go-fiber-beginner/mocks"
+	"github.com/jerpsp/go-fiber-beginner/pkg/activity"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CirculationServiceSuite struct {
+	suite.Suite
+	mockRepo     *mocks.CirculationRepository
+	mockUserRepo *mocks.UserRepository
+	mockEmail    *mocks.EmailRepository
+	service      circulation.CirculationService
+}
+
+func TestCirculationServiceSuite(t *testing.T) {
+	suite.Run(t, new(CirculationServiceSuite))
+}
+
+func (s *CirculationServiceSuite) SetupTest() {
+	s.mockRepo = mocks.NewCirculationRepository(s.T())
+	s.mockUserRepo = mocks.NewUserRepository(s.T())
+	s.mockEmail = mocks.NewEmailRepository(s.T())
+	cfg := &config.Config{Circulation: &config.Circulation{HoldPickupDays: 3, FineDailyCents: 25, FineCapCents: 1000}}
+	s.service = circulation.NewCirculationService(cfg, s.mockRepo, s.mockUserRepo, s.mockEmail, activity.New(&activity.ActivityConfig{}, nil))
+}
+
+// pickupIn matches a pickup deadline the given number of days from now
+func pickupIn(days int) interface{} {
+	return mock.MatchedBy(func(pickupBy time.Time) bool {
+		expected := time.Now().AddDate(0, 0, days)
+		return pickupBy.After(expected.Add(-time.Minute)) && !pickupBy.After(expected)
+	})
+}
+
+// expectHoldReady expects the holder of a hold that was just set a copy aside to be emailed
+func (s *CirculationServiceSuite) expectHoldReady(hold circulation.Hold, holder *user.User) {
+	s.mockRepo.EXPECT().FindHoldByID(mock.Anything, hold.ID).Return(hold, nil)
+	s.mockUserRepo.EXPECT().FindUserByID(mock.Anything, holder.ID).Return(holder, nil)
+	s.mockEmail.EXPECT().SendEmail(holder.Email, "Your hold is ready for pickup", "hold_ready", mock.MatchedBy(func(data map[string]interface{}) bool {
+		return data["Title"] == hold.Book.Title && data["Location"] == hold.Copy.Location
+	})).Return(nil)
+}
+
+func (s *CirculationServiceSuite) TestPlaceHold1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Place Hold Joins The End Of The Queue", 34)
+
+	// Setup Mock
+	bookID, userID := uuid.New(), uuid.New()
+	created := circulation.Hold{ID: uuid.New(), BookID: bookID, UserID: userID, Status: circulation.HoldWaiting}
+	queued := created
+	queued.Position = 3
+	s.mockRepo.EXPECT().CreateHold(mock.Anything, circulation.Hold{BookID: bookID, UserID: userID, Status: circulation.HoldWaiting}).Return(created, nil)
+	s.mockRepo.EXPECT().FindHoldByID(mock.Anything, created.ID).Return(queued, nil)
+
+	// Call the service method
+	hold, err := s.service.PlaceHold(&fiber.Ctx{}, bookID, userID)
+
+	// Assertions
+	s.NoError(err)
+	s.Equal(queued, hold)
+}
+
+func (s *CirculationServiceSuite) TestPlaceHold2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Place Hold With A Copy On The Shelf", 31)
+
+	// Setup Mock
+	bookID, userID := uuid.New(), uuid.New()
+	s.mockRepo.EXPECT().CreateHold(mock.Anything, mock.Anything).Return(circulation.Hold{}, circulation.ErrCopyAvailable)
+
+	// Call the service method
+	_, err := s.service.PlaceHold(&fiber.Ctx{}, bookID, userID)
+
+	// Assertions
+	s.ErrorIs(err, circulation.ErrCopyAvailable)
+}
+
+func (s *CirculationServiceSuite) TestReturnCopy1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Return Copy Sets It Aside For The Next Hold", 34)
+
+	// Setup Mock
+	copyID, staffID := uuid.New(), uuid.New()
+	returnedAt := time.Now()
+	loan := circulation.Loan{ID: uuid.New(), CopyID: copyID, UserID: uuid.New(), ReturnedAt: &returnedAt, ReturnedBy: &staffID}
+	holder := &user.User{ID: uuid.New(), FirstName: "Jane", Email: "jane.doe@example.com"}
+	pickupBy := returnedAt.AddDate(0, 0, 3)
+	ready := circulation.Hold{
+		ID: uuid.New(), UserID: holder.ID, Status: circulation.HoldReady, CopyID: &copyID, PickupBy: &pickupBy,
+		Book: &book.Book{Title: "Dune"}, Copy: &circulation.Copy{ID: copyID, Location: "Shelf A"},
+	}
+	s.mockRepo.EXPECT().ReturnCopy(mock.Anything, copyID, staffID, pickupIn(3)).Return(loan, &circulation.Hold{ID: ready.ID}, nil)
+	s.expectHoldReady(ready, holder)
+	s.mockRepo.EXPECT().FindLoanByID(mock.Anything, loan.ID).Return(loan, nil)
+
+	// Call the service method
+	result, err := s.service.ReturnCopy(&fiber.Ctx{}, copyID, staffID)
+
+	// Assertions
+	s.NoError(err)
+	s.Equal(loan, result)
+}
+
+func (s *CirculationServiceSuite) TestReturnCopy2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Return Copy Without Holds", 34)
+
+	// Setup Mock
+	// nobody is emailed when the copy goes back on the shelf
+	copyID, staffID := uuid.New(), uuid.New()
+	returnedAt := time.Now()
+	loan := circulation.Loan{ID: uuid.New(), CopyID: copyID, ReturnedAt: &returnedAt}
+	s.mockRepo.EXPECT().ReturnCopy(mock.Anything, copyID, staffID, pickupIn(3)).Return(loan, nil, nil)
+	s.mockRepo.EXPECT().FindLoanByID(mock.Anything, loan.ID).Return(loan, nil)
+
+	// Call the service method
+	result, err := s.service.ReturnCopy(&fiber.Ctx{}, copyID, staffID)
+
+	// Assertions
+	s.NoError(err)
+	s.Equal(loan, result)
+}
+
+func (s *CirculationServiceSuite) TestExpireHolds1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Expire Holds Passes The Copies On In Deadline Order", 34)
+
+	// Setup Mock
+	// the first copy rolls over to the next hold in its queue, the second has nobody waiting
+	firstID, secondID := uuid.New(), uuid.New()
+	copyID := uuid.New()
+	holder := &user.User{ID: uuid.New(), FirstName: "Jane", Email: "jane.doe@example.com"}
+	pickupBy := time.Now().AddDate(0, 0, 3)
+	next := circulation.Hold{
+		ID: uuid.New(), UserID: holder.ID, Status: circulation.HoldReady, CopyID: &copyID, PickupBy: &pickupBy,
+		Book: &book.Book{Title: "Dune"}, Copy: &circulation.Copy{ID: copyID, Location: "Shelf B"},
+	}
+	s.mockRepo.EXPECT().FindExpiredHolds(mock.Anything, mock.Anything).Return([]uuid.UUID{firstID, secondID}, nil)
+	mock.InOrder(
+		s.mockRepo.EXPECT().ExpireHold(mock.Anything, firstID, mock.Anything, pickupIn(3)).Return(&circulation.Hold{ID: next.ID}, nil).Call,
+		s.mockRepo.EXPECT().ExpireHold(mock.Anything, secondID, mock.Anything, pickupIn(3)).Return(nil, nil).Call,
+	)
+	s.expectHoldReady(next, holder)
+
+	// Call the service method
+	expired, err := s.service.ExpireHolds(&fiber.Ctx{})
+
+	// Assertions
+	s.NoError(err)
+	s.Equal(2, expired)
+}
+
+func (s *CirculationServiceSuite) TestCancelHold1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Cancel Ready Hold Moves The Copy Down The Queue", 34)
+
+	// Setup Mock
+	userID, copyID := uuid.New(), uuid.New()
+	cancelled := circulation.Hold{ID: uuid.New(), UserID: userID, Status: circulation.HoldReady, CopyID: &copyID}
+	holder := &user.User{ID: uuid.New(), FirstName: "Jane", Email: "jane.doe@example.com"}
+	pickupBy := time.Now().AddDate(0, 0, 3)
+	next := circulation.Hold{
+		ID: uuid.New(), UserID: holder.ID, Status: circulation.HoldReady, CopyID: &copyID, PickupBy: &pickupBy,
+		Book: &book.Book{Title: "Dune"}, Copy: &circulation.Copy{ID: copyID, Location: "Shelf A"},
+	}
+	s.mockRepo.EXPECT().FindHoldByID(mock.Anything, cancelled.ID).Return(cancelled, nil)
+	s.mockRepo.EXPECT().CancelHold(mock.Anything, cancelled.ID, pickupIn(3)).Return(&circulation.Hold{ID: next.ID}, nil)
+	s.expectHoldReady(next, holder)
+
+	// Call the service method
+	err := s.service.CancelHold(&fiber.Ctx{}, cancelled.ID, userID, false)
+
+	// Assertions
+	s.NoError(err)
+}
+
+func (s *CirculationServiceSuite) TestCancelHold2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Cancel Someone Else's Hold", 31)
+
+	// Setup Mock
+	hold := circulation.Hold{ID: uuid.New(), UserID: uuid.New(), Status: circulation.HoldWaiting}
+	s.mockRepo.EXPECT().FindHoldByID(mock.Anything, hold.ID).Return(hold, nil)
+
+	// Call the service method
+	err := s.service.CancelHold(&fiber.Ctx{}, hold.ID, uuid.New(), false)
+
+	// Assertions
+	s.ErrorIs(err, circulation.ErrNotHolder)
+}
@@ -0,0 +1,29 @@
+package readinglist
+
+import "github.com/google/uuid"
+
+// ReadingListRequest is the writable part of a list, shelves only accept a visibility change
+type ReadingListRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description" validate:"omitempty,max=1000"`
+	Visibility  string `json:"visibility" validate:"omitempty,oneof=private public"`
+}
+
+// ListBookRequest adds a book to a list, at the end unless a position is given
+type ListBookRequest struct {
+	BookID   uuid.UUID `json:"book_id" validate:"required"`
+	Position *int      `json:"position" validate:"omitempty,min=0"`
+}
+
+// ReorderRequest lists every book on the list in its new order
+type ReorderRequest struct {
+	BookIDs []uuid.UUID `json:"book_ids" validate:"required"`
+}
+
+// ProgressRequest replaces the reading progress of a book, dates are plain YYYY-MM-DD strings
+type ProgressRequest struct {
+	CurrentPage *int     `json:"current_page" validate:"omitempty,min=0"`
+	Percentage  *float64 `json:"percentage" validate:"omitempty,min=0,max=100"`
+	StartedAt   string   `json:"started_at" validate:"omitempty,datetime=2006-01-02"`
+	FinishedAt  string   `json:"finished_at" validate:"omitempty,datetime=2006-01-02"`
+}
@@ -0,0 +1,227 @@
+package readinglist
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/pkg/patch"
+	"gorm.io/gorm"
+)
+
+type ReadingListHandler struct {
+	config  *config.Config
+	service ReadingListService
+}
+
+func NewReadingListHandler(config *config.Config, service ReadingListService) *ReadingListHandler {
+	return &ReadingListHandler{config: config, service: service}
+}
+
+// Handler methods
+func (h *ReadingListHandler) GetLists(c *fiber.Ctx) error {
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	lists, err := h.service.GetLists(c, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"lists": lists})
+}
+
+func (h *ReadingListHandler) GetList(c *fiber.Ctx) error {
+	listID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid list ID"})
+	}
+
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	list, err := h.service.GetList(c, listID, userID)
+	if err != nil {
+		return listError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"list": list})
+}
+
+func (h *ReadingListHandler) GetSharedList(c *fiber.Ctx) error {
+	token, err := uuid.Parse(c.Params("token"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": gorm.ErrRecordNotFound.Error()})
+	}
+
+	list, err := h.service.GetSharedList(c, token)
+	if err != nil {
+		return listError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"list": list})
+}
+
+func (h *ReadingListHandler) CreateList(c *fiber.Ctx) error {
+	var listParams ReadingListRequest
+	if err := c.BodyParser(&listParams); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid input"})
+	}
+
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	list, err := h.service.CreateList(c, userID, listParams)
+	if err != nil {
+		return listError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"list": list})
+}
+
+func (h *ReadingListHandler) UpdateList(c *fiber.Ctx) error {
+	listID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid list ID"})
+	}
+
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	list, err := h.service.UpdateList(c, listID, userID, c.Get(fiber.HeaderContentType), c.Body())
+	if err != nil {
+		return listError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"list": list})
+}
+
+func (h *ReadingListHandler) DeleteList(c *fiber.Ctx) error {
+	listID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid list ID"})
+	}
+
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	if err := h.service.DeleteList(c, listID, userID); err != nil {
+		return listError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *ReadingListHandler) AddBook(c *fiber.Ctx) error {
+	listID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid list ID"})
+	}
+
+	var bookParams ListBookRequest
+	if err := c.BodyParser(&bookParams); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid input"})
+	}
+
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	list, err := h.service.AddBook(c, listID, userID, bookParams)
+	if err != nil {
+		return listError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"list": list})
+}
+
+func (h *ReadingListHandler) RemoveBook(c *fiber.Ctx) error {
+	listID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid list ID"})
+	}
+	bookID, err := uuid.Parse(c.Params("bookId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	list, err := h.service.RemoveBook(c, listID, userID, bookID)
+	if err != nil {
+		return listError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"list": list})
+}
+
+func (h *ReadingListHandler) ReorderBooks(c *fiber.Ctx) error {
+	listID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid list ID"})
+	}
+
+	var orderParams ReorderRequest
+	if err := c.BodyParser(&orderParams); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid input"})
+	}
+
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	list, err := h.service.ReorderBooks(c, listID, userID, orderParams)
+	if err != nil {
+		return listError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"list": list})
+}
+
+func (h *ReadingListHandler) GetProgress(c *fiber.Ctx) error {
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	progress, err := h.service.GetProgress(c, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"progress": progress})
+}
+
+func (h *ReadingListHandler) GetBookProgress(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("bookId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	progress, err := h.service.GetBookProgress(c, userID, bookID)
+	if err != nil {
+		return listError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"progress": progress})
+}
+
+func (h *ReadingListHandler) SetProgress(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("bookId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	var progressParams ProgressRequest
+	if err := c.BodyParser(&progressParams); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid input"})
+	}
+
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	progress, err := h.service.SetProgress(c, userID, bookID, progressParams)
+	if err != nil {
+		return listError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"progress": progress})
+}
+
+func (h *ReadingListHandler) DeleteProgress(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("bookId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	if err := h.service.DeleteProgress(c, userID, bookID); err != nil {
+		return listError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// listError maps service errors to the matching HTTP status
+func listError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, patch.ErrUnsupportedMediaType):
+		return c.Status(fiber.StatusUnsupportedMediaType).JSON(fiber.Map{"message": err.Error()})
+	case errors.Is(err, ErrInvalidList), errors.Is(err, ErrInvalidOrder), errors.Is(err, ErrInvalidProgress),
+		errors.Is(err, patch.ErrInvalidPatch):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+	case errors.Is(err, ErrShelfLocked):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"message": err.Error()})
+	case errors.Is(err, ErrAlreadyOnList), errors.Is(err, patch.ErrTestFailed):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+}
@@ -0,0 +1,33 @@
+package readinglist
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidList     = errors.New("invalid reading list")
+	ErrShelfLocked     = errors.New("built-in shelves can only change visibility")
+	ErrAlreadyOnList   = errors.New("book is already on this list")
+	ErrInvalidOrder    = errors.New("book_ids must list every book on the list exactly once")
+	ErrInvalidProgress = errors.New("invalid reading progress")
+)
+
+// SameBooks reports whether requested is a permutation of current without duplicates
+func SameBooks(current, requested []uuid.UUID) bool {
+	if len(current) != len(requested) {
+		return false
+	}
+	remaining := make(map[uuid.UUID]bool, len(current))
+	for _, bookID := range current {
+		remaining[bookID] = true
+	}
+	for _, bookID := range requested {
+		if !remaining[bookID] {
+			return false
+		}
+		delete(remaining, bookID)
+	}
+	return true
+}
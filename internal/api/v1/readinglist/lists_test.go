@@ -0,0 +1,37 @@
+package readinglist_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/readinglist"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type ListsSuite struct {
+	suite.Suite
+}
+
+func TestListsSuite(t *testing.T) {
+	suite.Run(t, new(ListsSuite))
+}
+
+func (s *ListsSuite) TestSameBooks1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Same Books In New Order", 34)
+
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+	s.True(readinglist.SameBooks([]uuid.UUID{first, second, third}, []uuid.UUID{third, first, second}))
+	s.True(readinglist.SameBooks(nil, []uuid.UUID{}))
+}
+
+func (s *ListsSuite) TestSameBooks2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Missing, Extra Or Duplicated Books", 31)
+
+	first, second := uuid.New(), uuid.New()
+	s.False(readinglist.SameBooks([]uuid.UUID{first, second}, []uuid.UUID{first}))
+	s.False(readinglist.SameBooks([]uuid.UUID{first, second}, []uuid.UUID{first, uuid.New()}))
+	s.False(readinglist.SameBooks([]uuid.UUID{first, second}, []uuid.UUID{first, first}))
+}
@@ -0,0 +1,76 @@
+package readinglist
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/user"
+)
+
+const (
+	ShelfWantToRead = "want_to_read"
+	ShelfReading    = "reading"
+	ShelfRead       = "read"
+)
+
+const (
+	VisibilityPrivate = "private"
+	VisibilityPublic  = "public"
+)
+
+// defaultShelves are created for every user the first time their lists are read
+var defaultShelves = []ReadingList{
+	{Shelf: ShelfWantToRead, Name: "Want to read"},
+	{Shelf: ShelfReading, Name: "Reading"},
+	{Shelf: ShelfRead, Name: "Read"},
+}
+
+// ReadingList is either one of the built-in shelves or a custom list named by the user. Public lists can be read
+// by anyone holding the share token.
+type ReadingList struct {
+	ID          uuid.UUID         `gorm:"type:uuid; default:uuid_generate_v4()" json:"id"`
+	UserID      uuid.UUID         `gorm:"type:uuid;not null;index;uniqueIndex:idx_reading_lists_user_shelf,where:shelf <> ''" json:"user_id"`
+	Shelf       string            `gorm:"size:20;not null;default:'';uniqueIndex:idx_reading_lists_user_shelf" json:"shelf,omitempty"`
+	Name        string            `gorm:"size:100;not null" json:"name"`
+	Description string            `gorm:"type:text" json:"description"`
+	Visibility  string            `gorm:"size:10;not null;default:'private'" json:"visibility"`
+	ShareToken  uuid.UUID         `gorm:"type:uuid;not null;uniqueIndex;default:uuid_generate_v4()" json:"share_token"`
+	ShareURL    string            `gorm:"-" json:"share_url,omitempty"`
+	BookCount   int64             `gorm:"->;-:migration" json:"book_count"`
+	Items       []ReadingListItem `gorm:"foreignKey:ListID;constraint:OnDelete:CASCADE" json:"items,omitempty"`
+	User        user.User         `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+func (l ReadingList) IsShelf() bool {
+	return l.Shelf != ""
+}
+
+// ReadingListItem places a book on a list, Position orders the list starting at 0
+type ReadingListItem struct {
+	ListID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"-"`
+	BookID    uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"book_id"`
+	Position  int       `gorm:"not null" json:"position"`
+	Book      book.Book `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE" json:"book"`
+	CreatedAt time.Time `json:"added_at"`
+}
+
+// ReadingProgress tracks how far a user is through a book, by page, percentage or both
+type ReadingProgress struct {
+	UserID      uuid.UUID  `gorm:"type:uuid;primaryKey" json:"user_id"`
+	BookID      uuid.UUID  `gorm:"type:uuid;primaryKey;index" json:"book_id"`
+	CurrentPage *int       `json:"current_page"`
+	Percentage  *float64   `gorm:"type:numeric(5,2)" json:"percentage"`
+	StartedAt   *time.Time `gorm:"type:date" json:"started_at"`
+	FinishedAt  *time.Time `gorm:"type:date" json:"finished_at"`
+	User        user.User  `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Book        book.Book  `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE" json:"book"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func (ReadingProgress) TableName() string {
+	return "reading_progress"
+}
@@ -0,0 +1,238 @@
+package readinglist
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+	"github.com/jerpsp/go-fiber-beginner/pkg/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReadingListRepository interface {
+	EnsureShelves(c *fiber.Ctx, userID uuid.UUID) error
+	FindUserLists(c *fiber.Ctx, userID uuid.UUID) ([]ReadingList, error)
+	FindListByID(c *fiber.Ctx, listID uuid.UUID) (ReadingList, error)
+	FindListByShareToken(c *fiber.Ctx, token uuid.UUID) (ReadingList, error)
+	CreateList(c *fiber.Ctx, newList ReadingList) (ReadingList, error)
+	UpdateListFields(c *fiber.Ctx, listID uuid.UUID, fields map[string]interface{}) error
+	DeleteList(c *fiber.Ctx, listID uuid.UUID) error
+	AddBook(c *fiber.Ctx, listID, bookID uuid.UUID, position *int) error
+	RemoveBook(c *fiber.Ctx, listID, bookID uuid.UUID) error
+	ReorderBooks(c *fiber.Ctx, listID uuid.UUID, bookIDs []uuid.UUID) error
+	FindUserProgress(c *fiber.Ctx, userID uuid.UUID) ([]ReadingProgress, error)
+	FindProgress(c *fiber.Ctx, userID, bookID uuid.UUID) (ReadingProgress, error)
+	SaveProgress(c *fiber.Ctx, progress ReadingProgress) error
+	DeleteProgress(c *fiber.Ctx, userID, bookID uuid.UUID) error
+}
+
+type readingListRepository struct {
+	config *config.Config
+	db     *database.GormDB
+}
+
+func NewReadingListRepository(cfg *config.Config, db *database.GormDB) ReadingListRepository {
+	return &readingListRepository{config: cfg, db: db}
+}
+
+// Repository methods
+
+// EnsureShelves creates any missing built-in shelf, existing shelves are left untouched
+func (r *readingListRepository) EnsureShelves(c *fiber.Ctx, userID uuid.UUID) error {
+	shelves := make([]ReadingList, len(defaultShelves))
+	for i, shelf := range defaultShelves {
+		shelves[i] = ReadingList{UserID: userID, Shelf: shelf.Shelf, Name: shelf.Name, Visibility: VisibilityPrivate}
+	}
+	return r.db.DB.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&shelves).Error
+}
+
+// FindUserLists returns the shelves first, then custom lists by name
+func (r *readingListRepository) FindUserLists(c *fiber.Ctx, userID uuid.UUID) ([]ReadingList, error) {
+	var lists []ReadingList
+	err := r.db.DB.Model(&ReadingList{}).
+		Select("reading_lists.*, (SELECT COUNT(*) FROM reading_list_items WHERE reading_list_items.list_id = reading_lists.id) AS book_count").
+		Where("user_id = ?", userID).
+		Order("shelf = '' ASC").Order("created_at ASC").Order("name ASC").
+		Find(&lists).Error
+	if err != nil {
+		return nil, err
+	}
+	return lists, nil
+}
+
+func (r *readingListRepository) FindListByID(c *fiber.Ctx, listID uuid.UUID) (ReadingList, error) {
+	return r.findList(r.db.DB.Where("reading_lists.id = ?", listID))
+}
+
+func (r *readingListRepository) FindListByShareToken(c *fiber.Ctx, token uuid.UUID) (ReadingList, error) {
+	return r.findList(r.db.DB.Where("reading_lists.share_token = ?", token))
+}
+
+func (r *readingListRepository) findList(query *gorm.DB) (ReadingList, error) {
+	var list ReadingList
+	err := query.Model(&ReadingList{}).
+		Select("reading_lists.*, (SELECT COUNT(*) FROM reading_list_items WHERE reading_list_items.list_id = reading_lists.id) AS book_count").
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Preload("Items.Book").
+		First(&list).Error
+	if err != nil {
+		return ReadingList{}, err
+	}
+	return list, nil
+}
+
+func (r *readingListRepository) CreateList(c *fiber.Ctx, newList ReadingList) (ReadingList, error) {
+	if err := r.db.DB.Omit(clause.Associations).Create(&newList).Error; err != nil {
+		return ReadingList{}, err
+	}
+	return newList, nil
+}
+
+func (r *readingListRepository) UpdateListFields(c *fiber.Ctx, listID uuid.UUID, fields map[string]interface{}) error {
+	result := r.db.DB.Model(&ReadingList{}).Where("id = ?", listID).Updates(fields)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *readingListRepository) DeleteList(c *fiber.Ctx, listID uuid.UUID) error {
+	result := r.db.DB.Delete(&ReadingList{}, "id = ?", listID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// AddBook inserts the book at position, shifting the books after it down. A position past the end appends.
+func (r *readingListRepository) AddBook(c *fiber.Ctx, listID, bookID uuid.UUID, position *int) error {
+	return r.db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockList(tx, listID); err != nil {
+			return err
+		}
+		if err := tx.Select("id").Where("id = ?", bookID).First(&book.Book{}).Error; err != nil {
+			return err
+		}
+
+		var existing int64
+		if err := tx.Model(&ReadingListItem{}).Where("list_id = ? AND book_id = ?", listID, bookID).Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return ErrAlreadyOnList
+		}
+
+		var count int64
+		if err := tx.Model(&ReadingListItem{}).Where("list_id = ?", listID).Count(&count).Error; err != nil {
+			return err
+		}
+		index := int(count)
+		if position != nil && *position < index {
+			index = *position
+		}
+
+		if err := tx.Model(&ReadingListItem{}).Where("list_id = ? AND position >= ?", listID, index).
+			UpdateColumn("position", gorm.Expr("position + 1")).Error; err != nil {
+			return err
+		}
+		item := ReadingListItem{ListID: listID, BookID: bookID, Position: index}
+		return tx.Omit(clause.Associations).Create(&item).Error
+	})
+}
+
+// RemoveBook takes the book off the list and closes the gap it leaves
+func (r *readingListRepository) RemoveBook(c *fiber.Ctx, listID, bookID uuid.UUID) error {
+	return r.db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockList(tx, listID); err != nil {
+			return err
+		}
+
+		var item ReadingListItem
+		if err := tx.Where("list_id = ? AND book_id = ?", listID, bookID).First(&item).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&ReadingListItem{}, "list_id = ? AND book_id = ?", listID, bookID).Error; err != nil {
+			return err
+		}
+		return tx.Model(&ReadingListItem{}).Where("list_id = ? AND position > ?", listID, item.Position).
+			UpdateColumn("position", gorm.Expr("position - 1")).Error
+	})
+}
+
+// ReorderBooks rewrites every position, bookIDs must hold exactly the books already on the list
+func (r *readingListRepository) ReorderBooks(c *fiber.Ctx, listID uuid.UUID, bookIDs []uuid.UUID) error {
+	return r.db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockList(tx, listID); err != nil {
+			return err
+		}
+
+		var current []uuid.UUID
+		if err := tx.Model(&ReadingListItem{}).Where("list_id = ?", listID).Pluck("book_id", &current).Error; err != nil {
+			return err
+		}
+		if !SameBooks(current, bookIDs) {
+			return ErrInvalidOrder
+		}
+
+		for position, bookID := range bookIDs {
+			if err := tx.Model(&ReadingListItem{}).Where("list_id = ? AND book_id = ?", listID, bookID).
+				UpdateColumn("position", position).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *readingListRepository) FindUserProgress(c *fiber.Ctx, userID uuid.UUID) ([]ReadingProgress, error) {
+	var progress []ReadingProgress
+	if err := r.db.DB.Preload("Book").Where("user_id = ?", userID).Order("updated_at DESC").Find(&progress).Error; err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+func (r *readingListRepository) FindProgress(c *fiber.Ctx, userID, bookID uuid.UUID) (ReadingProgress, error) {
+	var progress ReadingProgress
+	if err := r.db.DB.Preload("Book").Where("user_id = ? AND book_id = ?", userID, bookID).First(&progress).Error; err != nil {
+		return ReadingProgress{}, err
+	}
+	return progress, nil
+}
+
+// SaveProgress creates or replaces the progress row for the user and book
+func (r *readingListRepository) SaveProgress(c *fiber.Ctx, progress ReadingProgress) error {
+	return r.db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Select("id").Where("id = ?", progress.BookID).First(&book.Book{}).Error; err != nil {
+			return err
+		}
+		return tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "book_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"current_page", "percentage", "started_at", "finished_at", "updated_at"}),
+		}).Create(&progress).Error
+	})
+}
+
+func (r *readingListRepository) DeleteProgress(c *fiber.Ctx, userID, bookID uuid.UUID) error {
+	result := r.db.DB.Delete(&ReadingProgress{}, "user_id = ? AND book_id = ?", userID, bookID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// lockList serialises changes to the positions of one list
+func lockList(tx *gorm.DB, listID uuid.UUID) error {
+	var locked ReadingList
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", listID).First(&locked).Error
+}
@@ -0,0 +1,30 @@
+package readinglist
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/middleware"
+)
+
+func RegisterRoutes(cfg *config.Config, router fiber.Router, handler *ReadingListHandler) {
+	// Public routes - read-only view of a list shared by its owner
+	router.Get("/lists/shared/:token", handler.GetSharedList)
+
+	// User authenticated routes - every user manages their own lists and progress
+	meGroup := router.Group("/users/me")
+	{
+		meGroup.Get("/lists", middleware.JWTMiddleware(cfg), handler.GetLists)
+		meGroup.Post("/lists", middleware.JWTMiddleware(cfg), handler.CreateList)
+		meGroup.Get("/lists/:id", middleware.JWTMiddleware(cfg), handler.GetList)
+		meGroup.Patch("/lists/:id", middleware.JWTMiddleware(cfg), handler.UpdateList)
+		meGroup.Delete("/lists/:id", middleware.JWTMiddleware(cfg), handler.DeleteList)
+		meGroup.Post("/lists/:id/books", middleware.JWTMiddleware(cfg), handler.AddBook)
+		meGroup.Put("/lists/:id/books", middleware.JWTMiddleware(cfg), handler.ReorderBooks)
+		meGroup.Delete("/lists/:id/books/:bookId", middleware.JWTMiddleware(cfg), handler.RemoveBook)
+
+		meGroup.Get("/progress", middleware.JWTMiddleware(cfg), handler.GetProgress)
+		meGroup.Get("/progress/:bookId", middleware.JWTMiddleware(cfg), handler.GetBookProgress)
+		meGroup.Put("/progress/:bookId", middleware.JWTMiddleware(cfg), handler.SetProgress)
+		meGroup.Delete("/progress/:bookId", middleware.JWTMiddleware(cfg), handler.DeleteProgress)
+	}
+}
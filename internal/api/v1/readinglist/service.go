@@ -0,0 +1,245 @@
+package readinglist
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/pkg/activity"
+	"github.com/jerpsp/go-fiber-beginner/pkg/patch"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const dateLayout = "2006-01-02"
+
+// Setup
+type ReadingListService interface {
+	GetLists(c *fiber.Ctx, userID uuid.UUID) ([]ReadingList, error)
+	GetList(c *fiber.Ctx, listID, userID uuid.UUID) (ReadingList, error)
+	GetSharedList(c *fiber.Ctx, token uuid.UUID) (ReadingList, error)
+	CreateList(c *fiber.Ctx, userID uuid.UUID, listParams ReadingListRequest) (ReadingList, error)
+	UpdateList(c *fiber.Ctx, listID, userID uuid.UUID, contentType string, body []byte) (ReadingList, error)
+	DeleteList(c *fiber.Ctx, listID, userID uuid.UUID) error
+	AddBook(c *fiber.Ctx, listID, userID uuid.UUID, bookParams ListBookRequest) (ReadingList, error)
+	RemoveBook(c *fiber.Ctx, listID, userID, bookID uuid.UUID) (ReadingList, error)
+	ReorderBooks(c *fiber.Ctx, listID, userID uuid.UUID, orderParams ReorderRequest) (ReadingList, error)
+	GetProgress(c *fiber.Ctx, userID uuid.UUID) ([]ReadingProgress, error)
+	GetBookProgress(c *fiber.Ctx, userID, bookID uuid.UUID) (ReadingProgress, error)
+	SetProgress(c *fiber.Ctx, userID, bookID uuid.UUID, progressParams ProgressRequest) (ReadingProgress, error)
+	DeleteProgress(c *fiber.Ctx, userID, bookID uuid.UUID) error
+}
+
+type readingListService struct {
+	config   *config.Config
+	repo     ReadingListRepository
+	activity activity.Counter
+}
+
+func NewReadingListService(config *config.Config, repo ReadingListRepository, activityCounter activity.Counter) ReadingListService {
+	return &readingListService{config: config, repo: repo, activity: activityCounter}
+}
+
+// Service methods
+func (s *readingListService) GetLists(c *fiber.Ctx, userID uuid.UUID) ([]ReadingList, error) {
+	if err := s.repo.EnsureShelves(c, userID); err != nil {
+		return nil, err
+	}
+	lists, err := s.repo.FindUserLists(c, userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range lists {
+		s.setShareURL(c, &lists[i])
+	}
+	return lists, nil
+}
+
+func (s *readingListService) GetList(c *fiber.Ctx, listID, userID uuid.UUID) (ReadingList, error) {
+	list, err := s.ownedList(c, listID, userID)
+	if err != nil {
+		return ReadingList{}, err
+	}
+	s.setShareURL(c, &list)
+	return list, nil
+}
+
+// GetSharedList returns a public list by its share token, private lists look like they do not exist
+func (s *readingListService) GetSharedList(c *fiber.Ctx, token uuid.UUID) (ReadingList, error) {
+	list, err := s.repo.FindListByShareToken(c, token)
+	if err != nil {
+		return ReadingList{}, err
+	}
+	if list.Visibility != VisibilityPublic {
+		return ReadingList{}, gorm.ErrRecordNotFound
+	}
+	s.setShareURL(c, &list)
+	return list, nil
+}
+
+func (s *readingListService) CreateList(c *fiber.Ctx, userID uuid.UUID, listParams ReadingListRequest) (ReadingList, error) {
+	listParams.normalize()
+	if err := utils.Validate(&listParams); err != nil {
+		return ReadingList{}, fmt.Errorf("%w: %v", ErrInvalidList, err)
+	}
+
+	newList := ReadingList{UserID: userID, Name: listParams.Name, Description: listParams.Description, Visibility: listParams.Visibility}
+	createdList, err := s.repo.CreateList(c, newList)
+	if err != nil {
+		return ReadingList{}, err
+	}
+	return s.GetList(c, createdList.ID, userID)
+}
+
+// UpdateList applies a merge patch or JSON patch to the list, shelves keep their name and description
+func (s *readingListService) UpdateList(c *fiber.Ctx, listID, userID uuid.UUID, contentType string, body []byte) (ReadingList, error) {
+	list, err := s.ownedList(c, listID, userID)
+	if err != nil {
+		return ReadingList{}, err
+	}
+
+	current := ReadingListRequest{Name: list.Name, Description: list.Description, Visibility: list.Visibility}
+	patched := current
+	if err := patch.Apply(&patched, contentType, body); err != nil {
+		return ReadingList{}, err
+	}
+	patched.normalize()
+	if err := utils.Validate(&patched); err != nil {
+		return ReadingList{}, fmt.Errorf("%w: %v", ErrInvalidList, err)
+	}
+
+	changes := patch.Changes(current, patched)
+	if list.IsShelf() {
+		for column := range changes {
+			if column != "visibility" {
+				return ReadingList{}, ErrShelfLocked
+			}
+		}
+	}
+	if len(changes) > 0 {
+		if err := s.repo.UpdateListFields(c, listID, changes); err != nil {
+			return ReadingList{}, err
+		}
+	}
+	return s.GetList(c, listID, userID)
+}
+
+func (s *readingListService) DeleteList(c *fiber.Ctx, listID, userID uuid.UUID) error {
+	list, err := s.ownedList(c, listID, userID)
+	if err != nil {
+		return err
+	}
+	if list.IsShelf() {
+		return ErrShelfLocked
+	}
+	return s.repo.DeleteList(c, listID)
+}
+
+func (s *readingListService) AddBook(c *fiber.Ctx, listID, userID uuid.UUID, bookParams ListBookRequest) (ReadingList, error) {
+	if err := utils.Validate(&bookParams); err != nil {
+		return ReadingList{}, fmt.Errorf("%w: %v", ErrInvalidList, err)
+	}
+	if _, err := s.ownedList(c, listID, userID); err != nil {
+		return ReadingList{}, err
+	}
+	if err := s.repo.AddBook(c, listID, bookParams.BookID, bookParams.Position); err != nil {
+		return ReadingList{}, err
+	}
+	// the catalogue has no separate favourites, putting a book on a list is what counts as one
+	s.activity.Record(c.UserContext(), activity.EventFavourite, bookParams.BookID)
+	return s.GetList(c, listID, userID)
+}
+
+func (s *readingListService) RemoveBook(c *fiber.Ctx, listID, userID, bookID uuid.UUID) (ReadingList, error) {
+	if _, err := s.ownedList(c, listID, userID); err != nil {
+		return ReadingList{}, err
+	}
+	if err := s.repo.RemoveBook(c, listID, bookID); err != nil {
+		return ReadingList{}, err
+	}
+	return s.GetList(c, listID, userID)
+}
+
+func (s *readingListService) ReorderBooks(c *fiber.Ctx, listID, userID uuid.UUID, orderParams ReorderRequest) (ReadingList, error) {
+	if _, err := s.ownedList(c, listID, userID); err != nil {
+		return ReadingList{}, err
+	}
+	if err := s.repo.ReorderBooks(c, listID, orderParams.BookIDs); err != nil {
+		return ReadingList{}, err
+	}
+	return s.GetList(c, listID, userID)
+}
+
+func (s *readingListService) GetProgress(c *fiber.Ctx, userID uuid.UUID) ([]ReadingProgress, error) {
+	return s.repo.FindUserProgress(c, userID)
+}
+
+func (s *readingListService) GetBookProgress(c *fiber.Ctx, userID, bookID uuid.UUID) (ReadingProgress, error) {
+	return s.repo.FindProgress(c, userID, bookID)
+}
+
+// SetProgress replaces the user's progress on a book, a finished date cannot come before the start date
+func (s *readingListService) SetProgress(c *fiber.Ctx, userID, bookID uuid.UUID, progressParams ProgressRequest) (ReadingProgress, error) {
+	if err := utils.Validate(&progressParams); err != nil {
+		return ReadingProgress{}, fmt.Errorf("%w: %v", ErrInvalidProgress, err)
+	}
+
+	progress := ReadingProgress{
+		UserID:      userID,
+		BookID:      bookID,
+		CurrentPage: progressParams.CurrentPage,
+		Percentage:  progressParams.Percentage,
+		StartedAt:   parseDate(progressParams.StartedAt),
+		FinishedAt:  parseDate(progressParams.FinishedAt),
+	}
+	if progress.StartedAt != nil && progress.FinishedAt != nil && progress.FinishedAt.Before(*progress.StartedAt) {
+		return ReadingProgress{}, fmt.Errorf("%w: finished_at is before started_at", ErrInvalidProgress)
+	}
+
+	if err := s.repo.SaveProgress(c, progress); err != nil {
+		return ReadingProgress{}, err
+	}
+	return s.repo.FindProgress(c, userID, bookID)
+}
+
+func (s *readingListService) DeleteProgress(c *fiber.Ctx, userID, bookID uuid.UUID) error {
+	return s.repo.DeleteProgress(c, userID, bookID)
+}
+
+// ownedList loads a list of the user, lists of other users are reported as not found
+func (s *readingListService) ownedList(c *fiber.Ctx, listID, userID uuid.UUID) (ReadingList, error) {
+	list, err := s.repo.FindListByID(c, listID)
+	if err != nil {
+		return ReadingList{}, err
+	}
+	if list.UserID != userID {
+		return ReadingList{}, gorm.ErrRecordNotFound
+	}
+	return list, nil
+}
+
+func (s *readingListService) setShareURL(c *fiber.Ctx, list *ReadingList) {
+	if list.Visibility == VisibilityPublic {
+		list.ShareURL = fmt.Sprintf("%s/api/v1/lists/shared/%s", c.BaseURL(), list.ShareToken)
+	}
+}
+
+func (r *ReadingListRequest) normalize() {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Description = strings.TrimSpace(r.Description)
+	if r.Visibility == "" {
+		r.Visibility = VisibilityPrivate
+	}
+}
+
+// parseDate expects an already validated YYYY-MM-DD string, an empty string clears the date
+func parseDate(value string) *time.Time {
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return nil
+	}
+	return &date
+}
@@ -0,0 +1,5 @@
+package recommendation
+
+type RecommendationFilter struct {
+	Limit int `query:"limit"`
+}
@@ -0,0 +1,55 @@
+package recommendation
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"gorm.io/gorm"
+)
+
+type RecommendationHandler struct {
+	config  *config.Config
+	service RecommendationService
+}
+
+func NewRecommendationHandler(config *config.Config, service RecommendationService) *RecommendationHandler {
+	return &RecommendationHandler{config: config, service: service}
+}
+
+func (h *RecommendationHandler) GetSimilarBooks(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	var filter RecommendationFilter
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid query parameters"})
+	}
+
+	similar, err := h.service.GetSimilarBooks(c, bookID, filter)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"similar": similar})
+}
+
+func (h *RecommendationHandler) GetRecommendations(c *fiber.Ctx) error {
+	userID, _ := c.Locals("userID").(uuid.UUID)
+
+	var filter RecommendationFilter
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid query parameters"})
+	}
+
+	recommendations, err := h.service.GetUserRecommendations(c, userID, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"recommendations": recommendations})
+}
@@ -0,0 +1,40 @@
+package recommendation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/user"
+)
+
+// BookSimilarity is one precomputed neighbour of a book, Rank 1 being the most similar. The table is rebuilt in
+// full by the recommendation job.
+type BookSimilarity struct {
+	BookID    uuid.UUID `gorm:"type:uuid;primaryKey;index:idx_book_similarities_book_rank,priority:1" json:"-"`
+	SimilarID uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"-"`
+	Rank      int       `gorm:"not null;index:idx_book_similarities_book_rank,priority:2" json:"rank"`
+	Score     float64   `gorm:"not null" json:"score"`
+	Book      book.Book `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE" json:"-"`
+	Similar   book.Book `gorm:"foreignKey:SimilarID;constraint:OnDelete:CASCADE" json:"book"`
+	CreatedAt time.Time `json:"computed_at"`
+}
+
+// UserRecommendation is one precomputed recommendation for a user, rebuilt along with the similarities
+type UserRecommendation struct {
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey;index:idx_user_recommendations_user_rank,priority:1" json:"-"`
+	BookID    uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"-"`
+	Rank      int       `gorm:"not null;index:idx_user_recommendations_user_rank,priority:2" json:"rank"`
+	Score     float64   `gorm:"not null" json:"score"`
+	User      user.User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Book      book.Book `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE" json:"book"`
+	CreatedAt time.Time `json:"computed_at"`
+}
+
+// RebuildReport sums up a run of the recommendation job
+type RebuildReport struct {
+	Books           int
+	Similarities    int
+	Users           int
+	Recommendations int
+}
@@ -0,0 +1,118 @@
+package recommendation
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+	"github.com/jerpsp/go-fiber-beginner/pkg/database"
+	"gorm.io/gorm"
+)
+
+// The recommendation job writes its tables in batches of this size
+const rebuildBatchSize = 1000
+
+type RecommendationRepository interface {
+	FindSignals(c *fiber.Ctx) (Signals, error)
+	ReplaceAll(c *fiber.Ctx, similarities []BookSimilarity, recommendations []UserRecommendation) error
+	FindSimilarBooks(c *fiber.Ctx, bookID uuid.UUID, limit int) ([]BookSimilarity, error)
+	FindUserRecommendations(c *fiber.Ctx, userID uuid.UUID, limit int) ([]UserRecommendation, error)
+}
+
+type recommendationRepository struct {
+	config *config.Config
+	db     *database.GormDB
+}
+
+func NewRecommendationRepository(cfg *config.Config, db *database.GormDB) RecommendationRepository {
+	return &recommendationRepository{config: cfg, db: db}
+}
+
+// Repository methods
+
+// userBooksQuery selects every (user, book) a user has read or meant to read, from reading lists and loans.
+// The tables belong to the readinglist and circulation packages.
+const userBooksQuery = `SELECT reading_lists.user_id, reading_list_items.book_id
+	FROM reading_list_items JOIN reading_lists ON reading_lists.id = reading_list_items.list_id
+	UNION
+	SELECT loans.user_id, copies.book_id FROM loans JOIN copies ON copies.id = loans.copy_id`
+
+type pair struct {
+	Owner  uuid.UUID
+	Member uuid.UUID
+}
+
+func (r *recommendationRepository) findPairs(query string) (map[uuid.UUID][]uuid.UUID, error) {
+	var pairs []pair
+	if err := r.db.DB.Raw(query).Scan(&pairs).Error; err != nil {
+		return nil, err
+	}
+	grouped := make(map[uuid.UUID][]uuid.UUID)
+	for _, p := range pairs {
+		grouped[p.Owner] = append(grouped[p.Owner], p.Member)
+	}
+	return grouped, nil
+}
+
+func (r *recommendationRepository) FindSignals(c *fiber.Ctx) (Signals, error) {
+	var signals Signals
+	var err error
+	if signals.Authors, err = r.findPairs(`SELECT book_id AS owner, author_id AS member FROM book_authors`); err != nil {
+		return Signals{}, err
+	}
+	if signals.Categories, err = r.findPairs(`SELECT book_id AS owner, category_id AS member FROM book_categories`); err != nil {
+		return Signals{}, err
+	}
+	if signals.Readers, err = r.findPairs(`SELECT user_id AS owner, book_id AS member FROM (` + userBooksQuery + `) AS user_books`); err != nil {
+		return Signals{}, err
+	}
+	return signals, nil
+}
+
+// ReplaceAll swaps the precomputed tables in one transaction, readers see either the old or the new run
+func (r *recommendationRepository) ReplaceAll(c *fiber.Ctx, similarities []BookSimilarity, recommendations []UserRecommendation) error {
+	return r.db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&BookSimilarity{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("1 = 1").Delete(&UserRecommendation{}).Error; err != nil {
+			return err
+		}
+		if len(similarities) > 0 {
+			if err := tx.Omit("Book", "Similar").CreateInBatches(similarities, rebuildBatchSize).Error; err != nil {
+				return err
+			}
+		}
+		if len(recommendations) > 0 {
+			if err := tx.Omit("User", "Book").CreateInBatches(recommendations, rebuildBatchSize).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *recommendationRepository) FindSimilarBooks(c *fiber.Ctx, bookID uuid.UUID, limit int) ([]BookSimilarity, error) {
+	if err := r.db.DB.Select("id").Where("id = ?", bookID).First(&book.Book{}).Error; err != nil {
+		return nil, err
+	}
+
+	var similarities []BookSimilarity
+	err := r.db.DB.Preload("Similar").Where("book_id = ?", bookID).Order("rank").Limit(limit).Find(&similarities).Error
+	if err != nil {
+		return nil, err
+	}
+	return similarities, nil
+}
+
+// FindUserRecommendations leaves out books the user listed or borrowed since the last run
+func (r *recommendationRepository) FindUserRecommendations(c *fiber.Ctx, userID uuid.UUID, limit int) ([]UserRecommendation, error) {
+	var recommendations []UserRecommendation
+	err := r.db.DB.Preload("Book").Where("user_id = ?", userID).
+		Where("book_id NOT IN (SELECT book_id FROM ("+userBooksQuery+") AS user_books WHERE user_id = ?)", userID).
+		Order("rank").Limit(limit).Find(&recommendations).Error
+	if err != nil {
+		return nil, err
+	}
+	return recommendations, nil
+}
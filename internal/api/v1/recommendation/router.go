@@ -0,0 +1,15 @@
+package recommendation
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/middleware"
+)
+
+func RegisterRoutes(cfg *config.Config, router fiber.Router, handler *RecommendationHandler) {
+	// Public routes - similar books are the same for everyone
+	router.Get("/books/:id/similar", handler.GetSimilarBooks)
+
+	// User authenticated routes - recommendations are personal
+	router.Get("/users/me/recommendations", middleware.JWTMiddleware(cfg), handler.GetRecommendations)
+}
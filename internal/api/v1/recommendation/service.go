@@ -0,0 +1,85 @@
+package recommendation
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+)
+
+// How many neighbours per book and recommendations per user the job keeps, reads are capped at these
+const (
+	maxSimilarBooks     = 20
+	maxRecommendations  = 50
+	defaultReadingLimit = 10
+)
+
+// Setup
+type RecommendationService interface {
+	GetSimilarBooks(c *fiber.Ctx, bookID uuid.UUID, filter RecommendationFilter) ([]BookSimilarity, error)
+	GetUserRecommendations(c *fiber.Ctx, userID uuid.UUID, filter RecommendationFilter) ([]UserRecommendation, error)
+	Rebuild(c *fiber.Ctx) (RebuildReport, error)
+}
+
+type recommendationService struct {
+	config *config.Config
+	repo   RecommendationRepository
+}
+
+func NewRecommendationService(config *config.Config, repo RecommendationRepository) RecommendationService {
+	return &recommendationService{config: config, repo: repo}
+}
+
+// Service methods
+func (s *recommendationService) GetSimilarBooks(c *fiber.Ctx, bookID uuid.UUID, filter RecommendationFilter) ([]BookSimilarity, error) {
+	return s.repo.FindSimilarBooks(c, bookID, readingLimit(filter.Limit, maxSimilarBooks))
+}
+
+func (s *recommendationService) GetUserRecommendations(c *fiber.Ctx, userID uuid.UUID, filter RecommendationFilter) ([]UserRecommendation, error) {
+	return s.repo.FindUserRecommendations(c, userID, readingLimit(filter.Limit, maxRecommendations))
+}
+
+// Rebuild is the scheduled recommendation run. It recomputes the neighbours of every book and the
+// recommendations of every user from scratch and replaces the previous run.
+func (s *recommendationService) Rebuild(c *fiber.Ctx) (RebuildReport, error) {
+	signals, err := s.repo.FindSignals(c)
+	if err != nil {
+		return RebuildReport{}, err
+	}
+
+	similarities := ComputeSimilarities(signals, maxSimilarBooks)
+	recommendations := RecommendForUsers(signals, similarities, maxRecommendations)
+
+	now := time.Now()
+	report := RebuildReport{Books: len(similarities), Users: len(recommendations)}
+	var similarityRows []BookSimilarity
+	for bookID, neighbours := range similarities {
+		for i, neighbour := range neighbours {
+			similarityRows = append(similarityRows, BookSimilarity{BookID: bookID, SimilarID: neighbour.BookID, Rank: i + 1, Score: neighbour.Score, CreatedAt: now})
+		}
+	}
+	var recommendationRows []UserRecommendation
+	for userID, neighbours := range recommendations {
+		for i, neighbour := range neighbours {
+			recommendationRows = append(recommendationRows, UserRecommendation{UserID: userID, BookID: neighbour.BookID, Rank: i + 1, Score: neighbour.Score, CreatedAt: now})
+		}
+	}
+	report.Similarities = len(similarityRows)
+	report.Recommendations = len(recommendationRows)
+
+	if err := s.repo.ReplaceAll(c, similarityRows, recommendationRows); err != nil {
+		return RebuildReport{}, err
+	}
+	return report, nil
+}
+
+func readingLimit(limit, max int) int {
+	if limit <= 0 {
+		return defaultReadingLimit
+	}
+	if limit > max {
+		return max
+	}
+	return limit
+}
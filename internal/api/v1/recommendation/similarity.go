@@ -0,0 +1,184 @@
+package recommendation
+
+import (
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// Weights of the three signals in a similarity score, they add up to 1 so scores stay between 0 and 1
+const (
+	authorWeight       = 0.4
+	categoryWeight     = 0.3
+	coOccurrenceWeight = 0.3
+)
+
+// Signals is everything the similarity is computed from
+type Signals struct {
+	// Authors and Categories map a book to the authors credited on it and the categories it is filed under
+	Authors    map[uuid.UUID][]uuid.UUID
+	Categories map[uuid.UUID][]uuid.UUID
+	// Readers maps a user to the books they put on a reading list or borrowed
+	Readers map[uuid.UUID][]uuid.UUID
+}
+
+// Neighbour is a scored book, either a similar book or a recommendation
+type Neighbour struct {
+	BookID uuid.UUID
+	Score  float64
+}
+
+type idSet map[uuid.UUID]struct{}
+
+func newIDSet(ids []uuid.UUID) idSet {
+	set := make(idSet, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+	return set
+}
+
+func (s idSet) intersection(other idSet) int {
+	if len(other) < len(s) {
+		s, other = other, s
+	}
+	n := 0
+	for id := range s {
+		if _, ok := other[id]; ok {
+			n++
+		}
+	}
+	return n
+}
+
+// jaccard is the share of the union two sets have in common
+func jaccard(a, b idSet) float64 {
+	common := a.intersection(b)
+	if common == 0 {
+		return 0
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
+
+// cosine is the co-occurrence of two books, the readers they share relative to how widely each is read
+func cosine(a, b idSet) float64 {
+	common := a.intersection(b)
+	if common == 0 {
+		return 0
+	}
+	return float64(common) / math.Sqrt(float64(len(a))*float64(len(b)))
+}
+
+// invert turns an owner to members map into a member to owners map
+func invert(m map[uuid.UUID][]uuid.UUID) map[uuid.UUID]idSet {
+	inverted := make(map[uuid.UUID]idSet)
+	for owner, members := range m {
+		for _, member := range members {
+			if inverted[member] == nil {
+				inverted[member] = idSet{}
+			}
+			inverted[member][owner] = struct{}{}
+		}
+	}
+	return inverted
+}
+
+func toSets(m map[uuid.UUID][]uuid.UUID) map[uuid.UUID]idSet {
+	sets := make(map[uuid.UUID]idSet, len(m))
+	for key, ids := range m {
+		sets[key] = newIDSet(ids)
+	}
+	return sets
+}
+
+// ComputeSimilarities scores every pair of books sharing an author, a category or a reader and keeps the limit
+// best neighbours of each book. Books with nothing in common are never compared.
+func ComputeSimilarities(signals Signals, limit int) map[uuid.UUID][]Neighbour {
+	authors := toSets(signals.Authors)
+	categories := toSets(signals.Categories)
+	readers := invert(signals.Readers)
+
+	// candidates are found through the inverted indexes instead of comparing every book with every other
+	byAuthor := invert(signals.Authors)
+	byCategory := invert(signals.Categories)
+	byReader := toSets(signals.Readers)
+
+	books := idSet{}
+	for _, index := range []map[uuid.UUID]idSet{authors, categories, readers} {
+		for bookID := range index {
+			books[bookID] = struct{}{}
+		}
+	}
+
+	similarities := make(map[uuid.UUID][]Neighbour, len(books))
+	for bookID := range books {
+		candidates := idSet{}
+		collect := func(keys idSet, index map[uuid.UUID]idSet) {
+			for key := range keys {
+				for candidate := range index[key] {
+					candidates[candidate] = struct{}{}
+				}
+			}
+		}
+		collect(authors[bookID], byAuthor)
+		collect(categories[bookID], byCategory)
+		collect(readers[bookID], byReader)
+		delete(candidates, bookID)
+
+		neighbours := make([]Neighbour, 0, len(candidates))
+		for candidate := range candidates {
+			score := authorWeight*jaccard(authors[bookID], authors[candidate]) +
+				categoryWeight*jaccard(categories[bookID], categories[candidate]) +
+				coOccurrenceWeight*cosine(readers[bookID], readers[candidate])
+			if score > 0 {
+				neighbours = append(neighbours, Neighbour{BookID: candidate, Score: score})
+			}
+		}
+		if len(neighbours) > 0 {
+			similarities[bookID] = topNeighbours(neighbours, limit)
+		}
+	}
+	return similarities
+}
+
+// RecommendForUsers ranks the books a user has not read yet by how similar they are to the books they did read,
+// a book close to several of them adds up the similarity to each
+func RecommendForUsers(signals Signals, similarities map[uuid.UUID][]Neighbour, limit int) map[uuid.UUID][]Neighbour {
+	recommendations := make(map[uuid.UUID][]Neighbour, len(signals.Readers))
+	for userID, bookIDs := range signals.Readers {
+		seen := newIDSet(bookIDs)
+		scores := make(map[uuid.UUID]float64)
+		for bookID := range seen {
+			for _, neighbour := range similarities[bookID] {
+				if _, ok := seen[neighbour.BookID]; !ok {
+					scores[neighbour.BookID] += neighbour.Score
+				}
+			}
+		}
+		if len(scores) == 0 {
+			continue
+		}
+
+		neighbours := make([]Neighbour, 0, len(scores))
+		for bookID, score := range scores {
+			neighbours = append(neighbours, Neighbour{BookID: bookID, Score: score})
+		}
+		recommendations[userID] = topNeighbours(neighbours, limit)
+	}
+	return recommendations
+}
+
+// topNeighbours sorts by score, ties broken by ID so every run writes the same ranking
+func topNeighbours(neighbours []Neighbour, limit int) []Neighbour {
+	sort.Slice(neighbours, func(i, j int) bool {
+		if neighbours[i].Score != neighbours[j].Score {
+			return neighbours[i].Score > neighbours[j].Score
+		}
+		return neighbours[i].BookID.String() < neighbours[j].BookID.String()
+	})
+	if len(neighbours) > limit {
+		neighbours = neighbours[:limit]
+	}
+	return neighbours
+}
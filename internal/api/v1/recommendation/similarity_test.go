@@ -0,0 +1,128 @@
+package recommendation_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/recommendation"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type SimilaritySuite struct {
+	suite.Suite
+	tolkien, lewis, fantasy, scifi uuid.UUID
+	hobbit, lotr, narnia, dune     uuid.UUID
+	alice, bob                     uuid.UUID
+	signals                        recommendation.Signals
+}
+
+// SetupTest builds a small catalogue: two Tolkien books, a Lewis book and Dune, read by two users
+func (s *SimilaritySuite) SetupTest() {
+	s.tolkien, s.lewis, s.fantasy, s.scifi = uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	s.hobbit, s.lotr, s.narnia, s.dune = uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	s.alice, s.bob = uuid.New(), uuid.New()
+
+	s.signals = recommendation.Signals{
+		Authors: map[uuid.UUID][]uuid.UUID{
+			s.hobbit: {s.tolkien},
+			s.lotr:   {s.tolkien},
+			s.narnia: {s.lewis},
+		},
+		Categories: map[uuid.UUID][]uuid.UUID{
+			s.hobbit: {s.fantasy},
+			s.lotr:   {s.fantasy},
+			s.narnia: {s.fantasy},
+			s.dune:   {s.scifi},
+		},
+		Readers: map[uuid.UUID][]uuid.UUID{
+			s.alice: {s.hobbit, s.narnia},
+			s.bob:   {s.hobbit, s.dune},
+		},
+	}
+}
+
+func TestSimilaritySuite(t *testing.T) {
+	suite.Run(t, new(SimilaritySuite))
+}
+
+func (s *SimilaritySuite) scoreOf(neighbours []recommendation.Neighbour, bookID uuid.UUID) float64 {
+	for _, neighbour := range neighbours {
+		if neighbour.BookID == bookID {
+			return neighbour.Score
+		}
+	}
+	return 0
+}
+
+func (s *SimilaritySuite) TestComputeSimilarities1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Shared Author Ranks Above Shared Category", 34)
+
+	similarities := recommendation.ComputeSimilarities(s.signals, 10)
+
+	hobbit := similarities[s.hobbit]
+	s.Require().Len(hobbit, 3)
+	s.Equal(s.lotr, hobbit[0].BookID)
+	// same author and category, no shared reader
+	s.InDelta(0.7, s.scoreOf(hobbit, s.lotr), 1e-9)
+	// same category, alice read both: 0.3 + 0.3 * 1/sqrt(2*1)
+	s.InDelta(0.3+0.3/1.4142135623730951, s.scoreOf(hobbit, s.narnia), 1e-9)
+	// only bob in common: 0.3 * 1/sqrt(2*1)
+	s.InDelta(0.3/1.4142135623730951, s.scoreOf(hobbit, s.dune), 1e-9)
+}
+
+func (s *SimilaritySuite) TestComputeSimilarities2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Symmetric Scores And Limit", 34)
+
+	similarities := recommendation.ComputeSimilarities(s.signals, 1)
+
+	s.Len(similarities[s.hobbit], 1)
+	s.Equal(s.lotr, similarities[s.hobbit][0].BookID)
+	s.Equal(s.hobbit, similarities[s.lotr][0].BookID)
+	s.InDelta(similarities[s.hobbit][0].Score, similarities[s.lotr][0].Score, 1e-9)
+}
+
+func (s *SimilaritySuite) TestComputeSimilarities3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Book Without Anything In Common", 31)
+
+	lonely := uuid.New()
+	s.signals.Categories[lonely] = []uuid.UUID{uuid.New()}
+
+	similarities := recommendation.ComputeSimilarities(s.signals, 10)
+
+	s.NotContains(similarities, lonely)
+	s.Zero(s.scoreOf(similarities[s.dune], s.narnia))
+}
+
+func (s *SimilaritySuite) TestRecommendForUsers1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Recommend Unseen Books Only", 34)
+
+	similarities := recommendation.ComputeSimilarities(s.signals, 10)
+	recommendations := recommendation.RecommendForUsers(s.signals, similarities, 10)
+
+	alice := recommendations[s.alice]
+	s.Require().NotEmpty(alice)
+	// lotr is close to both the hobbit and narnia, so it beats dune
+	s.Equal(s.lotr, alice[0].BookID)
+	s.InDelta(s.scoreOf(similarities[s.hobbit], s.lotr)+s.scoreOf(similarities[s.narnia], s.lotr), alice[0].Score, 1e-9)
+	s.Zero(s.scoreOf(alice, s.hobbit))
+	s.Zero(s.scoreOf(alice, s.narnia))
+}
+
+func (s *SimilaritySuite) TestRecommendForUsers2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: User Who Read Everything Or Nothing", 31)
+
+	s.signals.Readers[s.alice] = []uuid.UUID{s.hobbit, s.lotr, s.narnia, s.dune}
+	s.signals.Readers[uuid.New()] = nil
+
+	similarities := recommendation.ComputeSimilarities(s.signals, 10)
+	recommendations := recommendation.RecommendForUsers(s.signals, similarities, 10)
+
+	s.NotContains(recommendations, s.alice)
+	s.Len(recommendations, 1)
+}
@@ -0,0 +1,43 @@
+package review
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AccountReviews removes the reviews of a deleted account together with their share of the book ratings
+type AccountReviews struct {
+	bookCache BookCache
+}
+
+func NewAccountReviews(bookCache BookCache) *AccountReviews {
+	return &AccountReviews{bookCache: bookCache}
+}
+
+// ReleaseAccount deletes the user's reviews and recomputes the ratings of the books they rated in the caller's
+// transaction. The books are locked in ID order, so two accounts deleted at once can't deadlock on them.
+func (a *AccountReviews) ReleaseAccount(c *fiber.Ctx, tx *gorm.DB, userID uuid.UUID) (func(), error) {
+	var bookIDs []uuid.UUID
+	if err := tx.Model(&Review{}).Where("user_id = ?", userID).Distinct().Order("book_id").Pluck("book_id", &bookIDs).Error; err != nil {
+		return nil, err
+	}
+	if len(bookIDs) == 0 {
+		return nil, nil
+	}
+
+	for _, bookID := range bookIDs {
+		if err := lockBook(tx, bookID); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Delete(&Review{}, "user_id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+	for _, bookID := range bookIDs {
+		if err := refreshBookRating(c, tx, bookID); err != nil {
+			return nil, err
+		}
+	}
+	return func() { a.bookCache.InvalidateBooks(c, bookIDs...) }, nil
+}
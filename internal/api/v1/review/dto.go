@@ -0,0 +1,27 @@
+package review
+
+const (
+	SortHelpful = "helpful"
+	SortNewest  = "newest"
+	SortOldest  = "oldest"
+)
+
+type ReviewRequest struct {
+	Rating int    `json:"rating" validate:"required,min=1,max=5"`
+	Title  string `json:"title" validate:"omitempty,max=255"`
+	Body   string `json:"body" validate:"omitempty,max=10000"`
+}
+
+type ReviewListRequest struct {
+	Page  int    `query:"page"`
+	Limit int    `query:"limit"`
+	Sort  string `query:"sort"`
+}
+
+type PaginatedResponse struct {
+	Reviews    []Review `json:"reviews"`
+	Total      int64    `json:"total"`
+	Page       int      `json:"page"`
+	PerPage    int      `json:"per_page"`
+	TotalPages int      `json:"total_pages"`
+}
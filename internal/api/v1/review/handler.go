@@ -0,0 +1,171 @@
+package review
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/middleware"
+	"github.com/jerpsp/go-fiber-beginner/pkg/patch"
+	"gorm.io/gorm"
+)
+
+type ReviewHandler struct {
+	config  *config.Config
+	service ReviewService
+}
+
+func NewReviewHandler(config *config.Config, service ReviewService) *ReviewHandler {
+	return &ReviewHandler{config: config, service: service}
+}
+
+// Handler methods
+func (h *ReviewHandler) GetBookReviews(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	request := ReviewListRequest{Page: 1, Limit: 10, Sort: SortNewest}
+	if err := c.QueryParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid pagination parameters"})
+	}
+	if request.Sort != SortHelpful && request.Sort != SortNewest && request.Sort != SortOldest {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid sort, expected helpful, newest or oldest"})
+	}
+
+	reviews, total, err := h.service.GetBookReviews(c, bookID, request.Page, request.Limit, request.Sort)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	if request.Page <= 0 {
+		request.Page = 1
+	}
+	if request.Limit <= 0 || request.Limit > 100 {
+		request.Limit = 10
+	}
+	totalPages := int(total) / request.Limit
+	if int(total)%request.Limit > 0 {
+		totalPages++
+	}
+
+	response := PaginatedResponse{
+		Reviews:    reviews,
+		Total:      total,
+		Page:       request.Page,
+		PerPage:    request.Limit,
+		TotalPages: totalPages,
+	}
+
+	return c.JSON(response)
+}
+
+func (h *ReviewHandler) CreateReview(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	var reviewParams ReviewRequest
+	if err := c.BodyParser(&reviewParams); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid input"})
+	}
+
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	review, err := h.service.CreateReview(c, bookID, userID, reviewParams)
+	if err != nil {
+		return reviewError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"review": review})
+}
+
+func (h *ReviewHandler) UpdateReview(c *fiber.Ctx) error {
+	reviewID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid review ID"})
+	}
+
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	review, err := h.service.UpdateReview(c, reviewID, userID, c.Get(fiber.HeaderContentType), c.Body())
+	if err != nil {
+		return reviewError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"review": review})
+}
+
+func (h *ReviewHandler) DeleteReview(c *fiber.Ctx) error {
+	reviewID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid review ID"})
+	}
+
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	role, _ := c.Locals("role").(string)
+	if err := h.service.DeleteReview(c, reviewID, userID, middleware.UserRole(role) == middleware.RoleAdmin); err != nil {
+		return reviewError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *ReviewHandler) HideReview(c *fiber.Ctx) error {
+	return h.setHidden(c, true)
+}
+
+func (h *ReviewHandler) UnhideReview(c *fiber.Ctx) error {
+	return h.setHidden(c, false)
+}
+
+func (h *ReviewHandler) setHidden(c *fiber.Ctx, hidden bool) error {
+	reviewID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid review ID"})
+	}
+
+	moderatorID, _ := c.Locals("userID").(uuid.UUID)
+	review, err := h.service.SetReviewHidden(c, reviewID, moderatorID, hidden)
+	if err != nil {
+		return reviewError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"review": review})
+}
+
+func (h *ReviewHandler) AddHelpfulVote(c *fiber.Ctx) error {
+	return h.vote(c, true)
+}
+
+func (h *ReviewHandler) RemoveHelpfulVote(c *fiber.Ctx) error {
+	return h.vote(c, false)
+}
+
+func (h *ReviewHandler) vote(c *fiber.Ctx, helpful bool) error {
+	reviewID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid review ID"})
+	}
+
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	review, err := h.service.VoteHelpful(c, reviewID, userID, helpful)
+	if err != nil {
+		return reviewError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"review": review})
+}
+
+// reviewError maps service errors to the matching HTTP status
+func reviewError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, patch.ErrUnsupportedMediaType):
+		return c.Status(fiber.StatusUnsupportedMediaType).JSON(fiber.Map{"message": err.Error()})
+	case errors.Is(err, ErrInvalidReview), errors.Is(err, patch.ErrInvalidPatch), errors.Is(err, ErrOwnReviewVote):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+	case errors.Is(err, ErrNotReviewAuthor):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"message": err.Error()})
+	case errors.Is(err, ErrAlreadyReviewed), errors.Is(err, patch.ErrTestFailed):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+}
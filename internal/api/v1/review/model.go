@@ -0,0 +1,45 @@
+package review
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+)
+
+// Review is one user's rating of a book, a user can review each book once
+type Review struct {
+	ID           uuid.UUID  `gorm:"type:uuid; default:uuid_generate_v4()" json:"id"`
+	BookID       uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_reviews_book_user;index:idx_reviews_book_helpful,priority:1" json:"book_id"`
+	UserID       uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_reviews_book_user" json:"user_id"`
+	Rating       int        `gorm:"not null;check:rating BETWEEN 1 AND 5" json:"rating"`
+	Title        string     `gorm:"size:255" json:"title"`
+	Body         string     `gorm:"type:text" json:"body"`
+	HelpfulCount int        `gorm:"not null;default:0;index:idx_reviews_book_helpful,priority:2" json:"helpful_count"`
+	Hidden       bool       `gorm:"not null;default:false" json:"hidden"`
+	HiddenBy     *uuid.UUID `gorm:"type:uuid" json:"hidden_by,omitempty"`
+	HiddenAt     *time.Time `json:"hidden_at,omitempty"`
+	Reviewer     Reviewer   `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"reviewer"`
+	Book         book.Book  `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// Reviewer is the public part of the user who wrote a review
+type Reviewer struct {
+	ID        uuid.UUID `json:"id"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+}
+
+func (Reviewer) TableName() string {
+	return "users"
+}
+
+// ReviewVote marks a review as helpful for one user
+type ReviewVote struct {
+	ReviewID  uuid.UUID `gorm:"type:uuid;primaryKey" json:"review_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	Review    Review    `gorm:"foreignKey:ReviewID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,183 @@
+package review
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+	"github.com/jerpsp/go-fiber-beginner/pkg/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReviewRepository interface {
+	FindBookReviews(c *fiber.Ctx, bookID uuid.UUID, page, perPage int, sort string) ([]Review, int64, error)
+	FindReviewByID(c *fiber.Ctx, reviewID uuid.UUID) (Review, error)
+	FindReviewByBookAndUser(c *fiber.Ctx, bookID, userID uuid.UUID) (Review, error)
+	CreateReview(c *fiber.Ctx, newReview Review) (Review, error)
+	UpdateReviewFields(c *fiber.Ctx, review Review, fields map[string]interface{}) error
+	DeleteReview(c *fiber.Ctx, review Review) error
+	AddVote(c *fiber.Ctx, reviewID, userID uuid.UUID) error
+	RemoveVote(c *fiber.Ctx, reviewID, userID uuid.UUID) error
+}
+
+type reviewRepository struct {
+	config *config.Config
+	db     *database.GormDB
+}
+
+func NewReviewRepository(cfg *config.Config, db *database.GormDB) ReviewRepository {
+	return &reviewRepository{config: cfg, db: db}
+}
+
+// Repository methods
+
+func (r *reviewRepository) FindBookReviews(c *fiber.Ctx, bookID uuid.UUID, page, perPage int, sort string) ([]Review, int64, error) {
+	var reviews []Review
+	var total int64
+
+	query := r.db.DB.Model(&Review{}).Where("book_id = ? AND NOT hidden", bookID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	switch sort {
+	case SortHelpful:
+		query = query.Order("helpful_count DESC").Order("created_at DESC")
+	case SortOldest:
+		query = query.Order("created_at ASC")
+	default:
+		query = query.Order("created_at DESC")
+	}
+
+	offset := (page - 1) * perPage
+	if err := query.Preload("Reviewer").Offset(offset).Limit(perPage).Find(&reviews).Error; err != nil {
+		return nil, 0, err
+	}
+	return reviews, total, nil
+}
+
+func (r *reviewRepository) FindReviewByID(c *fiber.Ctx, reviewID uuid.UUID) (Review, error) {
+	var review Review
+	if err := r.db.DB.Preload("Reviewer").Where("id = ?", reviewID).First(&review).Error; err != nil {
+		return Review{}, err
+	}
+	return review, nil
+}
+
+func (r *reviewRepository) FindReviewByBookAndUser(c *fiber.Ctx, bookID, userID uuid.UUID) (Review, error) {
+	var review Review
+	if err := r.db.DB.Where("book_id = ? AND user_id = ?", bookID, userID).First(&review).Error; err != nil {
+		return Review{}, err
+	}
+	return review, nil
+}
+
+func (r *reviewRepository) CreateReview(c *fiber.Ctx, newReview Review) (Review, error) {
+	err := r.db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockBook(tx, newReview.BookID); err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Create(&newReview).Error; err != nil {
+			return err
+		}
+		return refreshBookRating(c, tx, newReview.BookID)
+	})
+	if err != nil {
+		return Review{}, err
+	}
+	return newReview, nil
+}
+
+// UpdateReviewFields writes the given columns, hiding and rating changes are reflected in the book rating
+func (r *reviewRepository) UpdateReviewFields(c *fiber.Ctx, review Review, fields map[string]interface{}) error {
+	return r.db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockBook(tx, review.BookID); err != nil {
+			return err
+		}
+		result := tx.Model(&Review{}).Where("id = ?", review.ID).Updates(fields)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return refreshBookRating(c, tx, review.BookID)
+	})
+}
+
+func (r *reviewRepository) DeleteReview(c *fiber.Ctx, review Review) error {
+	return r.db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockBook(tx, review.BookID); err != nil {
+			return err
+		}
+		result := tx.Delete(&Review{}, "id = ?", review.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return refreshBookRating(c, tx, review.BookID)
+	})
+}
+
+// AddVote records a helpful vote once per user, voting twice is a no-op
+func (r *reviewRepository) AddVote(c *fiber.Ctx, reviewID, userID uuid.UUID) error {
+	return r.db.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&ReviewVote{ReviewID: reviewID, UserID: userID})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return tx.Model(&Review{}).Where("id = ?", reviewID).
+			UpdateColumn("helpful_count", gorm.Expr("helpful_count + 1")).Error
+	})
+}
+
+func (r *reviewRepository) RemoveVote(c *fiber.Ctx, reviewID, userID uuid.UUID) error {
+	return r.db.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&ReviewVote{}, "review_id = ? AND user_id = ?", reviewID, userID)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return tx.Model(&Review{}).Where("id = ?", reviewID).
+			UpdateColumn("helpful_count", gorm.Expr("helpful_count - 1")).Error
+	})
+}
+
+// lockBook serialises review writes per book so the recomputed rating always sees the other writers' rows
+func lockBook(tx *gorm.DB, bookID uuid.UUID) error {
+	var locked book.Book
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", bookID).First(&locked).Error
+}
+
+// refreshBookRating recomputes the denormalised rating of a book from its visible reviews,
+// a changed rating is a new version of the book so its ETag moves with it
+func refreshBookRating(c *fiber.Ctx, tx *gorm.DB, bookID uuid.UUID) error {
+	var before, after book.Book
+	if err := tx.Select("rating_average", "rating_count").Where("id = ?", bookID).First(&before).Error; err != nil {
+		return err
+	}
+	err := tx.Exec(`UPDATE books SET rating_count = stats.count, rating_average = stats.average
+		FROM (SELECT COUNT(*) AS count, COALESCE(AVG(rating), 0) AS average FROM reviews WHERE book_id = ? AND NOT hidden) AS stats
+		WHERE books.id = ?`, bookID, bookID).Error
+	if err != nil {
+		return err
+	}
+	if err := tx.Select("rating_average", "rating_count").Where("id = ?", bookID).First(&after).Error; err != nil {
+		return err
+	}
+
+	changes := book.FieldChanges{}
+	if before.RatingAverage != after.RatingAverage {
+		changes["rating_average"] = book.FieldChange{From: before.RatingAverage, To: after.RatingAverage}
+	}
+	if before.RatingCount != after.RatingCount {
+		changes["rating_count"] = book.FieldChange{From: before.RatingCount, To: after.RatingCount}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return book.BumpBookVersion(c, tx, bookID, changes)
+}
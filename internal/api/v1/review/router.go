@@ -0,0 +1,26 @@
+package review
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/middleware"
+)
+
+func RegisterRoutes(cfg *config.Config, router fiber.Router, handler *ReviewHandler) {
+	// Reviews of a book - anyone can read, any authenticated user can write one
+	router.Get("/books/:id/reviews", handler.GetBookReviews)
+	router.Post("/books/:id/reviews", middleware.JWTMiddleware(cfg), handler.CreateReview)
+
+	reviewGroup := router.Group("/reviews")
+	{
+		// User authenticated routes - ownership is checked by the service
+		reviewGroup.Patch("/:id", middleware.JWTMiddleware(cfg), handler.UpdateReview)
+		reviewGroup.Delete("/:id", middleware.JWTMiddleware(cfg), handler.DeleteReview)
+		reviewGroup.Post("/:id/helpful", middleware.JWTMiddleware(cfg), handler.AddHelpfulVote)
+		reviewGroup.Delete("/:id/helpful", middleware.JWTMiddleware(cfg), handler.RemoveHelpfulVote)
+
+		// Moderator or Admin routes - only moderators and admins can hide reviews
+		reviewGroup.Post("/:id/hide", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.HideReview)
+		reviewGroup.Post("/:id/unhide", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.UnhideReview)
+	}
+}
@@ -0,0 +1,182 @@
+package review
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/pkg/activity"
+	"github.com/jerpsp/go-fiber-beginner/pkg/patch"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidReview   = errors.New("invalid review")
+	ErrAlreadyReviewed = errors.New("book already reviewed by this user")
+	ErrNotReviewAuthor = errors.New("only the author of the review can change it")
+	ErrOwnReviewVote   = errors.New("cannot vote on your own review")
+)
+
+// Setup
+type ReviewService interface {
+	GetBookReviews(c *fiber.Ctx, bookID uuid.UUID, page, limit int, sort string) ([]Review, int64, error)
+	CreateReview(c *fiber.Ctx, bookID, userID uuid.UUID, reviewParams ReviewRequest) (Review, error)
+	UpdateReview(c *fiber.Ctx, reviewID, userID uuid.UUID, contentType string, body []byte) (Review, error)
+	DeleteReview(c *fiber.Ctx, reviewID, userID uuid.UUID, isAdmin bool) error
+	SetReviewHidden(c *fiber.Ctx, reviewID, moderatorID uuid.UUID, hidden bool) (Review, error)
+	VoteHelpful(c *fiber.Ctx, reviewID, userID uuid.UUID, helpful bool) (Review, error)
+}
+
+// BookCache drops cached copies of books, it is implemented by the book package which embeds the rating in its books
+type BookCache interface {
+	InvalidateBooks(c *fiber.Ctx, bookIDs ...uuid.UUID)
+}
+
+type reviewService struct {
+	config    *config.Config
+	repo      ReviewRepository
+	activity  activity.Counter
+	bookCache BookCache
+}
+
+func NewReviewService(config *config.Config, repo ReviewRepository, activityCounter activity.Counter, bookCache BookCache) ReviewService {
+	return &reviewService{config: config, repo: repo, activity: activityCounter, bookCache: bookCache}
+}
+
+// Service methods
+func (s *reviewService) GetBookReviews(c *fiber.Ctx, bookID uuid.UUID, page, limit int, sort string) ([]Review, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	return s.repo.FindBookReviews(c, bookID, page, limit, sort)
+}
+
+func (s *reviewService) CreateReview(c *fiber.Ctx, bookID, userID uuid.UUID, reviewParams ReviewRequest) (Review, error) {
+	reviewParams.normalize()
+	if err := utils.Validate(&reviewParams); err != nil {
+		return Review{}, fmt.Errorf("%w: %v", ErrInvalidReview, err)
+	}
+
+	if _, err := s.repo.FindReviewByBookAndUser(c, bookID, userID); err == nil {
+		return Review{}, ErrAlreadyReviewed
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return Review{}, err
+	}
+
+	newReview := Review{BookID: bookID, UserID: userID, Rating: reviewParams.Rating, Title: reviewParams.Title, Body: reviewParams.Body}
+	createdReview, err := s.repo.CreateReview(c, newReview)
+	if err != nil {
+		// a concurrent request from the same user got past the lookup first
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return Review{}, ErrAlreadyReviewed
+		}
+		return Review{}, err
+	}
+	s.bookCache.InvalidateBooks(c, bookID)
+	s.activity.Record(c.UserContext(), activity.EventReview, bookID)
+	return s.repo.FindReviewByID(c, createdReview.ID)
+}
+
+// UpdateReview applies a merge patch or JSON patch to the reviewer's own review
+func (s *reviewService) UpdateReview(c *fiber.Ctx, reviewID, userID uuid.UUID, contentType string, body []byte) (Review, error) {
+	review, err := s.repo.FindReviewByID(c, reviewID)
+	if err != nil {
+		return Review{}, err
+	}
+	if review.UserID != userID {
+		return Review{}, ErrNotReviewAuthor
+	}
+
+	current := ReviewRequest{Rating: review.Rating, Title: review.Title, Body: review.Body}
+	patched := current
+	if err := patch.Apply(&patched, contentType, body); err != nil {
+		return Review{}, err
+	}
+	patched.normalize()
+	if err := utils.Validate(&patched); err != nil {
+		return Review{}, fmt.Errorf("%w: %v", ErrInvalidReview, err)
+	}
+
+	if changes := patch.Changes(current, patched); len(changes) > 0 {
+		if err := s.repo.UpdateReviewFields(c, review, changes); err != nil {
+			return Review{}, err
+		}
+		s.bookCache.InvalidateBooks(c, review.BookID)
+	}
+	return s.repo.FindReviewByID(c, reviewID)
+}
+
+// DeleteReview lets reviewers remove their own review, admins can remove any review
+func (s *reviewService) DeleteReview(c *fiber.Ctx, reviewID, userID uuid.UUID, isAdmin bool) error {
+	review, err := s.repo.FindReviewByID(c, reviewID)
+	if err != nil {
+		return err
+	}
+	if review.UserID != userID && !isAdmin {
+		return ErrNotReviewAuthor
+	}
+	if err := s.repo.DeleteReview(c, review); err != nil {
+		return err
+	}
+	s.bookCache.InvalidateBooks(c, review.BookID)
+	return nil
+}
+
+// SetReviewHidden hides or restores a review, hidden reviews do not count towards the book rating
+func (s *reviewService) SetReviewHidden(c *fiber.Ctx, reviewID, moderatorID uuid.UUID, hidden bool) (Review, error) {
+	review, err := s.repo.FindReviewByID(c, reviewID)
+	if err != nil {
+		return Review{}, err
+	}
+	if review.Hidden == hidden {
+		return review, nil
+	}
+
+	fields := map[string]interface{}{"hidden": hidden, "hidden_by": nil, "hidden_at": nil}
+	if hidden {
+		now := time.Now()
+		fields["hidden_by"] = moderatorID
+		fields["hidden_at"] = now
+	}
+	if err := s.repo.UpdateReviewFields(c, review, fields); err != nil {
+		return Review{}, err
+	}
+	s.bookCache.InvalidateBooks(c, review.BookID)
+	return s.repo.FindReviewByID(c, reviewID)
+}
+
+func (s *reviewService) VoteHelpful(c *fiber.Ctx, reviewID, userID uuid.UUID, helpful bool) (Review, error) {
+	review, err := s.repo.FindReviewByID(c, reviewID)
+	if err != nil {
+		return Review{}, err
+	}
+	if review.Hidden {
+		return Review{}, gorm.ErrRecordNotFound
+	}
+	if review.UserID == userID {
+		return Review{}, ErrOwnReviewVote
+	}
+
+	if helpful {
+		err = s.repo.AddVote(c, reviewID, userID)
+	} else {
+		err = s.repo.RemoveVote(c, reviewID, userID)
+	}
+	if err != nil {
+		return Review{}, err
+	}
+	return s.repo.FindReviewByID(c, reviewID)
+}
+
+func (r *ReviewRequest) normalize() {
+	r.Title = strings.TrimSpace(r.Title)
+	r.Body = strings.TrimSpace(r.Body)
+}
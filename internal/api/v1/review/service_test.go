@@ -0,0 +1,264 @@
+package review_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/review"
+	"github.com/jerpsp/go-fiber-beginner/mocks"
+	"github.com/jerpsp/go-fiber-beginner/pkg/activity"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/valyala/fasthttp"
+	"gorm.io/gorm"
+)
+
+type ReviewServiceSuite struct {
+	suite.Suite
+	mockRepo      *mocks.ReviewRepository
+	mockBookCache *mocks.BookCache
+	service       review.ReviewService
+}
+
+func TestReviewServiceSuite(t *testing.T) {
+	suite.Run(t, new(ReviewServiceSuite))
+}
+
+func (s *ReviewServiceSuite) SetupTest() {
+	s.mockRepo = mocks.NewReviewRepository(s.T())
+	s.mockBookCache = mocks.NewBookCache(s.T())
+	s.service = review.NewReviewService(&config.Config{}, s.mockRepo, activity.New(&activity.ActivityConfig{}, nil), s.mockBookCache)
+}
+
+func (s *ReviewServiceSuite) TestCreateReview1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Create Review Success", 34)
+
+	// Setup Mock
+	bookID, userID := uuid.New(), uuid.New()
+	created := review.Review{ID: uuid.New(), BookID: bookID, UserID: userID, Rating: 4, Title: "Great"}
+	s.mockRepo.EXPECT().FindReviewByBookAndUser(mock.Anything, bookID, userID).Return(review.Review{}, gorm.ErrRecordNotFound)
+	s.mockRepo.EXPECT().CreateReview(mock.Anything, review.Review{BookID: bookID, UserID: userID, Rating: 4, Title: "Great"}).Return(created, nil)
+	s.mockRepo.EXPECT().FindReviewByID(mock.Anything, created.ID).Return(created, nil)
+	s.mockBookCache.EXPECT().InvalidateBooks(mock.Anything, bookID).Return()
+
+	// Call the service method
+	app := fiber.New()
+	c := app.AcquireCtx(&fasthttp.RequestCtx{})
+	defer app.ReleaseCtx(c)
+	result, err := s.service.CreateReview(c, bookID, userID, review.ReviewRequest{Rating: 4, Title: " Great "})
+
+	// Assertions
+	s.NoError(err)
+	s.Equal(created, result)
+}
+
+func (s *ReviewServiceSuite) TestCreateReview2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Create Review Already Reviewed", 31)
+
+	// Setup Mock
+	bookID, userID := uuid.New(), uuid.New()
+	s.mockRepo.EXPECT().FindReviewByBookAndUser(mock.Anything, bookID, userID).Return(review.Review{ID: uuid.New()}, nil)
+
+	// Call the service method
+	_, err := s.service.CreateReview(&fiber.Ctx{}, bookID, userID, review.ReviewRequest{Rating: 4})
+
+	// Assertions
+	s.ErrorIs(err, review.ErrAlreadyReviewed)
+}
+
+func (s *ReviewServiceSuite) TestCreateReview3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Create Review Concurrent Duplicate", 31)
+
+	// Setup Mock
+	bookID, userID := uuid.New(), uuid.New()
+	s.mockRepo.EXPECT().FindReviewByBookAndUser(mock.Anything, bookID, userID).Return(review.Review{}, gorm.ErrRecordNotFound)
+	s.mockRepo.EXPECT().CreateReview(mock.Anything, mock.Anything).Return(review.Review{}, gorm.ErrDuplicatedKey)
+
+	// Call the service method
+	_, err := s.service.CreateReview(&fiber.Ctx{}, bookID, userID, review.ReviewRequest{Rating: 4})
+
+	// Assertions
+	s.ErrorIs(err, review.ErrAlreadyReviewed)
+}
+
+func (s *ReviewServiceSuite) TestCreateReview4() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Create Review Rating Out Of Range", 31)
+
+	// Call the service method
+	_, err := s.service.CreateReview(&fiber.Ctx{}, uuid.New(), uuid.New(), review.ReviewRequest{Rating: 6})
+
+	// Assertions
+	s.ErrorIs(err, review.ErrInvalidReview)
+}
+
+func (s *ReviewServiceSuite) TestSetReviewHidden1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Hide Review", 34)
+
+	// Setup Mock
+	moderatorID := uuid.New()
+	current := review.Review{ID: uuid.New(), BookID: uuid.New(), Rating: 1}
+	hidden := current
+	hidden.Hidden = true
+	s.mockRepo.EXPECT().FindReviewByID(mock.Anything, current.ID).Return(current, nil).Once()
+	s.mockRepo.EXPECT().UpdateReviewFields(mock.Anything, current, mock.MatchedBy(func(fields map[string]interface{}) bool {
+		_, stamped := fields["hidden_at"].(time.Time)
+		return fields["hidden"] == true && fields["hidden_by"] == moderatorID && stamped
+	})).Return(nil)
+	s.mockRepo.EXPECT().FindReviewByID(mock.Anything, current.ID).Return(hidden, nil).Once()
+	s.mockBookCache.EXPECT().InvalidateBooks(mock.Anything, current.BookID).Return()
+
+	// Call the service method
+	result, err := s.service.SetReviewHidden(&fiber.Ctx{}, current.ID, moderatorID, true)
+
+	// Assertions
+	s.NoError(err)
+	s.True(result.Hidden)
+}
+
+func (s *ReviewServiceSuite) TestSetReviewHidden2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Unhide Review", 34)
+
+	// Setup Mock
+	moderatorID := uuid.New()
+	current := review.Review{ID: uuid.New(), BookID: uuid.New(), Rating: 1, Hidden: true, HiddenBy: &moderatorID}
+	visible := review.Review{ID: current.ID, BookID: current.BookID, Rating: 1}
+	s.mockRepo.EXPECT().FindReviewByID(mock.Anything, current.ID).Return(current, nil).Once()
+	s.mockRepo.EXPECT().UpdateReviewFields(mock.Anything, current, map[string]interface{}{"hidden": false, "hidden_by": nil, "hidden_at": nil}).Return(nil)
+	s.mockRepo.EXPECT().FindReviewByID(mock.Anything, current.ID).Return(visible, nil).Once()
+	s.mockBookCache.EXPECT().InvalidateBooks(mock.Anything, current.BookID).Return()
+
+	// Call the service method
+	result, err := s.service.SetReviewHidden(&fiber.Ctx{}, current.ID, moderatorID, false)
+
+	// Assertions
+	s.NoError(err)
+	s.False(result.Hidden)
+}
+
+func (s *ReviewServiceSuite) TestSetReviewHidden3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Hide Review Already Hidden", 34)
+
+	// Setup Mock
+	current := review.Review{ID: uuid.New(), BookID: uuid.New(), Rating: 1, Hidden: true}
+	s.mockRepo.EXPECT().FindReviewByID(mock.Anything, current.ID).Return(current, nil)
+
+	// Call the service method
+	result, err := s.service.SetReviewHidden(&fiber.Ctx{}, current.ID, uuid.New(), true)
+
+	// Assertions
+	s.NoError(err)
+	s.Equal(current, result)
+}
+
+func (s *ReviewServiceSuite) TestDeleteReview1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Delete Own Review Drops Cached Book", 34)
+
+	// Setup Mock
+	current := review.Review{ID: uuid.New(), BookID: uuid.New(), UserID: uuid.New(), Rating: 3}
+	s.mockRepo.EXPECT().FindReviewByID(mock.Anything, current.ID).Return(current, nil)
+	s.mockRepo.EXPECT().DeleteReview(mock.Anything, current).Return(nil)
+	s.mockBookCache.EXPECT().InvalidateBooks(mock.Anything, current.BookID).Return()
+
+	// Call the service method
+	err := s.service.DeleteReview(&fiber.Ctx{}, current.ID, current.UserID, false)
+
+	// Assertions
+	s.NoError(err)
+}
+
+func (s *ReviewServiceSuite) TestDeleteReview2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Delete Review Of Another User", 31)
+
+	// Setup Mock
+	current := review.Review{ID: uuid.New(), BookID: uuid.New(), UserID: uuid.New(), Rating: 3}
+	s.mockRepo.EXPECT().FindReviewByID(mock.Anything, current.ID).Return(current, nil)
+
+	// Call the service method
+	err := s.service.DeleteReview(&fiber.Ctx{}, current.ID, uuid.New(), false)
+
+	// Assertions
+	s.ErrorIs(err, review.ErrNotReviewAuthor)
+}
+
+func (s *ReviewServiceSuite) TestVoteHelpful1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Vote Review Helpful", 34)
+
+	// Setup Mock
+	voterID := uuid.New()
+	current := review.Review{ID: uuid.New(), UserID: uuid.New(), Rating: 5}
+	voted := current
+	voted.HelpfulCount = 1
+	s.mockRepo.EXPECT().FindReviewByID(mock.Anything, current.ID).Return(current, nil).Once()
+	s.mockRepo.EXPECT().AddVote(mock.Anything, current.ID, voterID).Return(nil)
+	s.mockRepo.EXPECT().FindReviewByID(mock.Anything, current.ID).Return(voted, nil).Once()
+
+	// Call the service method
+	result, err := s.service.VoteHelpful(&fiber.Ctx{}, current.ID, voterID, true)
+
+	// Assertions
+	s.NoError(err)
+	s.Equal(1, result.HelpfulCount)
+}
+
+func (s *ReviewServiceSuite) TestVoteHelpful2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Withdraw Helpful Vote", 34)
+
+	// Setup Mock
+	voterID := uuid.New()
+	current := review.Review{ID: uuid.New(), UserID: uuid.New(), Rating: 5, HelpfulCount: 1}
+	s.mockRepo.EXPECT().FindReviewByID(mock.Anything, current.ID).Return(current, nil).Once()
+	s.mockRepo.EXPECT().RemoveVote(mock.Anything, current.ID, voterID).Return(nil)
+	s.mockRepo.EXPECT().FindReviewByID(mock.Anything, current.ID).Return(review.Review{ID: current.ID}, nil).Once()
+
+	// Call the service method
+	result, err := s.service.VoteHelpful(&fiber.Ctx{}, current.ID, voterID, false)
+
+	// Assertions
+	s.NoError(err)
+	s.Equal(0, result.HelpfulCount)
+}
+
+func (s *ReviewServiceSuite) TestVoteHelpful3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Vote On Own Review", 31)
+
+	// Setup Mock
+	current := review.Review{ID: uuid.New(), UserID: uuid.New(), Rating: 5}
+	s.mockRepo.EXPECT().FindReviewByID(mock.Anything, current.ID).Return(current, nil)
+
+	// Call the service method
+	_, err := s.service.VoteHelpful(&fiber.Ctx{}, current.ID, current.UserID, true)
+
+	// Assertions
+	s.ErrorIs(err, review.ErrOwnReviewVote)
+}
+
+func (s *ReviewServiceSuite) TestVoteHelpful4() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Vote On Hidden Review", 31)
+
+	// Setup Mock
+	current := review.Review{ID: uuid.New(), UserID: uuid.New(), Rating: 5, Hidden: true}
+	s.mockRepo.EXPECT().FindReviewByID(mock.Anything, current.ID).Return(current, nil)
+
+	// Call the service method
+	_, err := s.service.VoteHelpful(&fiber.Ctx{}, current.ID, uuid.New(), true)
+
+	// Assertions
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+}
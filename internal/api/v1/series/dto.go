@@ -0,0 +1,11 @@
+package series
+
+// SeriesRequest is the writable view of a series, json names double as column names for partial updates
+type SeriesRequest struct {
+	Name        string `json:"name" validate:"required,max=255"`
+	Description string `json:"description" validate:"omitempty"`
+}
+
+type SeriesFilter struct {
+	Query string `query:"q"`
+}
@@ -0,0 +1,95 @@
+package series
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/pkg/patch"
+)
+
+type SeriesHandler struct {
+	config  *config.Config
+	service SeriesService
+}
+
+func NewSeriesHandler(config *config.Config, service SeriesService) *SeriesHandler {
+	return &SeriesHandler{config: config, service: service}
+}
+
+// Handler methods
+func (h *SeriesHandler) GetAllSeries(c *fiber.Ctx) error {
+	var filter SeriesFilter
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid filter parameters"})
+	}
+
+	allSeries, err := h.service.GetAllSeries(c, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"series": allSeries})
+}
+
+func (h *SeriesHandler) GetSeries(c *fiber.Ctx) error {
+	seriesID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid series ID"})
+	}
+
+	series, err := h.service.GetSeries(c, seriesID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"series": series})
+}
+
+func (h *SeriesHandler) CreateSeries(c *fiber.Ctx) error {
+	var seriesParams SeriesRequest
+	if err := c.BodyParser(&seriesParams); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid input"})
+	}
+
+	series, err := h.service.CreateSeries(c, seriesParams)
+	if err != nil {
+		if errors.Is(err, ErrInvalidSeries) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"series": series})
+}
+
+func (h *SeriesHandler) UpdateSeries(c *fiber.Ctx) error {
+	seriesID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid series ID"})
+	}
+
+	series, err := h.service.UpdateSeries(c, seriesID, c.Get(fiber.HeaderContentType), c.Body())
+	if err != nil {
+		switch {
+		case errors.Is(err, patch.ErrUnsupportedMediaType):
+			return c.Status(fiber.StatusUnsupportedMediaType).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, patch.ErrInvalidPatch), errors.Is(err, ErrInvalidSeries):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, patch.ErrTestFailed):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"series": series})
+}
+
+func (h *SeriesHandler) DeleteSeries(c *fiber.Ctx) error {
+	seriesID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid series ID"})
+	}
+
+	if err := h.service.DeleteSeries(c, seriesID); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,16 @@
+package series
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Series groups books published as numbered volumes, the volume number is kept on the book
+type Series struct {
+	ID          uuid.UUID `gorm:"type:uuid; default:uuid_generate_v4()" json:"id"`
+	Name        string    `gorm:"size:255;not null;index" json:"name"`
+	Description string    `gorm:"type:text" json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -0,0 +1,90 @@
+package series
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/pkg/database"
+	"gorm.io/gorm"
+)
+
+type SeriesRepository interface {
+	FindAllSeries(c *fiber.Ctx, filter SeriesFilter) ([]Series, error)
+	FindSeriesByID(c *fiber.Ctx, seriesID uuid.UUID) (Series, error)
+	CreateSeries(c *fiber.Ctx, newSeries Series) (Series, error)
+	UpdateSeriesFields(c *fiber.Ctx, seriesID uuid.UUID, fields map[string]interface{}) error
+	DeleteSeries(c *fiber.Ctx, seriesID uuid.UUID) error
+	FindSeriesBookIDs(c *fiber.Ctx, seriesID uuid.UUID) ([]uuid.UUID, error)
+}
+
+type seriesRepository struct {
+	config *config.Config
+	db     *database.GormDB
+}
+
+func NewSeriesRepository(cfg *config.Config, db *database.GormDB) SeriesRepository {
+	return &seriesRepository{config: cfg, db: db}
+}
+
+// Repository methods
+
+func (r *seriesRepository) FindAllSeries(c *fiber.Ctx, filter SeriesFilter) ([]Series, error) {
+	var allSeries []Series
+	query := r.db.DB.Order("name")
+	if filter.Query != "" {
+		query = query.Where("name ILIKE ?", "%"+strings.TrimSpace(filter.Query)+"%")
+	}
+	if err := query.Find(&allSeries).Error; err != nil {
+		return nil, err
+	}
+	return allSeries, nil
+}
+
+func (r *seriesRepository) FindSeriesByID(c *fiber.Ctx, seriesID uuid.UUID) (Series, error) {
+	var series Series
+	if err := r.db.DB.Where("id = ?", seriesID).First(&series).Error; err != nil {
+		return Series{}, err
+	}
+	return series, nil
+}
+
+func (r *seriesRepository) CreateSeries(c *fiber.Ctx, newSeries Series) (Series, error) {
+	if err := r.db.DB.Create(&newSeries).Error; err != nil {
+		return Series{}, err
+	}
+	return newSeries, nil
+}
+
+func (r *seriesRepository) UpdateSeriesFields(c *fiber.Ctx, seriesID uuid.UUID, fields map[string]interface{}) error {
+	result := r.db.DB.Model(&Series{}).Where("id = ?", seriesID).Updates(fields)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteSeries removes the series, its volumes stay in the catalogue outside any series
+func (r *seriesRepository) DeleteSeries(c *fiber.Ctx, seriesID uuid.UUID) error {
+	result := r.db.DB.Delete(&Series{}, "id = ?", seriesID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// FindSeriesBookIDs lists the volumes of the series, the books table belongs to the book package
+func (r *seriesRepository) FindSeriesBookIDs(c *fiber.Ctx, seriesID uuid.UUID) ([]uuid.UUID, error) {
+	var bookIDs []uuid.UUID
+	if err := r.db.DB.Table("books").Where("series_id = ?", seriesID).Pluck("id", &bookIDs).Error; err != nil {
+		return nil, err
+	}
+	return bookIDs, nil
+}
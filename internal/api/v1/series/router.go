@@ -0,0 +1,23 @@
+package series
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/middleware"
+)
+
+func RegisterRoutes(cfg *config.Config, router fiber.Router, handler *SeriesHandler) {
+	seriesGroup := router.Group("/series")
+	{
+		// Public routes - anyone can access
+		seriesGroup.Get("", handler.GetAllSeries)
+		seriesGroup.Get("/:id", handler.GetSeries)
+
+		// Moderator or Admin routes - only moderators and admins can curate series
+		seriesGroup.Post("", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.CreateSeries)
+		seriesGroup.Patch("/:id", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.UpdateSeries)
+
+		// Admin only routes - only admins can delete series
+		seriesGroup.Delete("/:id", middleware.JWTMiddleware(cfg), middleware.AdminOnly(), handler.DeleteSeries)
+	}
+}
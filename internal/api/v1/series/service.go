@@ -0,0 +1,111 @@
+package series
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/pkg/patch"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+)
+
+var ErrInvalidSeries = errors.New("invalid series")
+
+// Setup
+type SeriesService interface {
+	GetAllSeries(c *fiber.Ctx, filter SeriesFilter) ([]Series, error)
+	GetSeries(c *fiber.Ctx, seriesID uuid.UUID) (Series, error)
+	CreateSeries(c *fiber.Ctx, seriesParams SeriesRequest) (Series, error)
+	UpdateSeries(c *fiber.Ctx, seriesID uuid.UUID, contentType string, body []byte) (Series, error)
+	DeleteSeries(c *fiber.Ctx, seriesID uuid.UUID) error
+}
+
+// BookCache drops cached copies of books, it is implemented by the book package which embeds the series in its books
+type BookCache interface {
+	InvalidateBooks(c *fiber.Ctx, bookIDs ...uuid.UUID)
+}
+
+type seriesService struct {
+	config    *config.Config
+	repo      SeriesRepository
+	bookCache BookCache
+}
+
+func NewSeriesService(config *config.Config, repo SeriesRepository, bookCache BookCache) SeriesService {
+	return &seriesService{config: config, repo: repo, bookCache: bookCache}
+}
+
+// Service methods
+func (s *seriesService) GetAllSeries(c *fiber.Ctx, filter SeriesFilter) ([]Series, error) {
+	return s.repo.FindAllSeries(c, filter)
+}
+
+func (s *seriesService) GetSeries(c *fiber.Ctx, seriesID uuid.UUID) (Series, error) {
+	return s.repo.FindSeriesByID(c, seriesID)
+}
+
+func (s *seriesService) CreateSeries(c *fiber.Ctx, seriesParams SeriesRequest) (Series, error) {
+	seriesParams.normalize()
+	if err := utils.Validate(&seriesParams); err != nil {
+		return Series{}, fmt.Errorf("%w: %v", ErrInvalidSeries, err)
+	}
+
+	newSeries := Series{Name: seriesParams.Name, Description: seriesParams.Description}
+	return s.repo.CreateSeries(c, newSeries)
+}
+
+// UpdateSeries applies a merge patch or JSON patch and writes only the columns it changed
+func (s *seriesService) UpdateSeries(c *fiber.Ctx, seriesID uuid.UUID, contentType string, body []byte) (Series, error) {
+	series, err := s.repo.FindSeriesByID(c, seriesID)
+	if err != nil {
+		return Series{}, err
+	}
+
+	current := SeriesRequest{Name: series.Name, Description: series.Description}
+	patched := current
+	if err := patch.Apply(&patched, contentType, body); err != nil {
+		return Series{}, err
+	}
+	patched.normalize()
+	if err := utils.Validate(&patched); err != nil {
+		return Series{}, fmt.Errorf("%w: %v", ErrInvalidSeries, err)
+	}
+
+	if changes := patch.Changes(current, patched); len(changes) > 0 {
+		bookIDs := s.findBookIDs(c, seriesID)
+		if err := s.repo.UpdateSeriesFields(c, seriesID, changes); err != nil {
+			return Series{}, err
+		}
+		s.bookCache.InvalidateBooks(c, bookIDs...)
+	}
+
+	return s.GetSeries(c, seriesID)
+}
+
+func (s *seriesService) DeleteSeries(c *fiber.Ctx, seriesID uuid.UUID) error {
+	// the volumes are looked up first, the delete unlinks them
+	bookIDs := s.findBookIDs(c, seriesID)
+	if err := s.repo.DeleteSeries(c, seriesID); err != nil {
+		return err
+	}
+	s.bookCache.InvalidateBooks(c, bookIDs...)
+	return nil
+}
+
+// findBookIDs lists the volumes whose cached copies show the series, a failed lookup leaves them to expire
+func (s *seriesService) findBookIDs(c *fiber.Ctx, seriesID uuid.UUID) []uuid.UUID {
+	bookIDs, err := s.repo.FindSeriesBookIDs(c, seriesID)
+	if err != nil {
+		log.Errorf("failed to find the volumes of the series: %v", err)
+	}
+	return bookIDs
+}
+
+func (r *SeriesRequest) normalize() {
+	r.Name = strings.Join(strings.Fields(r.Name), " ")
+	r.Description = strings.TrimSpace(r.Description)
+}
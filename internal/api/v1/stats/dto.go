@@ -0,0 +1,56 @@
+package stats
+
+import (
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+)
+
+type TrendingFilter struct {
+	Window string `query:"window"`
+	Limit  int    `query:"limit"`
+}
+
+// RangeFilter selects days by UTC date, both ends included
+type RangeFilter struct {
+	From string `query:"from"`
+	To   string `query:"to"`
+}
+
+type Counts struct {
+	Views      int64 `json:"views"`
+	Loans      int64 `json:"loans"`
+	Favourites int64 `json:"favourites"`
+	Reviews    int64 `json:"reviews"`
+}
+
+func (c *Counts) add(other Counts) {
+	c.Views += other.Views
+	c.Loans += other.Loans
+	c.Favourites += other.Favourites
+	c.Reviews += other.Reviews
+}
+
+type DailyCounts struct {
+	Day string `json:"day"`
+	Counts
+}
+
+type TrendingBook struct {
+	BookID uuid.UUID `json:"-"`
+	Book   book.Book `gorm:"-" json:"book"`
+	Score  float64   `json:"score"`
+	Counts
+}
+
+type TrendingResponse struct {
+	Window string         `json:"window"`
+	Books  []TrendingBook `json:"books"`
+}
+
+type StatsResponse struct {
+	BookID *uuid.UUID    `json:"book_id,omitempty"`
+	From   string        `json:"from"`
+	To     string        `json:"to"`
+	Totals Counts        `json:"totals"`
+	Days   []DailyCounts `json:"days"`
+}
@@ -0,0 +1,75 @@
+package stats
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"gorm.io/gorm"
+)
+
+type StatsHandler struct {
+	config  *config.Config
+	service StatsService
+}
+
+func NewStatsHandler(config *config.Config, service StatsService) *StatsHandler {
+	return &StatsHandler{config: config, service: service}
+}
+
+func (h *StatsHandler) GetTrending(c *fiber.Ctx) error {
+	var filter TrendingFilter
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid query parameters"})
+	}
+
+	trending, err := h.service.GetTrending(c, filter)
+	if err != nil {
+		if errors.Is(err, ErrInvalidWindow) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.Status(fiber.StatusOK).JSON(trending)
+}
+
+func (h *StatsHandler) GetBookStats(c *fiber.Ctx) error {
+	bookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid book ID"})
+	}
+
+	var filter RangeFilter
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid query parameters"})
+	}
+
+	stats, err := h.service.GetBookStats(c, bookID, filter)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidRange):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.Status(fiber.StatusOK).JSON(stats)
+}
+
+func (h *StatsHandler) GetDailyStats(c *fiber.Ctx) error {
+	var filter RangeFilter
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid query parameters"})
+	}
+
+	stats, err := h.service.GetDailyStats(c, filter)
+	if err != nil {
+		if errors.Is(err, ErrInvalidRange) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.Status(fiber.StatusOK).JSON(stats)
+}
@@ -0,0 +1,26 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+)
+
+// BookDailyStat holds the event counts of a book for one UTC day, rolled up from the Redis counters
+type BookDailyStat struct {
+	BookID     uuid.UUID `gorm:"type:uuid;primaryKey" json:"book_id"`
+	Day        time.Time `gorm:"type:date;primaryKey;index" json:"day"`
+	Views      int64     `gorm:"not null;default:0" json:"views"`
+	Loans      int64     `gorm:"not null;default:0" json:"loans"`
+	Favourites int64     `gorm:"not null;default:0" json:"favourites"`
+	Reviews    int64     `gorm:"not null;default:0" json:"reviews"`
+	Book       book.Book `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE" json:"-"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// RollupReport sums up a run of the stats rollup
+type RollupReport struct {
+	Days int
+	Rows int
+}
@@ -0,0 +1,117 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+	"github.com/jerpsp/go-fiber-beginner/pkg/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// trendingScore weighs the events, a loan or review says more about interest than a page view
+const trendingScore = "SUM(views + 3 * favourites + 5 * loans + 5 * reviews)"
+
+const countColumns = "SUM(views) AS views, SUM(loans) AS loans, SUM(favourites) AS favourites, SUM(reviews) AS reviews"
+
+type StatsRepository interface {
+	FindExistingBookIDs(c *fiber.Ctx, bookIDs []uuid.UUID) ([]uuid.UUID, error)
+	UpsertDailyStats(c *fiber.Ctx, stats []BookDailyStat) error
+	FindTrending(c *fiber.Ctx, since time.Time, limit int) ([]TrendingBook, error)
+	FindBookDays(c *fiber.Ctx, bookID uuid.UUID, from, to time.Time) ([]DailyRow, error)
+	FindTotalDays(c *fiber.Ctx, from, to time.Time) ([]DailyRow, error)
+}
+
+type statsRepository struct {
+	config *config.Config
+	db     *database.GormDB
+}
+
+func NewStatsRepository(cfg *config.Config, db *database.GormDB) StatsRepository {
+	return &statsRepository{config: cfg, db: db}
+}
+
+// Repository methods
+
+// FindExistingBookIDs drops IDs of books deleted or merged away since their events were counted
+func (r *statsRepository) FindExistingBookIDs(c *fiber.Ctx, bookIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var existing []uuid.UUID
+	if len(bookIDs) == 0 {
+		return existing, nil
+	}
+	if err := r.db.DB.Model(&book.Book{}).Where("id IN ?", bookIDs).Pluck("id", &existing).Error; err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// UpsertDailyStats writes the counts of a day. The Redis counters only grow, so a count is never lowered, which
+// keeps a re-run or a flushed Redis from undoing earlier rollups.
+func (r *statsRepository) UpsertDailyStats(c *fiber.Ctx, stats []BookDailyStat) error {
+	if len(stats) == 0 {
+		return nil
+	}
+	return r.db.DB.Omit("Book").Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "book_id"}, {Name: "day"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"views":      gorm.Expr("GREATEST(book_daily_stats.views, excluded.views)"),
+			"loans":      gorm.Expr("GREATEST(book_daily_stats.loans, excluded.loans)"),
+			"favourites": gorm.Expr("GREATEST(book_daily_stats.favourites, excluded.favourites)"),
+			"reviews":    gorm.Expr("GREATEST(book_daily_stats.reviews, excluded.reviews)"),
+			"updated_at": gorm.Expr("excluded.updated_at"),
+		}),
+	}).CreateInBatches(stats, 1000).Error
+}
+
+func (r *statsRepository) FindTrending(c *fiber.Ctx, since time.Time, limit int) ([]TrendingBook, error) {
+	trending := []TrendingBook{}
+	err := r.db.DB.Model(&BookDailyStat{}).
+		Select("book_id, "+trendingScore+" AS score, "+countColumns).
+		Where("day >= ?", since).Group("book_id").
+		Order("score DESC, book_id").Limit(limit).
+		Scan(&trending).Error
+	if err != nil || len(trending) == 0 {
+		return trending, err
+	}
+
+	bookIDs := make([]uuid.UUID, len(trending))
+	for i, entry := range trending {
+		bookIDs[i] = entry.BookID
+	}
+	var books []book.Book
+	if err := r.db.DB.Where("id IN ?", bookIDs).Find(&books).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]book.Book, len(books))
+	for _, b := range books {
+		byID[b.ID] = b
+	}
+	for i := range trending {
+		trending[i].Book = byID[trending[i].BookID]
+	}
+	return trending, nil
+}
+
+func (r *statsRepository) FindBookDays(c *fiber.Ctx, bookID uuid.UUID, from, to time.Time) ([]DailyRow, error) {
+	if err := r.db.DB.Select("id").Where("id = ?", bookID).First(&book.Book{}).Error; err != nil {
+		return nil, err
+	}
+
+	var rows []DailyRow
+	err := r.db.DB.Model(&BookDailyStat{}).Select("day, views, loans, favourites, reviews").
+		Where("book_id = ? AND day BETWEEN ? AND ?", bookID, from, to).Order("day").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// FindTotalDays sums the counts of all books per day
+func (r *statsRepository) FindTotalDays(c *fiber.Ctx, from, to time.Time) ([]DailyRow, error) {
+	var rows []DailyRow
+	err := r.db.DB.Model(&BookDailyStat{}).Select("day, "+countColumns).
+		Where("day BETWEEN ? AND ?", from, to).Group("day").Order("day").
+		Scan(&rows).Error
+	return rows, err
+}
@@ -0,0 +1,17 @@
+package stats
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/middleware"
+)
+
+// RegisterRoutes must run before the book routes, otherwise "trending" is taken for a book ID
+func RegisterRoutes(cfg *config.Config, router fiber.Router, handler *StatsHandler) {
+	// Public routes - anyone can see what is popular
+	router.Get("/books/trending", handler.GetTrending)
+
+	// Admin only routes - per day counts of one book or the whole catalogue
+	router.Get("/books/:id/stats", middleware.JWTMiddleware(cfg), middleware.AdminOnly(), handler.GetBookStats)
+	router.Get("/stats/daily", middleware.JWTMiddleware(cfg), middleware.AdminOnly(), handler.GetDailyStats)
+}
@@ -0,0 +1,93 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jerpsp/go-fiber-beginner/pkg/activity"
+)
+
+var (
+	ErrInvalidWindow = errors.New("invalid window")
+	ErrInvalidRange  = errors.New("invalid date range")
+)
+
+const (
+	defaultWindow = "7d"
+	maxWindowDays = 90
+	// defaultRangeDays is the range of the stats endpoints without from and to
+	defaultRangeDays = 30
+	maxRangeDays     = 366
+)
+
+// ParseWindow reads a trending window such as "7d", counted in whole days including today
+func ParseWindow(window string) (int, error) {
+	if window == "" {
+		window = defaultWindow
+	}
+	days, err := strconv.Atoi(strings.TrimSuffix(window, "d"))
+	if err != nil || !strings.HasSuffix(window, "d") || days < 1 || days > maxWindowDays {
+		return 0, fmt.Errorf("%w: %s, expected 1d to %dd", ErrInvalidWindow, window, maxWindowDays)
+	}
+	return days, nil
+}
+
+// ParseRange reads a from and to date, a missing end defaults to today and a missing start to 30 days before the end
+func ParseRange(filter RangeFilter, now time.Time) (time.Time, time.Time, error) {
+	to := activity.Day(now)
+	if filter.To != "" {
+		day, err := time.Parse(time.DateOnly, filter.To)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: to: %v", ErrInvalidRange, err)
+		}
+		to = day
+	}
+
+	from := to.AddDate(0, 0, -(defaultRangeDays - 1))
+	if filter.From != "" {
+		day, err := time.Parse(time.DateOnly, filter.From)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: from: %v", ErrInvalidRange, err)
+		}
+		from = day
+	}
+
+	if from.After(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: from is after to", ErrInvalidRange)
+	}
+	if days := int(to.Sub(from).Hours()/24) + 1; days > maxRangeDays {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: at most %d days", ErrInvalidRange, maxRangeDays)
+	}
+	return from, to, nil
+}
+
+// DailyRow is the counts of one day as read from the database
+type DailyRow struct {
+	Day time.Time
+	Counts
+}
+
+// FillSeries returns one entry per day from from to to, days without a row count zero
+func FillSeries(rows []DailyRow, from, to time.Time) ([]DailyCounts, Counts) {
+	byDay := make(map[string]Counts, len(rows))
+	for _, row := range rows {
+		byDay[row.Day.Format(time.DateOnly)] = row.Counts
+	}
+
+	var totals Counts
+	series := []DailyCounts{}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		key := day.Format(time.DateOnly)
+		counts := byDay[key]
+		totals.add(counts)
+		series = append(series, DailyCounts{Day: key, Counts: counts})
+	}
+	return series, totals
+}
+
+func windowName(days int) string {
+	return strconv.Itoa(days) + "d"
+}
@@ -0,0 +1,102 @@
+package stats_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/stats"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type SeriesSuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func (s *SeriesSuite) SetupTest() {
+	s.now = time.Date(2025, 3, 10, 15, 30, 0, 0, time.UTC)
+}
+
+func TestSeriesSuite(t *testing.T) {
+	suite.Run(t, new(SeriesSuite))
+}
+
+func (s *SeriesSuite) day(value string) time.Time {
+	day, err := time.Parse(time.DateOnly, value)
+	s.Require().NoError(err)
+	return day
+}
+
+func (s *SeriesSuite) TestParseWindow1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Parse Window", 34)
+
+	days, err := stats.ParseWindow("")
+	s.NoError(err)
+	s.Equal(7, days)
+
+	days, err = stats.ParseWindow("30d")
+	s.NoError(err)
+	s.Equal(30, days)
+}
+
+func (s *SeriesSuite) TestParseWindow2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Parse Invalid Window", 31)
+
+	for _, window := range []string{"7", "7h", "0d", "-1d", "91d", "d", "week"} {
+		_, err := stats.ParseWindow(window)
+		s.ErrorIs(err, stats.ErrInvalidWindow, window)
+	}
+}
+
+func (s *SeriesSuite) TestParseRange1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Parse Range With Defaults", 34)
+
+	from, to, err := stats.ParseRange(stats.RangeFilter{}, s.now)
+	s.NoError(err)
+	s.Equal(s.day("2025-03-10"), to)
+	s.Equal(s.day("2025-02-09"), from)
+
+	from, to, err = stats.ParseRange(stats.RangeFilter{From: "2025-01-01", To: "2025-01-31"}, s.now)
+	s.NoError(err)
+	s.Equal(s.day("2025-01-01"), from)
+	s.Equal(s.day("2025-01-31"), to)
+}
+
+func (s *SeriesSuite) TestParseRange2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Parse Invalid Range", 31)
+
+	for _, filter := range []stats.RangeFilter{
+		{From: "2025-02-01", To: "2025-01-01"},
+		{From: "2024-01-01", To: "2025-03-01"},
+		{From: "01/02/2025"},
+		{To: "yesterday"},
+	} {
+		_, _, err := stats.ParseRange(filter, s.now)
+		s.ErrorIs(err, stats.ErrInvalidRange)
+	}
+}
+
+func (s *SeriesSuite) TestFillSeries1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Fill Missing Days With Zero", 34)
+
+	rows := []stats.DailyRow{
+		{Day: s.day("2025-03-01"), Counts: stats.Counts{Views: 10, Loans: 1}},
+		{Day: s.day("2025-03-03"), Counts: stats.Counts{Views: 4, Favourites: 2, Reviews: 1}},
+	}
+
+	series, totals := stats.FillSeries(rows, s.day("2025-03-01"), s.day("2025-03-04"))
+
+	s.Equal([]stats.DailyCounts{
+		{Day: "2025-03-01", Counts: stats.Counts{Views: 10, Loans: 1}},
+		{Day: "2025-03-02"},
+		{Day: "2025-03-03", Counts: stats.Counts{Views: 4, Favourites: 2, Reviews: 1}},
+		{Day: "2025-03-04"},
+	}, series)
+	s.Equal(stats.Counts{Views: 14, Loans: 1, Favourites: 2, Reviews: 1}, totals)
+}
@@ -0,0 +1,136 @@
+package stats
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/pkg/activity"
+)
+
+// Setup
+type StatsService interface {
+	GetTrending(c *fiber.Ctx, filter TrendingFilter) (TrendingResponse, error)
+	GetBookStats(c *fiber.Ctx, bookID uuid.UUID, filter RangeFilter) (StatsResponse, error)
+	GetDailyStats(c *fiber.Ctx, filter RangeFilter) (StatsResponse, error)
+	Rollup(c *fiber.Ctx) (RollupReport, error)
+}
+
+type statsService struct {
+	config   *config.Config
+	repo     StatsRepository
+	activity activity.Counter
+}
+
+func NewStatsService(config *config.Config, repo StatsRepository, activityCounter activity.Counter) StatsService {
+	return &statsService{config: config, repo: repo, activity: activityCounter}
+}
+
+// Service methods
+
+// GetTrending ranks books by their weighted events over the last window days, up to the last rollup
+func (s *statsService) GetTrending(c *fiber.Ctx, filter TrendingFilter) (TrendingResponse, error) {
+	days, err := ParseWindow(filter.Window)
+	if err != nil {
+		return TrendingResponse{}, err
+	}
+	if filter.Limit <= 0 || filter.Limit > 100 {
+		filter.Limit = 10
+	}
+
+	since := activity.Day(time.Now()).AddDate(0, 0, -(days - 1))
+	books, err := s.repo.FindTrending(c, since, filter.Limit)
+	if err != nil {
+		return TrendingResponse{}, err
+	}
+	return TrendingResponse{Window: windowName(days), Books: books}, nil
+}
+
+func (s *statsService) GetBookStats(c *fiber.Ctx, bookID uuid.UUID, filter RangeFilter) (StatsResponse, error) {
+	from, to, err := ParseRange(filter, time.Now())
+	if err != nil {
+		return StatsResponse{}, err
+	}
+	rows, err := s.repo.FindBookDays(c, bookID, from, to)
+	if err != nil {
+		return StatsResponse{}, err
+	}
+
+	response := newStatsResponse(rows, from, to)
+	response.BookID = &bookID
+	return response, nil
+}
+
+func (s *statsService) GetDailyStats(c *fiber.Ctx, filter RangeFilter) (StatsResponse, error) {
+	from, to, err := ParseRange(filter, time.Now())
+	if err != nil {
+		return StatsResponse{}, err
+	}
+	rows, err := s.repo.FindTotalDays(c, from, to)
+	if err != nil {
+		return StatsResponse{}, err
+	}
+	return newStatsResponse(rows, from, to), nil
+}
+
+// Rollup is the scheduled stats run. It copies the counters of every day still kept in Redis to Postgres,
+// today's counts included, so running it often keeps trending fresh.
+func (s *statsService) Rollup(c *fiber.Ctx) (RollupReport, error) {
+	var report RollupReport
+	ctx := rollupContext(c)
+	now := time.Now()
+
+	for _, day := range activity.RetainedDays(s.config.Activity, now) {
+		counts, err := s.activity.DayCounts(ctx, day)
+		if err != nil {
+			return report, err
+		}
+		if len(counts) == 0 {
+			continue
+		}
+
+		bookIDs := make([]uuid.UUID, 0, len(counts))
+		for bookID := range counts {
+			bookIDs = append(bookIDs, bookID)
+		}
+		existing, err := s.repo.FindExistingBookIDs(c, bookIDs)
+		if err != nil {
+			return report, err
+		}
+
+		rows := make([]BookDailyStat, 0, len(existing))
+		for _, bookID := range existing {
+			events := counts[bookID]
+			rows = append(rows, BookDailyStat{
+				BookID:     bookID,
+				Day:        day,
+				Views:      events[activity.EventView],
+				Loans:      events[activity.EventLoan],
+				Favourites: events[activity.EventFavourite],
+				Reviews:    events[activity.EventReview],
+				UpdatedAt:  now,
+			})
+		}
+		if err := s.repo.UpsertDailyStats(c, rows); err != nil {
+			return report, err
+		}
+		report.Days++
+		report.Rows += len(rows)
+	}
+	return report, nil
+}
+
+func newStatsResponse(rows []DailyRow, from, to time.Time) StatsResponse {
+	days, totals := FillSeries(rows, from, to)
+	return StatsResponse{From: from.Format(time.DateOnly), To: to.Format(time.DateOnly), Totals: totals, Days: days}
+}
+
+// rollupContext is the request context, or a background one when the rollup runs from the CLI
+func rollupContext(c *fiber.Ctx) context.Context {
+	if c == nil {
+		return context.Background()
+	}
+	return c.UserContext()
+}
@@ -0,0 +1,130 @@
+package user_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/user"
+	"github.com/jerpsp/go-fiber-beginner/middleware"
+	"github.com/jerpsp/go-fiber-beginner/mocks"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// memoryTokens keeps the revocations the auth repository keeps in Redis
+type memoryTokens struct {
+	revokedAt map[uuid.UUID]time.Time
+}
+
+func (m *memoryTokens) DeleteUserTokens(c *fiber.Ctx, userID uuid.UUID, tokenType utils.TokenType) error {
+	return nil
+}
+
+func (m *memoryTokens) RevokeAccessTokens(c *fiber.Ctx, userID uuid.UUID) error {
+	m.revokedAt[userID] = time.Unix(time.Now().Unix(), 0)
+	return nil
+}
+
+func (m *memoryTokens) AccessTokensRevokedAt(ctx context.Context, userID uuid.UUID) (time.Time, error) {
+	return m.revokedAt[userID], nil
+}
+
+// AccountDeletionSuite runs the real JWTMiddleware and user service, so a deleted account's tokens are checked end to end
+type AccountDeletionSuite struct {
+	suite.Suite
+	config   *config.Config
+	mockRepo *mocks.UserRepository
+	router   *fiber.App
+}
+
+func TestAccountDeletionSuite(t *testing.T) {
+	suite.Run(t, new(AccountDeletionSuite))
+}
+
+func (s *AccountDeletionSuite) SetupTest() {
+	s.config = &config.Config{JWT: &config.JWT{Secret: "secret", AccessTokenExp: 15 * time.Minute}}
+	s.mockRepo = mocks.NewUserRepository(s.T())
+	tokens := &memoryTokens{revokedAt: map[uuid.UUID]time.Time{}}
+	middleware.UseTokenRevocations(tokens)
+
+	service := user.NewUserService(s.config, s.mockRepo, mocks.NewS3Repository(s.T()), mocks.NewEmailRepository(s.T()), tokens, nil)
+	handler := user.NewUserHandler(s.config, service)
+
+	s.router = fiber.New()
+	userGroup := s.router.Group("api/v1/users", middleware.JWTMiddleware(s.config))
+	{
+		userGroup.Get("/me", handler.GetMe)
+		userGroup.Delete("/me", handler.DeleteMe)
+	}
+}
+
+func (s *AccountDeletionSuite) TearDownTest() {
+	middleware.UseTokenRevocations(nil)
+}
+
+// accessToken signs an access token the way the auth service does, issued a minute ago
+func (s *AccountDeletionSuite) accessToken(account *user.User) string {
+	issuedAt := time.Now().Add(-time.Minute)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": account.ID.String(),
+		"email":   account.Email,
+		"role":    string(account.Role),
+		"type":    string(utils.AccessToken),
+		"exp":     issuedAt.Add(s.config.JWT.AccessTokenExp).Unix(),
+		"iat":     issuedAt.Unix(),
+	})
+	signed, err := token.SignedString([]byte(s.config.JWT.Secret))
+	s.Require().NoError(err)
+	return signed
+}
+
+func (s *AccountDeletionSuite) TestDeleteMe1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Access Token Rejected After Account Deletion", 31)
+
+	// Setup Mock
+	account := &user.User{ID: uuid.New(), Email: "john.doe@example.com", Role: user.RoleUser}
+	_ = account.HashPassword("password123")
+	s.mockRepo.EXPECT().FindUserByID(mock.Anything, account.ID).Return(account, nil).Twice()
+	s.mockRepo.EXPECT().AnonymiseUser(mock.Anything, account.ID, []user.AccountRecords(nil)).Return(nil)
+	token := s.accessToken(account)
+
+	// Setup Request
+	req, _ := http.NewRequest("GET", "/api/v1/users/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	// Setup Request
+	reqBody, _ := json.Marshal(user.DeleteAccountRequest{Password: "password123"})
+	req, _ = http.NewRequest("DELETE", "/api/v1/users/me", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	// Run Test Request
+	resp, _ = s.router.Test(req)
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	// Setup Request
+	req, _ = http.NewRequest("GET", "/api/v1/users/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	// Run Test Request
+	resp, _ = s.router.Test(req)
+
+	var body map[string]string
+	s.NoError(json.NewDecoder(resp.Body).Decode(&body))
+	s.Equal(http.StatusUnauthorized, resp.StatusCode)
+	s.Equal("Token revoked", body["message"])
+}
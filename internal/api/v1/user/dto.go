@@ -1,5 +1,11 @@
 package user
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
 type UserCreateRequest struct {
 	Email     string `json:"email" form:"email" validate:"required,email"`
 	Password  string `json:"password" form:"password" validate:"required"`
@@ -13,6 +19,11 @@ type UserUpdateRequest struct {
 	LastName  string `json:"last_name" form:"last_name" validate:"omitempty"`
 }
 
+// DeleteAccountRequest confirms the password before users delete their own account
+type DeleteAccountRequest struct {
+	Password string `json:"password" validate:"required"`
+}
+
 type UserRoleUpdateRequest struct {
 	Role string `json:"role" form:"role" validate:"required,oneof=admin user moderator"`
 }
@@ -22,12 +33,40 @@ type PaginationRequest struct {
 	Limit int `json:"limit" query:"limit"`
 }
 
+// PaginatedResponse lists []User for admins and []UserProfile for everyone else
 type PaginatedResponse struct {
-	Users      []User `json:"users"`
-	Total      int64  `json:"total"`
-	Page       int    `json:"page"`
-	PerPage    int    `json:"per_page"`
-	TotalPages int    `json:"total_pages"`
+	Users      interface{} `json:"users"`
+	Total      int64       `json:"total"`
+	Page       int         `json:"page"`
+	PerPage    int         `json:"per_page"`
+	TotalPages int         `json:"total_pages"`
+}
+
+// UserProfile is the view of a user given to non-admins. Email and role are only filled in for the user's own
+// profile, reset tokens are never part of it.
+type UserProfile struct {
+	ID           uuid.UUID `json:"id"`
+	Email        string    `json:"email,omitempty"`
+	FirstName    string    `json:"first_name"`
+	LastName     string    `json:"last_name"`
+	Role         UserRole  `json:"role,omitempty"`
+	ProfileImage string    `json:"profile_image"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func NewUserProfile(user User, self bool) UserProfile {
+	profile := UserProfile{
+		ID:           user.ID,
+		FirstName:    user.FirstName,
+		LastName:     user.LastName,
+		ProfileImage: user.ProfileImage,
+		CreatedAt:    user.CreatedAt,
+	}
+	if self {
+		profile.Email = user.Email
+		profile.Role = user.Role
+	}
+	return profile
 }
 
 type ForgotPasswordRequest struct {
@@ -1,10 +1,15 @@
 package user
 
 import (
+	"errors"
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/pkg/patch"
 	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"gorm.io/gorm"
 )
 
 type UserHandler struct {
@@ -39,7 +44,7 @@ func (h *UserHandler) GetAllUsers(c *fiber.Ctx) error {
 	}
 
 	response := PaginatedResponse{
-		Users:      users,
+		Users:      h.userViews(c, users),
 		Total:      total,
 		Page:       pagination.Page,
 		PerPage:    pagination.Limit,
@@ -55,12 +60,47 @@ func (h *UserHandler) GetUserByID(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid ID format"})
 	}
 
+	return h.getUser(c, id)
+}
+
+// GetMe returns the user the access token was issued to
+func (h *UserHandler) GetMe(c *fiber.Ctx) error {
+	id, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"message": "Unauthorized"})
+	}
+
+	return h.getUser(c, id)
+}
+
+func (h *UserHandler) getUser(c *fiber.Ctx, id uuid.UUID) error {
 	user, err := h.service.GetUserByID(c, id)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
 	}
 
-	return c.JSON(fiber.Map{"user": user})
+	return c.JSON(fiber.Map{"user": h.userView(c, *user)})
+}
+
+// userView returns the full user to admins and the profile to everyone else
+func (h *UserHandler) userView(c *fiber.Ctx, user User) interface{} {
+	if role, _ := c.Locals("role").(string); UserRole(role) == RoleAdmin {
+		return user
+	}
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	return NewUserProfile(user, user.ID == userID)
+}
+
+func (h *UserHandler) userViews(c *fiber.Ctx, users []User) interface{} {
+	if role, _ := c.Locals("role").(string); UserRole(role) == RoleAdmin {
+		return users
+	}
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	profiles := make([]UserProfile, 0, len(users))
+	for _, user := range users {
+		profiles = append(profiles, NewUserProfile(user, user.ID == userID))
+	}
+	return profiles
 }
 
 func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
@@ -84,13 +124,43 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 }
 
 func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
-	var user UserUpdateRequest
-
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid ID format"})
 	}
 
+	return h.updateUser(c, id)
+}
+
+// UpdateMe updates the profile of the user the access token was issued to
+func (h *UserHandler) UpdateMe(c *fiber.Ctx) error {
+	id, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"message": "Unauthorized"})
+	}
+
+	return h.updateUser(c, id)
+}
+
+func (h *UserHandler) updateUser(c *fiber.Ctx, id uuid.UUID) error {
+	var user UserUpdateRequest
+
+	// patch documents only touch the fields they mention and can clear optional ones
+	if contentType := c.Get(fiber.HeaderContentType); strings.HasPrefix(contentType, patch.ContentTypeMergePatch) || strings.HasPrefix(contentType, patch.ContentTypeJSONPatch) {
+		if err := h.service.PatchUser(c, id, contentType, c.Body()); err != nil {
+			switch {
+			case errors.Is(err, patch.ErrInvalidPatch), errors.Is(err, ErrInvalidUser):
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Validation failed", "details": err.Error()})
+			case errors.Is(err, patch.ErrTestFailed):
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": err.Error()})
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.JSON(fiber.Map{"message": "User updated successfully"})
+	}
+
 	if err := c.BodyParser(&user); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid request body"})
 	}
@@ -113,12 +183,48 @@ func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
 	}
 
 	if err := h.service.DeleteUser(c, id); err != nil {
+		// loans, holds and fines keep their borrower
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": "User has loans, holds or fines on record and can't be deleted"})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
 	}
 
 	return c.JSON(fiber.Map{"message": "User deleted successfully"})
 }
 
+// DeleteMe deletes the account of the user the access token was issued to, the password has to be confirmed again
+func (h *UserHandler) DeleteMe(c *fiber.Ctx) error {
+	var req DeleteAccountRequest
+
+	id, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"message": "Unauthorized"})
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid request body"})
+	}
+
+	if err := utils.Validate(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Validation failed", "details": err.Error()})
+	}
+
+	if err := h.service.DeleteAccount(c, id, req.Password); err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidPassword):
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, ErrAccountInUse):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{"message": "Account deleted successfully"})
+}
+
 func (h *UserHandler) UpdateUserRole(c *fiber.Ctx) error {
 	var roleUpdate UserRoleUpdateRequest
 
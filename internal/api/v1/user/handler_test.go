@@ -13,10 +13,12 @@ import (
 	"github.com/google/uuid"
 	"github.com/jerpsp/go-fiber-beginner/config"
 	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/user"
+	"github.com/jerpsp/go-fiber-beginner/middleware"
 	"github.com/jerpsp/go-fiber-beginner/mocks"
 	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
 )
 
 type UserHandlerSuite struct {
@@ -42,20 +44,34 @@ func (s *UserHandlerSuite) SetupTest() {
 	s.router = fiber.New()
 
 	// User
-	userGroup := s.router.Group("api/v1/users")
+	userGroup := s.router.Group("api/v1/users", signedIn)
 	{
 		userGroup.Post("/forgot-password", s.userHandler.ForgotPassword)
 		userGroup.Patch("/reset-password", s.userHandler.ResetPassword)
 		userGroup.Get("", s.userHandler.GetAllUsers)
-		userGroup.Get("/:id", s.userHandler.GetUserByID)
+		userGroup.Get("/me", s.userHandler.GetMe)
+		userGroup.Patch("/me", s.userHandler.UpdateMe)
+		userGroup.Delete("/me", s.userHandler.DeleteMe)
+		userGroup.Get("/:id", middleware.SelfOrRole("id", middleware.RoleAdmin, middleware.RoleModerator), s.userHandler.GetUserByID)
 		userGroup.Post("", s.userHandler.CreateUser)
-		userGroup.Patch("/:id", s.userHandler.UpdateUser)
+		userGroup.Patch("/:id", middleware.SelfOrRole("id", middleware.RoleAdmin), s.userHandler.UpdateUser)
 		userGroup.Delete("/:id", s.userHandler.DeleteUser)
 		userGroup.Patch("/:id/role", s.userHandler.UpdateUserRole)
 	}
 
 }
 
+// signedIn stands in for JWTMiddleware and signs the request in as the user in the X-User-ID and X-User-Role headers
+func signedIn(c *fiber.Ctx) error {
+	if userID, err := uuid.Parse(c.Get("X-User-ID")); err == nil {
+		c.Locals("userID", userID)
+	}
+	if role := c.Get("X-User-Role"); role != "" {
+		c.Locals("role", role)
+	}
+	return c.Next()
+}
+
 func (s *UserHandlerSuite) TestGetAllUsers1() {
 	// Case Name Print In Test
 	utils.ConsolePrintColoredText("CASE: Get All User Success", 34)
@@ -68,6 +84,7 @@ func (s *UserHandlerSuite) TestGetAllUsers1() {
 
 	// Setup Request
 	req, _ := http.NewRequest("GET", "/api/v1/users", nil)
+	req.Header.Set("X-User-Role", "admin")
 
 	// Run Test Request
 	resp, _ := s.router.Test(req)
@@ -91,6 +108,7 @@ func (s *UserHandlerSuite) TestGetUser1() {
 
 	// Setup Request
 	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/users/%s", serviceResponse.ID), nil)
+	req.Header.Set("X-User-Role", "admin")
 
 	// Run Test Request
 	resp, _ := s.router.Test(req)
@@ -111,6 +129,7 @@ func (s *UserHandlerSuite) TestGetUser2() {
 
 	// Setup Request
 	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/users/%s", userID), nil)
+	req.Header.Set("X-User-Role", "admin")
 
 	// Run Test Request
 	resp, _ := s.router.Test(req)
@@ -132,6 +151,7 @@ func (s *UserHandlerSuite) TestGetUser3() {
 
 	// Setup Request
 	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/users/%s", userID), nil)
+	req.Header.Set("X-User-Role", "admin")
 
 	// Run Test Request
 	resp, _ := s.router.Test(req)
@@ -143,6 +163,71 @@ func (s *UserHandlerSuite) TestGetUser3() {
 	s.Equal(string(expectedResp), string(actualResp))
 }
 
+func (s *UserHandlerSuite) TestGetUser4() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Get User Profile As Moderator", 34)
+
+	// Setup Mock
+	userID := uuid.New()
+	serviceResponse := user.User{ID: userID, FirstName: "John", LastName: "Doe", Email: "john.doe@example.com", ResetPasswordToken: "secret"}
+	handlerResponse := fiber.Map{"user": user.UserProfile{ID: userID, FirstName: "John", LastName: "Doe"}}
+	s.mockUserSvc.EXPECT().GetUserByID(mock.Anything, userID).Return(&serviceResponse, nil)
+
+	// Setup Request
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/users/%s", userID), nil)
+	req.Header.Set("X-User-ID", uuid.New().String())
+	req.Header.Set("X-User-Role", "moderator")
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	expectedResp, _ := json.Marshal(handlerResponse)
+	actualResp, _ := io.ReadAll(resp.Body)
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal(string(expectedResp), string(actualResp))
+}
+
+func (s *UserHandlerSuite) TestGetUser5() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Get Other User Forbidden", 31)
+
+	// Setup Request
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/users/%s", uuid.New()), nil)
+	req.Header.Set("X-User-ID", uuid.New().String())
+	req.Header.Set("X-User-Role", "user")
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	expectedResp, _ := json.Marshal(fiber.Map{"message": "Access denied: insufficient permissions"})
+	actualResp, _ := io.ReadAll(resp.Body)
+
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+	s.Equal(string(expectedResp), string(actualResp))
+}
+
+func (s *UserHandlerSuite) TestUpdateUser6() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Update Other User As Moderator Forbidden", 31)
+
+	// Setup Request
+	reqBodyBytes, _ := json.Marshal(user.UserUpdateRequest{FirstName: "John"})
+	req, _ := http.NewRequest("PATCH", fmt.Sprintf("/api/v1/users/%s", uuid.New()), bytes.NewBuffer(reqBodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", uuid.New().String())
+	req.Header.Set("X-User-Role", "moderator")
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	expectedResp, _ := json.Marshal(fiber.Map{"message": "Access denied: insufficient permissions"})
+	actualResp, _ := io.ReadAll(resp.Body)
+
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+	s.Equal(string(expectedResp), string(actualResp))
+}
+
 func (s *UserHandlerSuite) TestCreateUser1() {
 	// Case Name Print In Test
 	utils.ConsolePrintColoredText("CASE: Create User Success", 34)
@@ -247,6 +332,7 @@ func (s *UserHandlerSuite) TestUpdateUser1() {
 	}
 	reqBodyBytes, _ := json.Marshal(reqBody)
 	req, _ := http.NewRequest("PATCH", fmt.Sprintf("/api/v1/users/%s", userID), bytes.NewBuffer(reqBodyBytes))
+	req.Header.Set("X-User-Role", "admin")
 	req.Header.Set("Content-Type", "application/json")
 
 	// Run Test Request
@@ -271,6 +357,7 @@ func (s *UserHandlerSuite) TestUpdateUser2() {
 	}
 	reqBodyBytes, _ := json.Marshal(reqBody)
 	req, _ := http.NewRequest("PATCH", fmt.Sprintf("/api/v1/users/%s", userID), bytes.NewBuffer(reqBodyBytes))
+	req.Header.Set("X-User-Role", "admin")
 	req.Header.Set("Content-Type", "application/json")
 
 	// Run Test Request
@@ -295,6 +382,7 @@ func (s *UserHandlerSuite) TestUpdateUser3() {
 	}
 	reqBodyBytes, _ := json.Marshal(reqBody)
 	req, _ := http.NewRequest("PATCH", fmt.Sprintf("/api/v1/users/%s", userID), bytes.NewBuffer(reqBodyBytes))
+	req.Header.Set("X-User-Role", "admin")
 	req.Header.Set("Content-Type", "application/json")
 
 	// Run Test Request
@@ -325,6 +413,7 @@ func (s *UserHandlerSuite) TestUpdateUser4() {
 	}
 	reqBodyBytes, _ := json.Marshal(reqBody)
 	req, _ := http.NewRequest("PATCH", fmt.Sprintf("/api/v1/users/%s", userID), bytes.NewBuffer(reqBodyBytes))
+	req.Header.Set("X-User-Role", "admin")
 	req.Header.Set("Content-Type", "application/json")
 
 	// Run Test Request
@@ -337,6 +426,31 @@ func (s *UserHandlerSuite) TestUpdateUser4() {
 	s.Equal(string(expectedResp), string(actualResp))
 }
 
+func (s *UserHandlerSuite) TestUpdateUser5() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Update User Merge Patch Success", 34)
+
+	// Setup Mock
+	userID := uuid.New()
+	reqBody := `{"last_name": null}`
+	handlerResponse := fiber.Map{"message": "User updated successfully"}
+	s.mockUserSvc.EXPECT().PatchUser(mock.Anything, userID, "application/merge-patch+json", []byte(reqBody)).Return(nil)
+
+	// Setup Request
+	req, _ := http.NewRequest("PATCH", fmt.Sprintf("/api/v1/users/%s", userID), bytes.NewBufferString(reqBody))
+	req.Header.Set("X-User-Role", "admin")
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	expectedResp, _ := json.Marshal(handlerResponse)
+	actualResp, _ := io.ReadAll(resp.Body)
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal(string(expectedResp), string(actualResp))
+}
+
 func (s *UserHandlerSuite) TestDeleteUser1() {
 	// Case Name Print In Test
 	utils.ConsolePrintColoredText("CASE: Delete User Success", 34)
@@ -398,6 +512,136 @@ func (s *UserHandlerSuite) TestDeleteUser3() {
 	s.Equal(string(expectedResp), string(actualResp))
 }
 
+func (s *UserHandlerSuite) TestDeleteUser4() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Delete User With Lending History", 31)
+
+	// Setup Mock
+	userID := uuid.New()
+	s.mockUserSvc.EXPECT().DeleteUser(mock.Anything, userID).Return(gorm.ErrForeignKeyViolated)
+
+	// Setup Request
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/api/v1/users/%s", userID), nil)
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	s.Equal(http.StatusConflict, resp.StatusCode)
+}
+
+func (s *UserHandlerSuite) TestGetMe1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Get Me Success", 34)
+
+	// Setup Mock
+	userID := uuid.New()
+	serviceResponse := &user.User{ID: userID, FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"}
+	s.mockUserSvc.EXPECT().GetUserByID(mock.Anything, userID).Return(serviceResponse, nil)
+
+	// Setup Request
+	req, _ := http.NewRequest("GET", "/api/v1/users/me", nil)
+	req.Header.Set("X-User-ID", userID.String())
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	expectedResp, _ := json.Marshal(fiber.Map{"user": user.NewUserProfile(*serviceResponse, true)})
+	actualResp, _ := io.ReadAll(resp.Body)
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal(string(expectedResp), string(actualResp))
+}
+
+func (s *UserHandlerSuite) TestGetMe2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Get Me Unauthorized", 31)
+
+	// Setup Request
+	req, _ := http.NewRequest("GET", "/api/v1/users/me", nil)
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	expectedResp, _ := json.Marshal(fiber.Map{"message": "Unauthorized"})
+	actualResp, _ := io.ReadAll(resp.Body)
+
+	s.Equal(http.StatusUnauthorized, resp.StatusCode)
+	s.Equal(string(expectedResp), string(actualResp))
+}
+
+func (s *UserHandlerSuite) TestDeleteMe1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Delete Me Success", 34)
+
+	// Setup Mock
+	userID := uuid.New()
+	s.mockUserSvc.EXPECT().DeleteAccount(mock.Anything, userID, "password123").Return(nil)
+
+	// Setup Request
+	reqBody, _ := json.Marshal(user.DeleteAccountRequest{Password: "password123"})
+	req, _ := http.NewRequest("DELETE", "/api/v1/users/me", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", userID.String())
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	expectedResp, _ := json.Marshal(fiber.Map{"message": "Account deleted successfully"})
+	actualResp, _ := io.ReadAll(resp.Body)
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal(string(expectedResp), string(actualResp))
+}
+
+func (s *UserHandlerSuite) TestDeleteMe2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Delete Me Wrong Password", 31)
+
+	// Setup Mock
+	userID := uuid.New()
+	s.mockUserSvc.EXPECT().DeleteAccount(mock.Anything, userID, "wrongpassword").Return(user.ErrInvalidPassword)
+
+	// Setup Request
+	reqBody, _ := json.Marshal(user.DeleteAccountRequest{Password: "wrongpassword"})
+	req, _ := http.NewRequest("DELETE", "/api/v1/users/me", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", userID.String())
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	expectedResp, _ := json.Marshal(fiber.Map{"message": user.ErrInvalidPassword.Error()})
+	actualResp, _ := io.ReadAll(resp.Body)
+
+	s.Equal(http.StatusUnauthorized, resp.StatusCode)
+	s.Equal(string(expectedResp), string(actualResp))
+}
+
+func (s *UserHandlerSuite) TestDeleteMe3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Delete Me With Unpaid Fines", 31)
+
+	// Setup Mock
+	userID := uuid.New()
+	serviceErr := fmt.Errorf("%w: 250 cents of fines are unpaid", user.ErrAccountInUse)
+	s.mockUserSvc.EXPECT().DeleteAccount(mock.Anything, userID, "password123").Return(serviceErr)
+
+	// Setup Request
+	reqBody, _ := json.Marshal(user.DeleteAccountRequest{Password: "password123"})
+	req, _ := http.NewRequest("DELETE", "/api/v1/users/me", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", userID.String())
+
+	// Run Test Request
+	resp, _ := s.router.Test(req)
+
+	expectedResp, _ := json.Marshal(fiber.Map{"message": serviceErr.Error()})
+	actualResp, _ := io.ReadAll(resp.Body)
+
+	s.Equal(http.StatusConflict, resp.StatusCode)
+	s.Equal(string(expectedResp), string(actualResp))
+}
+
 func (s *UserHandlerSuite) TestUpdateUserRole1() {
 	// Case Name Print In Test
 	utils.ConsolePrintColoredText("CASE: Update User Role Success", 34)
@@ -7,6 +7,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/jerpsp/go-fiber-beginner/config"
 	"github.com/jerpsp/go-fiber-beginner/pkg/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserRepository interface {
@@ -16,7 +18,9 @@ type UserRepository interface {
 	FindUserByResetPasswordToken(c *fiber.Ctx, token string, expiresAt time.Time) (*User, error)
 	CreateUser(c *fiber.Ctx, user *User) (*User, error)
 	UpdateUser(c *fiber.Ctx, userID uuid.UUID, user *User) error
+	UpdateUserFields(c *fiber.Ctx, userID uuid.UUID, fields map[string]interface{}) error
 	DeleteUser(c *fiber.Ctx, userID uuid.UUID) error
+	AnonymiseUser(c *fiber.Ctx, userID uuid.UUID, records []AccountRecords) error
 }
 
 type userRepository struct {
@@ -84,9 +88,57 @@ func (r *userRepository) UpdateUser(c *fiber.Ctx, userID uuid.UUID, user *User)
 	return nil
 }
 
+// UpdateUserFields writes the given columns as-is, unlike UpdateUser it also writes zero values
+func (r *userRepository) UpdateUserFields(c *fiber.Ctx, userID uuid.UUID, fields map[string]interface{}) error {
+	if err := r.db.DB.Model(&User{}).Where("id = ?", userID).Updates(fields).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
 func (r *userRepository) DeleteUser(c *fiber.Ctx, userID uuid.UUID) error {
 	if err := r.db.DB.Delete(&User{}, "id = ?", userID).Error; err != nil {
 		return err
 	}
 	return nil
 }
+
+// AnonymiseUser releases the records other packages keep about the user and clears the personal data of the
+// user row in one transaction. The row stays behind the ledger that restricts its delete, locked the way
+// checkouts lock their borrower so no loan can start in between.
+func (r *userRepository) AnonymiseUser(c *fiber.Ctx, userID uuid.UUID, records []AccountRecords) error {
+	var committed []func()
+	err := r.db.DB.Transaction(func(tx *gorm.DB) error {
+		var locked User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", userID).First(&locked).Error; err != nil {
+			return err
+		}
+		for _, record := range records {
+			afterCommit, err := record.ReleaseAccount(c, tx, userID)
+			if err != nil {
+				return err
+			}
+			if afterCommit != nil {
+				committed = append(committed, afterCommit)
+			}
+		}
+		// the ID stands in for the email, which is unique and must not match a login anymore
+		return tx.Model(&User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"email":                  userID.String(),
+			"password":               "",
+			"first_name":             "",
+			"last_name":              "",
+			"active":                 false,
+			"reset_password_token":   "",
+			"reset_password_sent_at": time.Time{},
+			"profile_image":          "",
+		}).Error
+	})
+	if err != nil {
+		return err
+	}
+	for _, afterCommit := range committed {
+		afterCommit()
+	}
+	return nil
+}
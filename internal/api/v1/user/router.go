@@ -12,9 +12,13 @@ func RegisterRoutes(cfg *config.Config, router fiber.Router, handler *UserHandle
 		userRouter.Post("/forgot-password", handler.ForgotPassword)
 		userRouter.Patch("/reset-password", handler.ResetPassword)
 		userRouter.Get("", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.GetAllUsers)
-		userRouter.Get("/:id", middleware.JWTMiddleware(cfg), handler.GetUserByID)
+		// the signed in user, registered before "/:id" so "me" is not parsed as a user ID
+		userRouter.Get("/me", middleware.JWTMiddleware(cfg), handler.GetMe)
+		userRouter.Patch("/me", middleware.JWTMiddleware(cfg), handler.UpdateMe)
+		userRouter.Delete("/me", middleware.JWTMiddleware(cfg), handler.DeleteMe)
+		userRouter.Get("/:id", middleware.JWTMiddleware(cfg), middleware.SelfOrRole("id", middleware.RoleAdmin, middleware.RoleModerator), handler.GetUserByID)
 		userRouter.Post("", middleware.JWTMiddleware(cfg), middleware.AdminOnly(), handler.CreateUser)
-		userRouter.Patch("/:id", middleware.JWTMiddleware(cfg), handler.UpdateUser)
+		userRouter.Patch("/:id", middleware.JWTMiddleware(cfg), middleware.SelfOrRole("id", middleware.RoleAdmin), handler.UpdateUser)
 		userRouter.Patch("/:id/role", middleware.JWTMiddleware(cfg), middleware.AdminOnly(), handler.UpdateUserRole)
 		userRouter.Delete("/:id", middleware.JWTMiddleware(cfg), middleware.AdminOnly(), handler.DeleteUser)
 	}
@@ -1,6 +1,7 @@
 package user
 
 import (
+	"errors"
 	"fmt"
 	"mime/multipart"
 	"path"
@@ -8,10 +9,20 @@ import (
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
 	"github.com/google/uuid"
 	"github.com/jerpsp/go-fiber-beginner/config"
 	"github.com/jerpsp/go-fiber-beginner/pkg/email"
+	"github.com/jerpsp/go-fiber-beginner/pkg/patch"
 	"github.com/jerpsp/go-fiber-beginner/pkg/storage"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidUser     = errors.New("invalid user")
+	ErrInvalidPassword = errors.New("invalid password")
+	ErrAccountInUse    = errors.New("account has open loans, ready holds or unpaid fines")
 )
 
 type UserService interface {
@@ -19,21 +30,42 @@ type UserService interface {
 	GetUserByID(c *fiber.Ctx, userID uuid.UUID) (*User, error)
 	CreateUser(c *fiber.Ctx, userParams UserCreateRequest, file *multipart.FileHeader) (*User, error)
 	UpdateUser(c *fiber.Ctx, userID uuid.UUID, userParams *UserUpdateRequest) error
+	PatchUser(c *fiber.Ctx, userID uuid.UUID, contentType string, body []byte) error
 	DeleteUser(c *fiber.Ctx, userID uuid.UUID) error
 	UpdateUserRole(c *fiber.Ctx, userID uuid.UUID, role UserRole) error
 	ForgotPassword(c *fiber.Ctx, email string) error
 	ResetPassword(c *fiber.Ctx, resetPasswordToken string, newPassword string) error
+	DeleteAccount(c *fiber.Ctx, userID uuid.UUID, password string) error
+}
+
+// TokenStore revokes the tokens of a user. auth.AuthRepository implements it, the interface lives here
+// because the auth package depends on this one.
+type TokenStore interface {
+	DeleteUserTokens(c *fiber.Ctx, userID uuid.UUID, tokenType utils.TokenType) error
+	RevokeAccessTokens(c *fiber.Ctx, userID uuid.UUID) error
+}
+
+// AccountRecords is what another package keeps about a user. ReleaseAccount runs inside the transaction that
+// deletes an account and fails it with ErrAccountInUse while the records still bind the user, the returned func
+// runs once the transaction committed. Like TokenStore the interface lives here because those packages depend on
+// this one.
+type AccountRecords interface {
+	ReleaseAccount(c *fiber.Ctx, tx *gorm.DB, userID uuid.UUID) (func(), error)
 }
 
 type userService struct {
-	config    *config.Config
-	repo      UserRepository
-	s3Repo    storage.S3Repository
-	emailRepo email.EmailRepository
+	config         *config.Config
+	repo           UserRepository
+	s3Repo         storage.S3Repository
+	emailRepo      email.EmailRepository
+	tokenStore     TokenStore
+	accountRecords []AccountRecords
 }
 
-func NewUserService(config *config.Config, repo UserRepository, s3Repo storage.S3Repository, emailRepo email.EmailRepository) UserService {
-	return &userService{config: config, repo: repo, s3Repo: s3Repo, emailRepo: emailRepo}
+func NewUserService(config *config.Config, repo UserRepository, s3Repo storage.S3Repository, emailRepo email.EmailRepository,
+	tokenStore TokenStore, accountRecords []AccountRecords) UserService {
+	return &userService{config: config, repo: repo, s3Repo: s3Repo, emailRepo: emailRepo, tokenStore: tokenStore,
+		accountRecords: accountRecords}
 }
 
 func (s *userService) GetAllUsers(c *fiber.Ctx, page, limit int) ([]User, int64, error) {
@@ -116,6 +148,29 @@ func (s *userService) UpdateUser(c *fiber.Ctx, userID uuid.UUID, userParams *Use
 	return nil
 }
 
+// PatchUser applies a merge patch or JSON patch to the user's profile fields and writes only what changed
+func (s *userService) PatchUser(c *fiber.Ctx, userID uuid.UUID, contentType string, body []byte) error {
+	user, err := s.repo.FindUserByID(c, userID)
+	if err != nil {
+		return err
+	}
+
+	current := UserUpdateRequest{FirstName: user.FirstName, LastName: user.LastName}
+	patched := current
+	if err := patch.Apply(&patched, contentType, body); err != nil {
+		return err
+	}
+	if err := utils.Validate(&patched); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidUser, err)
+	}
+
+	changes := patch.Changes(current, patched)
+	if len(changes) == 0 {
+		return nil
+	}
+	return s.repo.UpdateUserFields(c, userID, changes)
+}
+
 func (s *userService) DeleteUser(c *fiber.Ctx, userID uuid.UUID) error {
 	if err := s.repo.DeleteUser(c, userID); err != nil {
 		return err
@@ -123,6 +178,32 @@ func (s *userService) DeleteUser(c *fiber.Ctx, userID uuid.UUID) error {
 	return nil
 }
 
+// DeleteAccount deletes the user's own account after the password was confirmed again. The user row is anonymised
+// rather than deleted so the lending ledger keeps its borrower. The access tokens issued so far are revoked and
+// the refresh tokens deleted, so the account can't be used again.
+func (s *userService) DeleteAccount(c *fiber.Ctx, userID uuid.UUID, password string) error {
+	user, err := s.repo.FindUserByID(c, userID)
+	if err != nil {
+		return err
+	}
+	if !user.CheckPassword(password) {
+		return ErrInvalidPassword
+	}
+
+	if err := s.repo.AnonymiseUser(c, userID, s.accountRecords); err != nil {
+		return err
+	}
+	if user.ProfileImage != "" {
+		if err := s.s3Repo.DeletePublicFile(user.ProfileImage); err != nil {
+			log.Errorf("failed to delete profile image %s of user %s: %v", user.ProfileImage, userID, err)
+		}
+	}
+	if err := s.tokenStore.RevokeAccessTokens(c, userID); err != nil {
+		return err
+	}
+	return s.tokenStore.DeleteUserTokens(c, userID, utils.RefreshToken)
+}
+
 func (s *userService) UpdateUserRole(c *fiber.Ctx, userID uuid.UUID, role UserRole) error {
 	user := &User{
 		Role: role,
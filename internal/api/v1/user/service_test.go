@@ -21,6 +21,8 @@ type UserServiceSuite struct {
 	mockRepo    *mocks.UserRepository
 	mockStorage *mocks.S3Repository
 	mockEmail   *mocks.EmailRepository
+	mockTokens  *mocks.TokenStore
+	mockRecords *mocks.AccountRecords
 	service     user.UserService
 }
 
@@ -31,7 +33,10 @@ func (s *UserServiceSuite) SetupTest() {
 	s.mockRepo = mocks.NewUserRepository(s.T())
 	s.mockStorage = mocks.NewS3Repository(s.T())
 	s.mockEmail = mocks.NewEmailRepository(s.T())
-	s.service = user.NewUserService(&config.Config{Email: &email.EmailConfig{ResetPasswordURL: "http://localhost:3000", ResetPasswordExpiresIn: 1800}}, s.mockRepo, s.mockStorage, s.mockEmail)
+	s.mockTokens = mocks.NewTokenStore(s.T())
+	s.mockRecords = mocks.NewAccountRecords(s.T())
+	s.service = user.NewUserService(&config.Config{Email: &email.EmailConfig{ResetPasswordURL: "http://localhost:3000", ResetPasswordExpiresIn: 1800}}, s.mockRepo, s.mockStorage, s.mockEmail, s.mockTokens,
+		[]user.AccountRecords{s.mockRecords})
 }
 
 func (s *UserServiceSuite) TestGetAllUsers1() {
@@ -182,6 +187,39 @@ func (s *UserServiceSuite) TestUpdateUser2() {
 	s.Error(err)
 }
 
+func (s *UserServiceSuite) TestPatchUser1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Patch User Clears Last Name", 34)
+
+	// Setup Mock
+	userID := uuid.New()
+	repoResponse := &user.User{ID: userID, FirstName: "John", LastName: "Doe"}
+	s.mockRepo.EXPECT().FindUserByID(mock.Anything, userID).Return(repoResponse, nil)
+	s.mockRepo.EXPECT().UpdateUserFields(mock.Anything, userID, map[string]interface{}{"last_name": ""}).Return(nil)
+
+	// Call the service method
+	err := s.service.PatchUser(&fiber.Ctx{}, userID, "application/merge-patch+json", []byte(`{"last_name": null}`))
+
+	// Assertions
+	s.NoError(err)
+}
+
+func (s *UserServiceSuite) TestPatchUser2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Patch User Fail Validation", 34)
+
+	// Setup Mock
+	userID := uuid.New()
+	repoResponse := &user.User{ID: userID, FirstName: "John", LastName: "Doe"}
+	s.mockRepo.EXPECT().FindUserByID(mock.Anything, userID).Return(repoResponse, nil)
+
+	// Call the service method
+	err := s.service.PatchUser(&fiber.Ctx{}, userID, "application/json-patch+json", []byte(`[{"op": "remove", "path": "/first_name"}]`))
+
+	// Assertions
+	s.ErrorIs(err, user.ErrInvalidUser)
+}
+
 func (s *UserServiceSuite) TestDeleteUser1() {
 	// Case Name Print In Test
 	utils.ConsolePrintColoredText("CASE: Delete User Success", 34)
@@ -212,6 +250,61 @@ func (s *UserServiceSuite) TestDeleteUser2() {
 	s.Error(err)
 }
 
+func (s *UserServiceSuite) TestDeleteAccount1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Delete Account Success", 34)
+
+	// Setup Mock
+	userResponse := &user.User{ID: uuid.New(), Email: "john.doe@example.com", Role: user.RoleUser, ProfileImage: "john.png"}
+	_ = userResponse.HashPassword("password123")
+	s.mockRepo.EXPECT().FindUserByID(mock.Anything, userResponse.ID).Return(userResponse, nil)
+	s.mockRepo.EXPECT().AnonymiseUser(mock.Anything, userResponse.ID, []user.AccountRecords{s.mockRecords}).Return(nil)
+	s.mockStorage.EXPECT().DeletePublicFile("john.png").Return(nil)
+	s.mockTokens.EXPECT().RevokeAccessTokens(mock.Anything, userResponse.ID).Return(nil)
+	s.mockTokens.EXPECT().DeleteUserTokens(mock.Anything, userResponse.ID, utils.RefreshToken).Return(nil)
+
+	// Call the service method
+	err := s.service.DeleteAccount(&fiber.Ctx{}, userResponse.ID, "password123")
+
+	// Assertions
+	s.NoError(err)
+}
+
+func (s *UserServiceSuite) TestDeleteAccount2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Delete Account Wrong Password", 31)
+
+	// Setup Mock
+	userResponse := &user.User{ID: uuid.New(), Email: "john.doe@example.com", Role: user.RoleUser}
+	_ = userResponse.HashPassword("password123")
+	s.mockRepo.EXPECT().FindUserByID(mock.Anything, userResponse.ID).Return(userResponse, nil)
+
+	// Call the service method
+	err := s.service.DeleteAccount(&fiber.Ctx{}, userResponse.ID, "wrongpassword")
+
+	// Assertions
+	s.ErrorIs(err, user.ErrInvalidPassword)
+}
+
+func (s *UserServiceSuite) TestDeleteAccount3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Delete Account With Open Loans", 31)
+
+	// Setup Mock
+	// the tokens stay valid and nothing is deleted while the account is in use
+	userResponse := &user.User{ID: uuid.New(), Email: "john.doe@example.com", Role: user.RoleUser, ProfileImage: "john.png"}
+	_ = userResponse.HashPassword("password123")
+	s.mockRepo.EXPECT().FindUserByID(mock.Anything, userResponse.ID).Return(userResponse, nil)
+	s.mockRepo.EXPECT().AnonymiseUser(mock.Anything, userResponse.ID, []user.AccountRecords{s.mockRecords}).
+		Return(fmt.Errorf("%w: 2 copies are still on loan", user.ErrAccountInUse))
+
+	// Call the service method
+	err := s.service.DeleteAccount(&fiber.Ctx{}, userResponse.ID, "password123")
+
+	// Assertions
+	s.ErrorIs(err, user.ErrAccountInUse)
+}
+
 func (s *UserServiceSuite) TestUpdateUserRole1() {
 	// Case Name Print In Test
 	utils.ConsolePrintColoredText("CASE: Update User Role Success", 34)
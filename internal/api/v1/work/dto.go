@@ -0,0 +1,12 @@
+package work
+
+// WorkRequest is the writable view of a work, json names double as column names for partial updates
+type WorkRequest struct {
+	Title            string `json:"title" validate:"required,max=255"`
+	Description      string `json:"description" validate:"omitempty"`
+	OriginalLanguage string `json:"original_language" validate:"omitempty,bcp47_language_tag"`
+}
+
+type WorkFilter struct {
+	Query string `query:"q"`
+}
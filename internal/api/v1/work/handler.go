@@ -0,0 +1,95 @@
+package work
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/pkg/patch"
+)
+
+type WorkHandler struct {
+	config  *config.Config
+	service WorkService
+}
+
+func NewWorkHandler(config *config.Config, service WorkService) *WorkHandler {
+	return &WorkHandler{config: config, service: service}
+}
+
+// Handler methods
+func (h *WorkHandler) GetWorks(c *fiber.Ctx) error {
+	var filter WorkFilter
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid filter parameters"})
+	}
+
+	works, err := h.service.GetWorks(c, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"works": works})
+}
+
+func (h *WorkHandler) GetWork(c *fiber.Ctx) error {
+	workID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid work ID"})
+	}
+
+	work, err := h.service.GetWork(c, workID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"work": work})
+}
+
+func (h *WorkHandler) CreateWork(c *fiber.Ctx) error {
+	var workParams WorkRequest
+	if err := c.BodyParser(&workParams); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid input"})
+	}
+
+	work, err := h.service.CreateWork(c, workParams)
+	if err != nil {
+		if errors.Is(err, ErrInvalidWork) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"work": work})
+}
+
+func (h *WorkHandler) UpdateWork(c *fiber.Ctx) error {
+	workID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid work ID"})
+	}
+
+	work, err := h.service.UpdateWork(c, workID, c.Get(fiber.HeaderContentType), c.Body())
+	if err != nil {
+		switch {
+		case errors.Is(err, patch.ErrUnsupportedMediaType):
+			return c.Status(fiber.StatusUnsupportedMediaType).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, patch.ErrInvalidPatch), errors.Is(err, ErrInvalidWork):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": err.Error()})
+		case errors.Is(err, patch.ErrTestFailed):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"message": err.Error()})
+		}
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"work": work})
+}
+
+func (h *WorkHandler) DeleteWork(c *fiber.Ctx) error {
+	workID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid work ID"})
+	}
+
+	if err := h.service.DeleteWork(c, workID); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"message": err.Error()})
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
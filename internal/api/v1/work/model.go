@@ -0,0 +1,17 @@
+package work
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Work is the abstract book that editions share, a hardcover, a paperback and a translation are all the same work
+type Work struct {
+	ID               uuid.UUID `gorm:"type:uuid; default:uuid_generate_v4()" json:"id"`
+	Title            string    `gorm:"size:255;not null;index" json:"title"`
+	Description      string    `gorm:"type:text" json:"description"`
+	OriginalLanguage string    `gorm:"size:35" json:"original_language"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
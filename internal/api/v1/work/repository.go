@@ -0,0 +1,90 @@
+package work
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/pkg/database"
+	"gorm.io/gorm"
+)
+
+type WorkRepository interface {
+	FindAllWorks(c *fiber.Ctx, filter WorkFilter) ([]Work, error)
+	FindWorkByID(c *fiber.Ctx, workID uuid.UUID) (Work, error)
+	CreateWork(c *fiber.Ctx, newWork Work) (Work, error)
+	UpdateWorkFields(c *fiber.Ctx, workID uuid.UUID, fields map[string]interface{}) error
+	DeleteWork(c *fiber.Ctx, workID uuid.UUID) error
+	FindWorkBookIDs(c *fiber.Ctx, workID uuid.UUID) ([]uuid.UUID, error)
+}
+
+type workRepository struct {
+	config *config.Config
+	db     *database.GormDB
+}
+
+func NewWorkRepository(cfg *config.Config, db *database.GormDB) WorkRepository {
+	return &workRepository{config: cfg, db: db}
+}
+
+// Repository methods
+
+func (r *workRepository) FindAllWorks(c *fiber.Ctx, filter WorkFilter) ([]Work, error) {
+	var works []Work
+	query := r.db.DB.Order("title")
+	if filter.Query != "" {
+		query = query.Where("title ILIKE ?", "%"+strings.TrimSpace(filter.Query)+"%")
+	}
+	if err := query.Find(&works).Error; err != nil {
+		return nil, err
+	}
+	return works, nil
+}
+
+func (r *workRepository) FindWorkByID(c *fiber.Ctx, workID uuid.UUID) (Work, error) {
+	var work Work
+	if err := r.db.DB.Where("id = ?", workID).First(&work).Error; err != nil {
+		return Work{}, err
+	}
+	return work, nil
+}
+
+func (r *workRepository) CreateWork(c *fiber.Ctx, newWork Work) (Work, error) {
+	if err := r.db.DB.Create(&newWork).Error; err != nil {
+		return Work{}, err
+	}
+	return newWork, nil
+}
+
+func (r *workRepository) UpdateWorkFields(c *fiber.Ctx, workID uuid.UUID, fields map[string]interface{}) error {
+	result := r.db.DB.Model(&Work{}).Where("id = ?", workID).Updates(fields)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteWork removes the work, its editions stay in the catalogue without a work
+func (r *workRepository) DeleteWork(c *fiber.Ctx, workID uuid.UUID) error {
+	result := r.db.DB.Delete(&Work{}, "id = ?", workID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// FindWorkBookIDs lists the editions of the work, the books table belongs to the book package
+func (r *workRepository) FindWorkBookIDs(c *fiber.Ctx, workID uuid.UUID) ([]uuid.UUID, error) {
+	var bookIDs []uuid.UUID
+	if err := r.db.DB.Table("books").Where("work_id = ?", workID).Pluck("id", &bookIDs).Error; err != nil {
+		return nil, err
+	}
+	return bookIDs, nil
+}
@@ -0,0 +1,23 @@
+package work
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/middleware"
+)
+
+func RegisterRoutes(cfg *config.Config, router fiber.Router, handler *WorkHandler) {
+	workGroup := router.Group("/works")
+	{
+		// Public routes - anyone can access
+		workGroup.Get("", handler.GetWorks)
+		workGroup.Get("/:id", handler.GetWork)
+
+		// Moderator or Admin routes - only moderators and admins can curate works
+		workGroup.Post("", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.CreateWork)
+		workGroup.Patch("/:id", middleware.JWTMiddleware(cfg), middleware.ModeratorOrAdmin(), handler.UpdateWork)
+
+		// Admin only routes - only admins can delete works
+		workGroup.Delete("/:id", middleware.JWTMiddleware(cfg), middleware.AdminOnly(), handler.DeleteWork)
+	}
+}
@@ -0,0 +1,112 @@
+package work
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/pkg/patch"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+)
+
+var ErrInvalidWork = errors.New("invalid work")
+
+// Setup
+type WorkService interface {
+	GetWorks(c *fiber.Ctx, filter WorkFilter) ([]Work, error)
+	GetWork(c *fiber.Ctx, workID uuid.UUID) (Work, error)
+	CreateWork(c *fiber.Ctx, workParams WorkRequest) (Work, error)
+	UpdateWork(c *fiber.Ctx, workID uuid.UUID, contentType string, body []byte) (Work, error)
+	DeleteWork(c *fiber.Ctx, workID uuid.UUID) error
+}
+
+// BookCache drops cached copies of books, it is implemented by the book package which embeds the work in its books
+type BookCache interface {
+	InvalidateBooks(c *fiber.Ctx, bookIDs ...uuid.UUID)
+}
+
+type workService struct {
+	config    *config.Config
+	repo      WorkRepository
+	bookCache BookCache
+}
+
+func NewWorkService(config *config.Config, repo WorkRepository, bookCache BookCache) WorkService {
+	return &workService{config: config, repo: repo, bookCache: bookCache}
+}
+
+// Service methods
+func (s *workService) GetWorks(c *fiber.Ctx, filter WorkFilter) ([]Work, error) {
+	return s.repo.FindAllWorks(c, filter)
+}
+
+func (s *workService) GetWork(c *fiber.Ctx, workID uuid.UUID) (Work, error) {
+	return s.repo.FindWorkByID(c, workID)
+}
+
+func (s *workService) CreateWork(c *fiber.Ctx, workParams WorkRequest) (Work, error) {
+	workParams.normalize()
+	if err := utils.Validate(&workParams); err != nil {
+		return Work{}, fmt.Errorf("%w: %v", ErrInvalidWork, err)
+	}
+
+	newWork := Work{Title: workParams.Title, Description: workParams.Description, OriginalLanguage: workParams.OriginalLanguage}
+	return s.repo.CreateWork(c, newWork)
+}
+
+// UpdateWork applies a merge patch or JSON patch and writes only the columns it changed
+func (s *workService) UpdateWork(c *fiber.Ctx, workID uuid.UUID, contentType string, body []byte) (Work, error) {
+	work, err := s.repo.FindWorkByID(c, workID)
+	if err != nil {
+		return Work{}, err
+	}
+
+	current := WorkRequest{Title: work.Title, Description: work.Description, OriginalLanguage: work.OriginalLanguage}
+	patched := current
+	if err := patch.Apply(&patched, contentType, body); err != nil {
+		return Work{}, err
+	}
+	patched.normalize()
+	if err := utils.Validate(&patched); err != nil {
+		return Work{}, fmt.Errorf("%w: %v", ErrInvalidWork, err)
+	}
+
+	if changes := patch.Changes(current, patched); len(changes) > 0 {
+		bookIDs := s.findBookIDs(c, workID)
+		if err := s.repo.UpdateWorkFields(c, workID, changes); err != nil {
+			return Work{}, err
+		}
+		s.bookCache.InvalidateBooks(c, bookIDs...)
+	}
+
+	return s.GetWork(c, workID)
+}
+
+func (s *workService) DeleteWork(c *fiber.Ctx, workID uuid.UUID) error {
+	// the editions are looked up first, the delete unlinks them
+	bookIDs := s.findBookIDs(c, workID)
+	if err := s.repo.DeleteWork(c, workID); err != nil {
+		return err
+	}
+	s.bookCache.InvalidateBooks(c, bookIDs...)
+	return nil
+}
+
+// findBookIDs lists the editions whose cached copies show the work, a failed lookup leaves them to expire
+func (s *workService) findBookIDs(c *fiber.Ctx, workID uuid.UUID) []uuid.UUID {
+	bookIDs, err := s.repo.FindWorkBookIDs(c, workID)
+	if err != nil {
+		log.Errorf("failed to find the editions of the work: %v", err)
+	}
+	return bookIDs
+}
+
+func (r *WorkRequest) normalize() {
+	r.Title = strings.Join(strings.Fields(r.Title), " ")
+	r.Description = strings.TrimSpace(r.Description)
+	r.OriginalLanguage = strings.TrimSpace(r.OriginalLanguage)
+}
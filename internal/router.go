@@ -3,27 +3,75 @@ package internal
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/expvar"
 	"github.com/gofiber/fiber/v2/middleware/helmet"
 	"github.com/gofiber/fiber/v2/middleware/limiter"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/jerpsp/go-fiber-beginner/config"
 	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/auth"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/author"
 	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/category"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/circulation"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/readinglist"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/recommendation"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/review"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/series"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/stats"
 	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/user"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/work"
 	"github.com/jerpsp/go-fiber-beginner/middleware"
 )
 
-func StartServer(cfg *config.Config, bookHandler *book.BookHandler,
-	userHandler *user.UserHandler, authHandler *auth.AuthHandler) {
+// bookFileUploadPath is the e-book upload route, which checks the body against its own, larger limit
+var bookFileUploadPath = regexp.MustCompile(`^/api/v1/books/[^/]+/files$`)
 
+func StartServer(cfg *config.Config, bookHandler *book.BookHandler, authorHandler *author.AuthorHandler,
+	categoryHandler *category.CategoryHandler, workHandler *work.WorkHandler, seriesHandler *series.SeriesHandler, reviewHandler *review.ReviewHandler,
+	readingListHandler *readinglist.ReadingListHandler, circulationHandler *circulation.CirculationHandler,
+	recommendationHandler *recommendation.RecommendationHandler, statsHandler *stats.StatsHandler, userHandler *user.UserHandler, authHandler *auth.AuthHandler) {
+
+	app := NewApp(cfg)
+	apiV1 := app.Group("/api/v1")
+
+	auth.RegisterRoutes(cfg, apiV1, authHandler)
+	// stats first, "/books/trending" would otherwise be taken for a book ID
+	stats.RegisterRoutes(cfg, apiV1, statsHandler)
+	book.RegisterRoutes(cfg, apiV1, bookHandler)
+	author.RegisterRoutes(cfg, apiV1, authorHandler)
+	category.RegisterRoutes(cfg, apiV1, categoryHandler)
+	work.RegisterRoutes(cfg, apiV1, workHandler)
+	series.RegisterRoutes(cfg, apiV1, seriesHandler)
+	review.RegisterRoutes(cfg, apiV1, reviewHandler)
+	readinglist.RegisterRoutes(cfg, apiV1, readingListHandler)
+	circulation.RegisterRoutes(cfg, apiV1, circulationHandler)
+	recommendation.RegisterRoutes(cfg, apiV1, recommendationHandler)
+	user.RegisterRoutes(cfg, apiV1, userHandler)
+
+	// Use PORT from environment if available, otherwise use config
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = strconv.Itoa(cfg.Server.Port)
+	}
+
+	app.Listen(fmt.Sprintf(":%s", port))
+}
+
+// NewApp sets up the server with the middleware every route goes through, the API routes are registered on it
+func NewApp(cfg *config.Config) *fiber.App {
+	// bodies are streamed rather than buffered up front, the BodyLimit middleware below bounds them per route
+	// and multipart forms are parsed by the handlers that read them
 	app := fiber.New(fiber.Config{
-		CaseSensitive: true,
-		ReadTimeout:   cfg.Server.Timeout * time.Second,
-		WriteTimeout:  cfg.Server.Timeout * time.Second,
+		CaseSensitive:                true,
+		ReadTimeout:                  cfg.Server.Timeout * time.Second,
+		WriteTimeout:                 cfg.Server.Timeout * time.Second,
+		StreamRequestBody:            true,
+		DisablePreParseMultipartForm: true,
 	})
 
 	app.Use(recover.New())
@@ -36,6 +84,12 @@ func StartServer(cfg *config.Config, bookHandler *book.BookHandler,
 		Expiration:        1 * time.Minute,
 		LimiterMiddleware: limiter.SlidingWindow{},
 	}))
+	app.Use(middleware.BodyLimit(middleware.BodyLimitConfig{
+		Limit: fiber.DefaultBodyLimit,
+		Next: func(c *fiber.Ctx) bool {
+			return c.Method() == fiber.MethodPost && bookFileUploadPath.MatchString(c.Path())
+		},
+	}))
 
 	app.Get("/health", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{
@@ -44,17 +98,8 @@ func StartServer(cfg *config.Config, bookHandler *book.BookHandler,
 		})
 	})
 
-	apiV1 := app.Group("/api/v1")
-
-	auth.RegisterRoutes(cfg, apiV1, authHandler)
-	book.RegisterRoutes(cfg, apiV1, bookHandler)
-	user.RegisterRoutes(cfg, apiV1, userHandler)
-
-	// Use PORT from environment if available, otherwise use config
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = strconv.Itoa(cfg.Server.Port)
-	}
+	// Runtime counters such as the book cache hit rate, admins only
+	app.Use("/debug/vars", middleware.JWTMiddleware(cfg), middleware.AdminOnly(), expvar.New())
 
-	app.Listen(fmt.Sprintf(":%s", port))
+	return app
 }
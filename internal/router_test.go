@@ -0,0 +1,111 @@
+package internal_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/config"
+	"github.com/jerpsp/go-fiber-beginner/internal"
+	"github.com/jerpsp/go-fiber-beginner/internal/api/v1/book"
+	"github.com/jerpsp/go-fiber-beginner/mocks"
+	"github.com/jerpsp/go-fiber-beginner/pkg/activity"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// RouterSuite runs the book routes behind the server's own middleware, so the body limits are checked as deployed
+type RouterSuite struct {
+	suite.Suite
+	config      *config.Config
+	mockBookSvc *mocks.BookService
+	app         *fiber.App
+}
+
+func TestRouterSuite(t *testing.T) {
+	suite.Run(t, new(RouterSuite))
+}
+
+func (s *RouterSuite) SetupTest() {
+	s.config = &config.Config{
+		Server:    &config.Server{Timeout: 10, AllowOrigins: "http://localhost:3000"},
+		JWT:       &config.JWT{Secret: "secret", AccessTokenExp: 15 * time.Minute},
+		BookFiles: &config.BookFiles{MaxSize: 10 * 1024 * 1024},
+	}
+	s.mockBookSvc = mocks.NewBookService(s.T())
+	handler := book.NewBookHandler(s.config, s.mockBookSvc, activity.New(&activity.ActivityConfig{}, nil), nil)
+
+	s.app = internal.NewApp(s.config)
+	book.RegisterRoutes(s.config, s.app.Group("/api/v1"), handler)
+}
+
+// moderatorToken signs an access token of a moderator the way the auth service does
+func (s *RouterSuite) moderatorToken() string {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": uuid.New().String(),
+		"email":   "jane.doe@example.com",
+		"role":    "moderator",
+		"type":    string(utils.AccessToken),
+		"exp":     time.Now().Add(s.config.JWT.AccessTokenExp).Unix(),
+		"iat":     time.Now().Unix(),
+	})
+	signed, err := token.SignedString([]byte(s.config.JWT.Secret))
+	s.Require().NoError(err)
+	return signed
+}
+
+func (s *RouterSuite) TestBodyLimit1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Oversized JSON Body On A Non-Upload Route", 31)
+
+	// the description alone is past the default limit
+	reqBody, _ := json.Marshal(book.Book{Title: "Dune", Description: strings.Repeat("a", fiber.DefaultBodyLimit)})
+
+	// Setup Request
+	req, _ := http.NewRequest("POST", "/api/v1/books", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.moderatorToken())
+
+	// Run Test Request
+	resp, err := s.app.Test(req, -1)
+
+	s.Require().NoError(err)
+	s.Equal(http.StatusRequestEntityTooLarge, resp.StatusCode)
+}
+
+func (s *RouterSuite) TestBodyLimit2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Multipart Cover Upload Still Parses", 34)
+
+	// Setup Mock
+	bookID := uuid.New()
+	cover := bytes.Repeat([]byte{0x89}, 64*1024)
+	s.mockBookSvc.EXPECT().UploadCover(mock.Anything, bookID, mock.MatchedBy(func(file *multipart.FileHeader) bool {
+		return file.Filename == "dune.png" && file.Size == int64(len(cover))
+	})).Return(book.Book{ID: bookID, Title: "Dune", Version: 2}, nil)
+
+	// Setup Request
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("cover", "dune.png")
+	_, _ = part.Write(cover)
+	_ = writer.Close()
+	req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/books/%s/cover", bookID), body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+s.moderatorToken())
+
+	// Run Test Request
+	resp, err := s.app.Test(req, -1)
+
+	s.Require().NoError(err)
+	s.Equal(http.StatusOK, resp.StatusCode)
+}
@@ -1,7 +1,10 @@
 package middleware
 
 import (
+	"slices"
+
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
 // UserRole type for roles
@@ -45,6 +48,28 @@ func RoleAuthorization(allowedRoles ...UserRole) fiber.Handler {
 	}
 }
 
+// SelfOrRole middleware lets users act on their own record, named by the given route parameter, and users with
+// one of the allowed roles act on any record
+func SelfOrRole(param string, allowedRoles ...UserRole) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		// Get the user ID and role from the context (set by JWTMiddleware)
+		if userID, ok := c.Locals("userID").(uuid.UUID); ok {
+			if id, err := uuid.Parse(c.Params(param)); err == nil && id == userID {
+				return c.Next()
+			}
+		}
+
+		role, _ := c.Locals("role").(string)
+		if !slices.Contains(allowedRoles, UserRole(role)) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"message": "Access denied: insufficient permissions",
+			})
+		}
+
+		return c.Next()
+	}
+}
+
 // AdminOnly middleware restricts access to admin users only
 func AdminOnly() fiber.Handler {
 	return RoleAuthorization(RoleAdmin)
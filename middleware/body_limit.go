@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BodyLimitConfig sets the largest request body in bytes, Next skips the check for routes that set their own limit
+type BodyLimitConfig struct {
+	Next  func(c *fiber.Ctx) bool
+	Limit int
+}
+
+// BodyLimit answers 413 for request bodies over the limit. The server streams request bodies instead of
+// buffering them, so this check is what keeps a body from being read past the limit of its route.
+func BodyLimit(config BodyLimitConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if config.Next != nil && config.Next(c) {
+			return c.Next()
+		}
+
+		length := c.Request().Header.ContentLength()
+		if length > config.Limit {
+			return bodyTooLarge(c)
+		}
+		// a chunked body has no length up front, it is read here up to one byte past the limit
+		if length == -1 {
+			if stream := c.Request().BodyStream(); stream != nil {
+				body, err := io.ReadAll(io.LimitReader(stream, int64(config.Limit)+1))
+				if err != nil {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"message": "Invalid request body"})
+				}
+				if len(body) > config.Limit {
+					return bodyTooLarge(c)
+				}
+				c.Request().SetBody(body)
+			}
+		}
+		return c.Next()
+	}
+}
+
+// bodyTooLarge closes the connection as well, the rest of the body is not worth reading
+func bodyTooLarge(c *fiber.Ctx) error {
+	c.Context().SetConnectionClose()
+	return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{"message": "Request body too large"})
+}
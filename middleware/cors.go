@@ -18,9 +18,9 @@ import (
 func Cors(cfg *config.Config) fiber.Handler {
 	return cors.New(cors.Config{
 		AllowOrigins:     cfg.Server.AllowOrigins,
-		AllowMethods:     "GET,POST,PATCH,DELETE,OPTIONS",
-		AllowHeaders:     "Origin,Content-Type,Accept,Authorization",
+		AllowMethods:     "GET,POST,PUT,PATCH,DELETE,OPTIONS",
+		AllowHeaders:     "Origin,Content-Type,Accept,Authorization,If-Match,If-None-Match",
 		AllowCredentials: true,
-		ExposeHeaders:    "Authorization",
+		ExposeHeaders:    "Authorization,ETag,Content-Disposition",
 	})
 }
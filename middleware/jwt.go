@@ -1,13 +1,29 @@
 package middleware
 
 import (
+	"context"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"github.com/jerpsp/go-fiber-beginner/config"
 	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
 )
 
+// TokenRevocations reports when the access tokens of a user were last revoked, the zero time means never
+type TokenRevocations interface {
+	AccessTokensRevokedAt(ctx context.Context, userID uuid.UUID) (time.Time, error)
+}
+
+var tokenRevocations TokenRevocations
+
+// UseTokenRevocations makes JWTMiddleware reject access tokens issued before their user's tokens were revoked.
+// Access tokens are not stored, so this is the only way to end one before it expires.
+func UseTokenRevocations(revocations TokenRevocations) {
+	tokenRevocations = revocations
+}
+
 // Protected is a middleware that checks if the user is authenticated
 func JWTMiddleware(cfg *config.Config) fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -45,6 +61,21 @@ func JWTMiddleware(cfg *config.Config) fiber.Handler {
 			})
 		}
 
+		// a token issued in the second of the revocation is revoked too, iat has no finer resolution
+		if tokenRevocations != nil {
+			revokedAt, err := tokenRevocations.AccessTokensRevokedAt(c.UserContext(), userInfo.ID)
+			if err != nil {
+				return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+					"message": "Unable to verify token",
+				})
+			}
+			if !revokedAt.IsZero() && !userInfo.IssuedAt.After(revokedAt) {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"message": "Token revoked",
+				})
+			}
+		}
+
 		// Set user information in context
 		c.Locals("userID", userInfo.ID)
 		c.Locals("email", userInfo.Email)
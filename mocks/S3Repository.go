@@ -5,7 +5,9 @@
 package mocks
 
 import (
+	"io"
 	"mime/multipart"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -37,6 +39,57 @@ func (_m *S3Repository) EXPECT() *S3Repository_Expecter {
 	return &S3Repository_Expecter{mock: &_m.Mock}
 }
 
+// DeletePrivateFile provides a mock function for the type S3Repository
+func (_mock *S3Repository) DeletePrivateFile(objKey string) error {
+	ret := _mock.Called(objKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePrivateFile")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(objKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// S3Repository_DeletePrivateFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePrivateFile'
+type S3Repository_DeletePrivateFile_Call struct {
+	*mock.Call
+}
+
+// DeletePrivateFile is a helper method to define mock.On call
+//   - objKey string
+func (_e *S3Repository_Expecter) DeletePrivateFile(objKey interface{}) *S3Repository_DeletePrivateFile_Call {
+	return &S3Repository_DeletePrivateFile_Call{Call: _e.mock.On("DeletePrivateFile", objKey)}
+}
+
+func (_c *S3Repository_DeletePrivateFile_Call) Run(run func(objKey string)) *S3Repository_DeletePrivateFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *S3Repository_DeletePrivateFile_Call) Return(err error) *S3Repository_DeletePrivateFile_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *S3Repository_DeletePrivateFile_Call) RunAndReturn(run func(objKey string) error) *S3Repository_DeletePrivateFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeletePublicFile provides a mock function for the type S3Repository
 func (_mock *S3Repository) DeletePublicFile(objKey string) error {
 	ret := _mock.Called(objKey)
@@ -88,6 +141,129 @@ func (_c *S3Repository_DeletePublicFile_Call) RunAndReturn(run func(objKey strin
 	return _c
 }
 
+// GetDownloadURLFile provides a mock function for the type S3Repository
+func (_mock *S3Repository) GetDownloadURLFile(objKey string, fileName string, expires time.Duration) (string, error) {
+	ret := _mock.Called(objKey, fileName, expires)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDownloadURLFile")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, time.Duration) (string, error)); ok {
+		return returnFunc(objKey, fileName, expires)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, time.Duration) string); ok {
+		r0 = returnFunc(objKey, fileName, expires)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string, time.Duration) error); ok {
+		r1 = returnFunc(objKey, fileName, expires)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// S3Repository_GetDownloadURLFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDownloadURLFile'
+type S3Repository_GetDownloadURLFile_Call struct {
+	*mock.Call
+}
+
+// GetDownloadURLFile is a helper method to define mock.On call
+//   - objKey string
+//   - fileName string
+//   - expires time.Duration
+func (_e *S3Repository_Expecter) GetDownloadURLFile(objKey interface{}, fileName interface{}, expires interface{}) *S3Repository_GetDownloadURLFile_Call {
+	return &S3Repository_GetDownloadURLFile_Call{Call: _e.mock.On("GetDownloadURLFile", objKey, fileName, expires)}
+}
+
+func (_c *S3Repository_GetDownloadURLFile_Call) Run(run func(objKey string, fileName string, expires time.Duration)) *S3Repository_GetDownloadURLFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Duration
+		if args[2] != nil {
+			arg2 = args[2].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *S3Repository_GetDownloadURLFile_Call) Return(s string, err error) *S3Repository_GetDownloadURLFile_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *S3Repository_GetDownloadURLFile_Call) RunAndReturn(run func(objKey string, fileName string, expires time.Duration) (string, error)) *S3Repository_GetDownloadURLFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPublicURLFile provides a mock function for the type S3Repository
+func (_mock *S3Repository) GetPublicURLFile(objKey string) string {
+	ret := _mock.Called(objKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPublicURLFile")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
+		r0 = returnFunc(objKey)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// S3Repository_GetPublicURLFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPublicURLFile'
+type S3Repository_GetPublicURLFile_Call struct {
+	*mock.Call
+}
+
+// GetPublicURLFile is a helper method to define mock.On call
+//   - objKey string
+func (_e *S3Repository_Expecter) GetPublicURLFile(objKey interface{}) *S3Repository_GetPublicURLFile_Call {
+	return &S3Repository_GetPublicURLFile_Call{Call: _e.mock.On("GetPublicURLFile", objKey)}
+}
+
+func (_c *S3Repository_GetPublicURLFile_Call) Run(run func(objKey string)) *S3Repository_GetPublicURLFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *S3Repository_GetPublicURLFile_Call) Return(s string) *S3Repository_GetPublicURLFile_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *S3Repository_GetPublicURLFile_Call) RunAndReturn(run func(objKey string) string) *S3Repository_GetPublicURLFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetURLFile provides a mock function for the type S3Repository
 func (_mock *S3Repository) GetURLFile(objKey string) (string, error) {
 	ret := _mock.Called(objKey)
@@ -148,6 +324,69 @@ func (_c *S3Repository_GetURLFile_Call) RunAndReturn(run func(objKey string) (st
 	return _c
 }
 
+// UploadPrivateFile provides a mock function for the type S3Repository
+func (_mock *S3Repository) UploadPrivateFile(body io.Reader, objKey string, contentType string) error {
+	ret := _mock.Called(body, objKey, contentType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadPrivateFile")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(io.Reader, string, string) error); ok {
+		r0 = returnFunc(body, objKey, contentType)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// S3Repository_UploadPrivateFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadPrivateFile'
+type S3Repository_UploadPrivateFile_Call struct {
+	*mock.Call
+}
+
+// UploadPrivateFile is a helper method to define mock.On call
+//   - body io.Reader
+//   - objKey string
+//   - contentType string
+func (_e *S3Repository_Expecter) UploadPrivateFile(body interface{}, objKey interface{}, contentType interface{}) *S3Repository_UploadPrivateFile_Call {
+	return &S3Repository_UploadPrivateFile_Call{Call: _e.mock.On("UploadPrivateFile", body, objKey, contentType)}
+}
+
+func (_c *S3Repository_UploadPrivateFile_Call) Run(run func(body io.Reader, objKey string, contentType string)) *S3Repository_UploadPrivateFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 io.Reader
+		if args[0] != nil {
+			arg0 = args[0].(io.Reader)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *S3Repository_UploadPrivateFile_Call) Return(err error) *S3Repository_UploadPrivateFile_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *S3Repository_UploadPrivateFile_Call) RunAndReturn(run func(body io.Reader, objKey string, contentType string) error) *S3Repository_UploadPrivateFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UploadPublicFile provides a mock function for the type S3Repository
 func (_mock *S3Repository) UploadPublicFile(objFile *multipart.FileHeader) (string, error) {
 	ret := _mock.Called(objFile)
@@ -207,3 +446,66 @@ func (_c *S3Repository_UploadPublicFile_Call) RunAndReturn(run func(objFile *mul
 	_c.Call.Return(run)
 	return _c
 }
+
+// UploadPublicObject provides a mock function for the type S3Repository
+func (_mock *S3Repository) UploadPublicObject(body []byte, objKey string, contentType string) error {
+	ret := _mock.Called(body, objKey, contentType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadPublicObject")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func([]byte, string, string) error); ok {
+		r0 = returnFunc(body, objKey, contentType)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// S3Repository_UploadPublicObject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadPublicObject'
+type S3Repository_UploadPublicObject_Call struct {
+	*mock.Call
+}
+
+// UploadPublicObject is a helper method to define mock.On call
+//   - body []byte
+//   - objKey string
+//   - contentType string
+func (_e *S3Repository_Expecter) UploadPublicObject(body interface{}, objKey interface{}, contentType interface{}) *S3Repository_UploadPublicObject_Call {
+	return &S3Repository_UploadPublicObject_Call{Call: _e.mock.On("UploadPublicObject", body, objKey, contentType)}
+}
+
+func (_c *S3Repository_UploadPublicObject_Call) Run(run func(body []byte, objKey string, contentType string)) *S3Repository_UploadPublicObject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []byte
+		if args[0] != nil {
+			arg0 = args[0].([]byte)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *S3Repository_UploadPublicObject_Call) Return(err error) *S3Repository_UploadPublicObject_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *S3Repository_UploadPublicObject_Call) RunAndReturn(run func(body []byte, objKey string, contentType string) error) *S3Repository_UploadPublicObject_Call {
+	_c.Call.Return(run)
+	return _c
+}
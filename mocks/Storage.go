@@ -5,7 +5,9 @@
 package mocks
 
 import (
+	"io"
 	"mime/multipart"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	mock "github.com/stretchr/testify/mock"
@@ -38,6 +40,68 @@ func (_m *Storage) EXPECT() *Storage_Expecter {
 	return &Storage_Expecter{mock: &_m.Mock}
 }
 
+// DeletePrivateFile provides a mock function for the type Storage
+func (_mock *Storage) DeletePrivateFile(objKey string) (*s3.DeleteObjectOutput, error) {
+	ret := _mock.Called(objKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePrivateFile")
+	}
+
+	var r0 *s3.DeleteObjectOutput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (*s3.DeleteObjectOutput, error)); ok {
+		return returnFunc(objKey)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *s3.DeleteObjectOutput); ok {
+		r0 = returnFunc(objKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*s3.DeleteObjectOutput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(objKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Storage_DeletePrivateFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePrivateFile'
+type Storage_DeletePrivateFile_Call struct {
+	*mock.Call
+}
+
+// DeletePrivateFile is a helper method to define mock.On call
+//   - objKey string
+func (_e *Storage_Expecter) DeletePrivateFile(objKey interface{}) *Storage_DeletePrivateFile_Call {
+	return &Storage_DeletePrivateFile_Call{Call: _e.mock.On("DeletePrivateFile", objKey)}
+}
+
+func (_c *Storage_DeletePrivateFile_Call) Run(run func(objKey string)) *Storage_DeletePrivateFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *Storage_DeletePrivateFile_Call) Return(deleteObjectOutput *s3.DeleteObjectOutput, err error) *Storage_DeletePrivateFile_Call {
+	_c.Call.Return(deleteObjectOutput, err)
+	return _c
+}
+
+func (_c *Storage_DeletePrivateFile_Call) RunAndReturn(run func(objKey string) (*s3.DeleteObjectOutput, error)) *Storage_DeletePrivateFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeletePublicFile provides a mock function for the type Storage
 func (_mock *Storage) DeletePublicFile(objKey string) (*s3.DeleteObjectOutput, error) {
 	ret := _mock.Called(objKey)
@@ -100,6 +164,78 @@ func (_c *Storage_DeletePublicFile_Call) RunAndReturn(run func(objKey string) (*
 	return _c
 }
 
+// GetPresignDownloadURL provides a mock function for the type Storage
+func (_mock *Storage) GetPresignDownloadURL(objKey string, fileName string, expires time.Duration) (string, error) {
+	ret := _mock.Called(objKey, fileName, expires)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPresignDownloadURL")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, time.Duration) (string, error)); ok {
+		return returnFunc(objKey, fileName, expires)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, time.Duration) string); ok {
+		r0 = returnFunc(objKey, fileName, expires)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string, time.Duration) error); ok {
+		r1 = returnFunc(objKey, fileName, expires)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Storage_GetPresignDownloadURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPresignDownloadURL'
+type Storage_GetPresignDownloadURL_Call struct {
+	*mock.Call
+}
+
+// GetPresignDownloadURL is a helper method to define mock.On call
+//   - objKey string
+//   - fileName string
+//   - expires time.Duration
+func (_e *Storage_Expecter) GetPresignDownloadURL(objKey interface{}, fileName interface{}, expires interface{}) *Storage_GetPresignDownloadURL_Call {
+	return &Storage_GetPresignDownloadURL_Call{Call: _e.mock.On("GetPresignDownloadURL", objKey, fileName, expires)}
+}
+
+func (_c *Storage_GetPresignDownloadURL_Call) Run(run func(objKey string, fileName string, expires time.Duration)) *Storage_GetPresignDownloadURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Duration
+		if args[2] != nil {
+			arg2 = args[2].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Storage_GetPresignDownloadURL_Call) Return(s string, err error) *Storage_GetPresignDownloadURL_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *Storage_GetPresignDownloadURL_Call) RunAndReturn(run func(objKey string, fileName string, expires time.Duration) (string, error)) *Storage_GetPresignDownloadURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetPresignURL provides a mock function for the type Storage
 func (_mock *Storage) GetPresignURL(objKey string) (string, error) {
 	ret := _mock.Called(objKey)
@@ -160,6 +296,131 @@ func (_c *Storage_GetPresignURL_Call) RunAndReturn(run func(objKey string) (stri
 	return _c
 }
 
+// GetPublicURL provides a mock function for the type Storage
+func (_mock *Storage) GetPublicURL(objKey string) string {
+	ret := _mock.Called(objKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPublicURL")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
+		r0 = returnFunc(objKey)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// Storage_GetPublicURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPublicURL'
+type Storage_GetPublicURL_Call struct {
+	*mock.Call
+}
+
+// GetPublicURL is a helper method to define mock.On call
+//   - objKey string
+func (_e *Storage_Expecter) GetPublicURL(objKey interface{}) *Storage_GetPublicURL_Call {
+	return &Storage_GetPublicURL_Call{Call: _e.mock.On("GetPublicURL", objKey)}
+}
+
+func (_c *Storage_GetPublicURL_Call) Run(run func(objKey string)) *Storage_GetPublicURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *Storage_GetPublicURL_Call) Return(s string) *Storage_GetPublicURL_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *Storage_GetPublicURL_Call) RunAndReturn(run func(objKey string) string) *Storage_GetPublicURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PutPrivateObject provides a mock function for the type Storage
+func (_mock *Storage) PutPrivateObject(body io.Reader, objKey string, contentType string) (*s3.PutObjectOutput, error) {
+	ret := _mock.Called(body, objKey, contentType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutPrivateObject")
+	}
+
+	var r0 *s3.PutObjectOutput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(io.Reader, string, string) (*s3.PutObjectOutput, error)); ok {
+		return returnFunc(body, objKey, contentType)
+	}
+	if returnFunc, ok := ret.Get(0).(func(io.Reader, string, string) *s3.PutObjectOutput); ok {
+		r0 = returnFunc(body, objKey, contentType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*s3.PutObjectOutput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(io.Reader, string, string) error); ok {
+		r1 = returnFunc(body, objKey, contentType)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Storage_PutPrivateObject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutPrivateObject'
+type Storage_PutPrivateObject_Call struct {
+	*mock.Call
+}
+
+// PutPrivateObject is a helper method to define mock.On call
+//   - body io.Reader
+//   - objKey string
+//   - contentType string
+func (_e *Storage_Expecter) PutPrivateObject(body interface{}, objKey interface{}, contentType interface{}) *Storage_PutPrivateObject_Call {
+	return &Storage_PutPrivateObject_Call{Call: _e.mock.On("PutPrivateObject", body, objKey, contentType)}
+}
+
+func (_c *Storage_PutPrivateObject_Call) Run(run func(body io.Reader, objKey string, contentType string)) *Storage_PutPrivateObject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 io.Reader
+		if args[0] != nil {
+			arg0 = args[0].(io.Reader)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Storage_PutPrivateObject_Call) Return(putObjectOutput *s3.PutObjectOutput, err error) *Storage_PutPrivateObject_Call {
+	_c.Call.Return(putObjectOutput, err)
+	return _c
+}
+
+func (_c *Storage_PutPrivateObject_Call) RunAndReturn(run func(body io.Reader, objKey string, contentType string) (*s3.PutObjectOutput, error)) *Storage_PutPrivateObject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PutPublicFile provides a mock function for the type Storage
 func (_mock *Storage) PutPublicFile(objFile multipart.File, objKey string) (*s3.PutObjectOutput, error) {
 	ret := _mock.Called(objFile, objKey)
@@ -227,3 +488,77 @@ func (_c *Storage_PutPublicFile_Call) RunAndReturn(run func(objFile multipart.Fi
 	_c.Call.Return(run)
 	return _c
 }
+
+// PutPublicObject provides a mock function for the type Storage
+func (_mock *Storage) PutPublicObject(body io.Reader, objKey string, contentType string) (*s3.PutObjectOutput, error) {
+	ret := _mock.Called(body, objKey, contentType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutPublicObject")
+	}
+
+	var r0 *s3.PutObjectOutput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(io.Reader, string, string) (*s3.PutObjectOutput, error)); ok {
+		return returnFunc(body, objKey, contentType)
+	}
+	if returnFunc, ok := ret.Get(0).(func(io.Reader, string, string) *s3.PutObjectOutput); ok {
+		r0 = returnFunc(body, objKey, contentType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*s3.PutObjectOutput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(io.Reader, string, string) error); ok {
+		r1 = returnFunc(body, objKey, contentType)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Storage_PutPublicObject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutPublicObject'
+type Storage_PutPublicObject_Call struct {
+	*mock.Call
+}
+
+// PutPublicObject is a helper method to define mock.On call
+//   - body io.Reader
+//   - objKey string
+//   - contentType string
+func (_e *Storage_Expecter) PutPublicObject(body interface{}, objKey interface{}, contentType interface{}) *Storage_PutPublicObject_Call {
+	return &Storage_PutPublicObject_Call{Call: _e.mock.On("PutPublicObject", body, objKey, contentType)}
+}
+
+func (_c *Storage_PutPublicObject_Call) Run(run func(body io.Reader, objKey string, contentType string)) *Storage_PutPublicObject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 io.Reader
+		if args[0] != nil {
+			arg0 = args[0].(io.Reader)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Storage_PutPublicObject_Call) Return(putObjectOutput *s3.PutObjectOutput, err error) *Storage_PutPublicObject_Call {
+	_c.Call.Return(putObjectOutput, err)
+	return _c
+}
+
+func (_c *Storage_PutPublicObject_Call) RunAndReturn(run func(body io.Reader, objKey string, contentType string) (*s3.PutObjectOutput, error)) *Storage_PutPublicObject_Call {
+	_c.Call.Return(run)
+	return _c
+}
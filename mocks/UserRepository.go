@@ -40,6 +40,69 @@ func (_m *UserRepository) EXPECT() *UserRepository_Expecter {
 	return &UserRepository_Expecter{mock: &_m.Mock}
 }
 
+// AnonymiseUser provides a mock function for the type UserRepository
+func (_mock *UserRepository) AnonymiseUser(c *fiber.Ctx, userID uuid.UUID, records []user.AccountRecords) error {
+	ret := _mock.Called(c, userID, records)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AnonymiseUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*fiber.Ctx, uuid.UUID, []user.AccountRecords) error); ok {
+		r0 = returnFunc(c, userID, records)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserRepository_AnonymiseUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AnonymiseUser'
+type UserRepository_AnonymiseUser_Call struct {
+	*mock.Call
+}
+
+// AnonymiseUser is a helper method to define mock.On call
+//   - c *fiber.Ctx
+//   - userID uuid.UUID
+//   - records []user.AccountRecords
+func (_e *UserRepository_Expecter) AnonymiseUser(c interface{}, userID interface{}, records interface{}) *UserRepository_AnonymiseUser_Call {
+	return &UserRepository_AnonymiseUser_Call{Call: _e.mock.On("AnonymiseUser", c, userID, records)}
+}
+
+func (_c *UserRepository_AnonymiseUser_Call) Run(run func(c *fiber.Ctx, userID uuid.UUID, records []user.AccountRecords)) *UserRepository_AnonymiseUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *fiber.Ctx
+		if args[0] != nil {
+			arg0 = args[0].(*fiber.Ctx)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 []user.AccountRecords
+		if args[2] != nil {
+			arg2 = args[2].([]user.AccountRecords)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepository_AnonymiseUser_Call) Return(err error) *UserRepository_AnonymiseUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserRepository_AnonymiseUser_Call) RunAndReturn(run func(c *fiber.Ctx, userID uuid.UUID, records []user.AccountRecords) error) *UserRepository_AnonymiseUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateUser provides a mock function for the type UserRepository
 func (_mock *UserRepository) CreateUser(c *fiber.Ctx, user1 *user.User) (*user.User, error) {
 	ret := _mock.Called(c, user1)
@@ -517,3 +580,66 @@ func (_c *UserRepository_UpdateUser_Call) RunAndReturn(run func(c *fiber.Ctx, us
 	_c.Call.Return(run)
 	return _c
 }
+
+// UpdateUserFields provides a mock function for the type UserRepository
+func (_mock *UserRepository) UpdateUserFields(c *fiber.Ctx, userID uuid.UUID, fields map[string]interface{}) error {
+	ret := _mock.Called(c, userID, fields)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserFields")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*fiber.Ctx, uuid.UUID, map[string]interface{}) error); ok {
+		r0 = returnFunc(c, userID, fields)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserRepository_UpdateUserFields_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserFields'
+type UserRepository_UpdateUserFields_Call struct {
+	*mock.Call
+}
+
+// UpdateUserFields is a helper method to define mock.On call
+//   - c *fiber.Ctx
+//   - userID uuid.UUID
+//   - fields map[string]interface{}
+func (_e *UserRepository_Expecter) UpdateUserFields(c interface{}, userID interface{}, fields interface{}) *UserRepository_UpdateUserFields_Call {
+	return &UserRepository_UpdateUserFields_Call{Call: _e.mock.On("UpdateUserFields", c, userID, fields)}
+}
+
+func (_c *UserRepository_UpdateUserFields_Call) Run(run func(c *fiber.Ctx, userID uuid.UUID, fields map[string]interface{})) *UserRepository_UpdateUserFields_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *fiber.Ctx
+		if args[0] != nil {
+			arg0 = args[0].(*fiber.Ctx)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 map[string]interface{}
+		if args[2] != nil {
+			arg2 = args[2].(map[string]interface{})
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepository_UpdateUserFields_Call) Return(err error) *UserRepository_UpdateUserFields_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserRepository_UpdateUserFields_Call) RunAndReturn(run func(c *fiber.Ctx, userID uuid.UUID, fields map[string]interface{}) error) *UserRepository_UpdateUserFields_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -114,6 +114,69 @@ func (_c *UserService_CreateUser_Call) RunAndReturn(run func(c *fiber.Ctx, userP
 	return _c
 }
 
+// DeleteAccount provides a mock function for the type UserService
+func (_mock *UserService) DeleteAccount(c *fiber.Ctx, userID uuid.UUID, password string) error {
+	ret := _mock.Called(c, userID, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAccount")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*fiber.Ctx, uuid.UUID, string) error); ok {
+		r0 = returnFunc(c, userID, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserService_DeleteAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAccount'
+type UserService_DeleteAccount_Call struct {
+	*mock.Call
+}
+
+// DeleteAccount is a helper method to define mock.On call
+//   - c *fiber.Ctx
+//   - userID uuid.UUID
+//   - password string
+func (_e *UserService_Expecter) DeleteAccount(c interface{}, userID interface{}, password interface{}) *UserService_DeleteAccount_Call {
+	return &UserService_DeleteAccount_Call{Call: _e.mock.On("DeleteAccount", c, userID, password)}
+}
+
+func (_c *UserService_DeleteAccount_Call) Run(run func(c *fiber.Ctx, userID uuid.UUID, password string)) *UserService_DeleteAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *fiber.Ctx
+		if args[0] != nil {
+			arg0 = args[0].(*fiber.Ctx)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_DeleteAccount_Call) Return(err error) *UserService_DeleteAccount_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserService_DeleteAccount_Call) RunAndReturn(run func(c *fiber.Ctx, userID uuid.UUID, password string) error) *UserService_DeleteAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteUser provides a mock function for the type UserService
 func (_mock *UserService) DeleteUser(c *fiber.Ctx, userID uuid.UUID) error {
 	ret := _mock.Called(c, userID)
@@ -376,6 +439,75 @@ func (_c *UserService_GetUserByID_Call) RunAndReturn(run func(c *fiber.Ctx, user
 	return _c
 }
 
+// PatchUser provides a mock function for the type UserService
+func (_mock *UserService) PatchUser(c *fiber.Ctx, userID uuid.UUID, contentType string, body []byte) error {
+	ret := _mock.Called(c, userID, contentType, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*fiber.Ctx, uuid.UUID, string, []byte) error); ok {
+		r0 = returnFunc(c, userID, contentType, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserService_PatchUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchUser'
+type UserService_PatchUser_Call struct {
+	*mock.Call
+}
+
+// PatchUser is a helper method to define mock.On call
+//   - c *fiber.Ctx
+//   - userID uuid.UUID
+//   - contentType string
+//   - body []byte
+func (_e *UserService_Expecter) PatchUser(c interface{}, userID interface{}, contentType interface{}, body interface{}) *UserService_PatchUser_Call {
+	return &UserService_PatchUser_Call{Call: _e.mock.On("PatchUser", c, userID, contentType, body)}
+}
+
+func (_c *UserService_PatchUser_Call) Run(run func(c *fiber.Ctx, userID uuid.UUID, contentType string, body []byte)) *UserService_PatchUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *fiber.Ctx
+		if args[0] != nil {
+			arg0 = args[0].(*fiber.Ctx)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []byte
+		if args[3] != nil {
+			arg3 = args[3].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_PatchUser_Call) Return(err error) *UserService_PatchUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserService_PatchUser_Call) RunAndReturn(run func(c *fiber.Ctx, userID uuid.UUID, contentType string, body []byte) error) *UserService_PatchUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ResetPassword provides a mock function for the type UserService
 func (_mock *UserService) ResetPassword(c *fiber.Ctx, resetPasswordToken string, newPassword string) error {
 	ret := _mock.Called(c, resetPasswordToken, newPassword)
@@ -0,0 +1,116 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"github.com/jerpsp/go-fiber-beginner/pkg/database"
+)
+
+type Event string
+
+const (
+	EventView      Event = "view"
+	EventLoan      Event = "loan"
+	EventFavourite Event = "favourite"
+	EventReview    Event = "review"
+)
+
+var Events = []Event{EventView, EventLoan, EventFavourite, EventReview}
+
+type ActivityConfig struct {
+	// CounterTTL is how long a day of counters stays in Redis, the rollup has to run at least once in that time
+	CounterTTL time.Duration `mapstructure:"ACTIVITY_COUNTER_TTL"`
+}
+
+// Counter keeps per day counts of book events. Days are UTC dates.
+type Counter interface {
+	Record(ctx context.Context, event Event, bookID uuid.UUID)
+	DayCounts(ctx context.Context, day time.Time) (map[uuid.UUID]map[Event]int64, error)
+}
+
+// New counts events in Redis, without a client events are dropped
+func New(cfg *ActivityConfig, redis *database.RedisDB) Counter {
+	if redis == nil {
+		return noopCounter{}
+	}
+	return &redisCounter{redis: redis, ttl: cfg.CounterTTL}
+}
+
+// Day truncates a time to the UTC date its events are counted under
+func Day(t time.Time) time.Time {
+	return time.Date(t.UTC().Year(), t.UTC().Month(), t.UTC().Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// RetainedDays lists the days that may still have counters in Redis, oldest first
+func RetainedDays(cfg *ActivityConfig, now time.Time) []time.Time {
+	n := int(math.Ceil(cfg.CounterTTL.Hours() / 24))
+	if n < 1 {
+		n = 1
+	}
+	today := Day(now)
+	days := make([]time.Time, 0, n)
+	for i := n - 1; i >= 0; i-- {
+		days = append(days, today.AddDate(0, 0, -i))
+	}
+	return days
+}
+
+type redisCounter struct {
+	redis *database.RedisDB
+	ttl   time.Duration
+}
+
+// counterKey holds one hash per day and event, fields are book IDs
+func counterKey(day time.Time, event Event) string {
+	return fmt.Sprintf("activity:%s:%s", day.Format(time.DateOnly), event)
+}
+
+// Record counts an event, failures are logged and never fail the request that caused the event
+func (r *redisCounter) Record(ctx context.Context, event Event, bookID uuid.UUID) {
+	key := counterKey(Day(time.Now()), event)
+	pipe := r.redis.Client.TxPipeline()
+	pipe.HIncrBy(ctx, key, bookID.String(), 1)
+	pipe.Expire(ctx, key, r.ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Errorf("failed to record %s of book %s: %v", event, bookID, err)
+	}
+}
+
+func (r *redisCounter) DayCounts(ctx context.Context, day time.Time) (map[uuid.UUID]map[Event]int64, error) {
+	counts := make(map[uuid.UUID]map[Event]int64)
+	for _, event := range Events {
+		fields, err := r.redis.Client.HGetAll(ctx, counterKey(Day(day), event)).Result()
+		if err != nil {
+			return nil, err
+		}
+		for field, value := range fields {
+			bookID, err := uuid.Parse(field)
+			if err != nil {
+				continue
+			}
+			count, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				continue
+			}
+			if counts[bookID] == nil {
+				counts[bookID] = make(map[Event]int64, len(Events))
+			}
+			counts[bookID][event] = count
+		}
+	}
+	return counts, nil
+}
+
+type noopCounter struct{}
+
+func (noopCounter) Record(ctx context.Context, event Event, bookID uuid.UUID) {}
+
+func (noopCounter) DayCounts(ctx context.Context, day time.Time) (map[uuid.UUID]map[Event]int64, error) {
+	return map[uuid.UUID]map[Event]int64{}, nil
+}
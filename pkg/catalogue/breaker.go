@@ -0,0 +1,81 @@
+package catalogue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+type breakerState int
+
+const (
+	stateClosed breakerState = iota
+	stateOpen
+	stateHalfOpen
+)
+
+// breaker stops calling a failing provider for a while, after the timeout a single trial call decides
+// whether it closes again. Unknown ISBNs are answers, not failures.
+type breaker struct {
+	provider    Provider
+	threshold   int
+	openTimeout time.Duration
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+}
+
+func NewBreaker(provider Provider, threshold int, openTimeout time.Duration) Provider {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &breaker{provider: provider, threshold: threshold, openTimeout: openTimeout}
+}
+
+func (b *breaker) LookupISBN(ctx context.Context, isbn string) (*Metadata, error) {
+	if !b.allow() {
+		return nil, ErrUnavailable
+	}
+
+	metadata, err := b.provider.LookupISBN(ctx, isbn)
+	b.record(err == nil || errors.Is(err, ErrNotFound))
+	return metadata, err
+}
+
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case stateOpen:
+		if time.Now().Sub(b.openedAt) < b.openTimeout {
+			return false
+		}
+		b.state = stateHalfOpen
+		return true
+	case stateHalfOpen:
+		// a trial call is already in flight
+		return false
+	}
+	return true
+}
+
+func (b *breaker) record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if success {
+		b.state = stateClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == stateHalfOpen || b.failures >= b.threshold {
+		b.state = stateOpen
+		b.openedAt = time.Now()
+	}
+}
@@ -0,0 +1,63 @@
+package catalogue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/jerpsp/go-fiber-beginner/pkg/database"
+	"github.com/redis/go-redis/v9"
+)
+
+type cachedProvider struct {
+	provider Provider
+	redis    *database.RedisDB
+	ttl      time.Duration
+}
+
+// NewCachedProvider keeps provider answers in Redis, unknown ISBNs are cached too so they are not asked again
+func NewCachedProvider(provider Provider, redis *database.RedisDB, ttl time.Duration) Provider {
+	return &cachedProvider{provider: provider, redis: redis, ttl: ttl}
+}
+
+// cachedLookup is stored per ISBN, a nil Metadata records that the catalogue does not know it
+type cachedLookup struct {
+	Metadata *Metadata `json:"metadata"`
+}
+
+func cacheKey(isbn string) string {
+	return fmt.Sprintf("catalogue:isbn:%s", isbn)
+}
+
+func (p *cachedProvider) LookupISBN(ctx context.Context, isbn string) (*Metadata, error) {
+	key := cacheKey(isbn)
+
+	data, err := p.redis.Client.Get(ctx, key).Bytes()
+	if err == nil {
+		var cached cachedLookup
+		if err := json.Unmarshal(data, &cached); err == nil {
+			if cached.Metadata == nil {
+				return nil, ErrNotFound
+			}
+			return cached.Metadata, nil
+		}
+	} else if !errors.Is(err, redis.Nil) {
+		log.Errorf("Error reading catalogue cache: %v", err)
+	}
+
+	metadata, err := p.provider.LookupISBN(ctx, isbn)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	if data, marshalErr := json.Marshal(cachedLookup{Metadata: metadata}); marshalErr == nil {
+		if setErr := p.redis.Client.Set(ctx, key, data, p.ttl).Err(); setErr != nil {
+			log.Errorf("Error writing catalogue cache: %v", setErr)
+		}
+	}
+
+	return metadata, err
+}
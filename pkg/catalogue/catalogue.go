@@ -0,0 +1,49 @@
+package catalogue
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jerpsp/go-fiber-beginner/pkg/database"
+)
+
+var (
+	ErrNotFound    = errors.New("isbn not found in catalogue")
+	ErrUnavailable = errors.New("catalogue provider unavailable")
+)
+
+type CatalogueConfig struct {
+	BaseURL          string        `mapstructure:"CATALOGUE_BASE_URL"`
+	Timeout          time.Duration `mapstructure:"CATALOGUE_TIMEOUT"`
+	CacheTTL         time.Duration `mapstructure:"CATALOGUE_CACHE_TTL"`
+	FailureThreshold int           `mapstructure:"CATALOGUE_FAILURE_THRESHOLD"`
+	OpenTimeout      time.Duration `mapstructure:"CATALOGUE_OPEN_TIMEOUT"`
+}
+
+// Metadata is what a catalogue knows about one edition, used to prefill a new book
+type Metadata struct {
+	ISBN          string   `json:"isbn"`
+	Title         string   `json:"title"`
+	Subtitle      string   `json:"subtitle,omitempty"`
+	Authors       []string `json:"authors"`
+	Publisher     string   `json:"publisher"`
+	PublishedDate string   `json:"published_date,omitempty"`
+	PageCount     int      `json:"page_count"`
+	CoverURL      string   `json:"cover_url,omitempty"`
+}
+
+// Provider looks up edition metadata by a normalised ISBN
+type Provider interface {
+	LookupISBN(ctx context.Context, isbn string) (*Metadata, error)
+}
+
+// New builds the Open Library provider behind a circuit breaker, answers are cached in Redis when a client is given
+func New(cfg *CatalogueConfig, redis *database.RedisDB) Provider {
+	var provider Provider = NewOpenLibraryProvider(cfg.BaseURL, cfg.Timeout)
+	provider = NewBreaker(provider, cfg.FailureThreshold, cfg.OpenTimeout)
+	if redis != nil {
+		provider = NewCachedProvider(provider, redis, cfg.CacheTTL)
+	}
+	return provider
+}
@@ -0,0 +1,138 @@
+package catalogue_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jerpsp/go-fiber-beginner/pkg/catalogue"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type CatalogueSuite struct {
+	suite.Suite
+}
+
+func TestCatalogueSuite(t *testing.T) {
+	suite.Run(t, new(CatalogueSuite))
+}
+
+type stubProvider struct {
+	calls int
+	err   error
+}
+
+func (p *stubProvider) LookupISBN(ctx context.Context, isbn string) (*catalogue.Metadata, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &catalogue.Metadata{ISBN: isbn}, nil
+}
+
+func (s *CatalogueSuite) TestOpenLibraryLookup1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Open Library Lookup Success", 34)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/api/books", r.URL.Path)
+		s.Equal("ISBN:9780140328721", r.URL.Query().Get("bibkeys"))
+		w.Write([]byte(`{"ISBN:9780140328721": {"title": "Fantastic Mr Fox", "number_of_pages": 96,
+			"authors": [{"name": "Roald Dahl"}], "publishers": [{"name": "Puffin"}],
+			"cover": {"medium": "https://covers.example/m.jpg", "large": "https://covers.example/l.jpg"}}}`))
+	}))
+	defer server.Close()
+
+	provider := catalogue.NewOpenLibraryProvider(server.URL, time.Second)
+	metadata, err := provider.LookupISBN(context.Background(), "9780140328721")
+
+	s.NoError(err)
+	s.Equal("Fantastic Mr Fox", metadata.Title)
+	s.Equal([]string{"Roald Dahl"}, metadata.Authors)
+	s.Equal("Puffin", metadata.Publisher)
+	s.Equal(96, metadata.PageCount)
+	s.Equal("https://covers.example/l.jpg", metadata.CoverURL)
+}
+
+func (s *CatalogueSuite) TestOpenLibraryLookup2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Open Library Lookup Unknown ISBN", 31)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	provider := catalogue.NewOpenLibraryProvider(server.URL, time.Second)
+	_, err := provider.LookupISBN(context.Background(), "9780140328721")
+
+	s.ErrorIs(err, catalogue.ErrNotFound)
+}
+
+func (s *CatalogueSuite) TestOpenLibraryLookup3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Open Library Lookup Timeout", 31)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	provider := catalogue.NewOpenLibraryProvider(server.URL, 10*time.Millisecond)
+	_, err := provider.LookupISBN(context.Background(), "9780140328721")
+
+	s.ErrorIs(err, catalogue.ErrUnavailable)
+}
+
+func (s *CatalogueSuite) TestBreaker1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Breaker Opens After Failures", 31)
+
+	stub := &stubProvider{err: errors.New("connection refused")}
+	provider := catalogue.NewBreaker(stub, 2, time.Minute)
+
+	provider.LookupISBN(context.Background(), "9780140328721")
+	provider.LookupISBN(context.Background(), "9780140328721")
+	_, err := provider.LookupISBN(context.Background(), "9780140328721")
+
+	s.ErrorIs(err, catalogue.ErrUnavailable)
+	s.Equal(2, stub.calls)
+}
+
+func (s *CatalogueSuite) TestBreaker2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Breaker Closes After Successful Trial", 34)
+
+	stub := &stubProvider{err: errors.New("connection refused")}
+	provider := catalogue.NewBreaker(stub, 1, 20*time.Millisecond)
+
+	provider.LookupISBN(context.Background(), "9780140328721")
+	time.Sleep(30 * time.Millisecond)
+	stub.err = nil
+
+	metadata, err := provider.LookupISBN(context.Background(), "9780140328721")
+	s.NoError(err)
+	s.Equal("9780140328721", metadata.ISBN)
+
+	_, err = provider.LookupISBN(context.Background(), "9780140328721")
+	s.NoError(err)
+	s.Equal(3, stub.calls)
+}
+
+func (s *CatalogueSuite) TestBreaker3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Breaker Ignores Unknown ISBN", 34)
+
+	stub := &stubProvider{err: catalogue.ErrNotFound}
+	provider := catalogue.NewBreaker(stub, 1, time.Minute)
+
+	provider.LookupISBN(context.Background(), "9780140328721")
+	_, err := provider.LookupISBN(context.Background(), "9780140328721")
+
+	s.ErrorIs(err, catalogue.ErrNotFound)
+	s.Equal(2, stub.calls)
+}
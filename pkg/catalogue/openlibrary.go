@@ -0,0 +1,100 @@
+package catalogue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type openLibraryProvider struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewOpenLibraryProvider talks to the Open Library books API, or any service exposing the same endpoint
+func NewOpenLibraryProvider(baseURL string, timeout time.Duration) Provider {
+	return &openLibraryProvider{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+type openLibraryBook struct {
+	Title         string `json:"title"`
+	Subtitle      string `json:"subtitle"`
+	PublishDate   string `json:"publish_date"`
+	NumberOfPages int    `json:"number_of_pages"`
+	Authors       []struct {
+		Name string `json:"name"`
+	} `json:"authors"`
+	Publishers []struct {
+		Name string `json:"name"`
+	} `json:"publishers"`
+	Cover struct {
+		Small  string `json:"small"`
+		Medium string `json:"medium"`
+		Large  string `json:"large"`
+	} `json:"cover"`
+}
+
+func (p *openLibraryProvider) LookupISBN(ctx context.Context, isbn string) (*Metadata, error) {
+	bibKey := "ISBN:" + isbn
+	query := url.Values{"bibkeys": {bibKey}, "format": {"json"}, "jscmd": {"data"}}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/api/books?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: unexpected status %d", ErrUnavailable, resp.StatusCode)
+	}
+
+	// unknown ISBNs come back as an empty object rather than a 404
+	var result map[string]openLibraryBook
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	found, ok := result[bibKey]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	metadata := &Metadata{
+		ISBN:          isbn,
+		Title:         strings.TrimSpace(found.Title),
+		Subtitle:      strings.TrimSpace(found.Subtitle),
+		PublishedDate: found.PublishDate,
+		PageCount:     found.NumberOfPages,
+		Authors:       []string{},
+	}
+	for _, author := range found.Authors {
+		if name := strings.TrimSpace(author.Name); name != "" {
+			metadata.Authors = append(metadata.Authors, name)
+		}
+	}
+	if len(found.Publishers) > 0 {
+		metadata.Publisher = strings.TrimSpace(found.Publishers[0].Name)
+	}
+	switch {
+	case found.Cover.Large != "":
+		metadata.CoverURL = found.Cover.Large
+	case found.Cover.Medium != "":
+		metadata.CoverURL = found.Cover.Medium
+	default:
+		metadata.CoverURL = found.Cover.Small
+	}
+
+	return metadata, nil
+}
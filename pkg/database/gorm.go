@@ -49,6 +49,8 @@ func NewGormDB(cfg *PostgresConfig) *GormDB {
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
 		Logger: newLogger,
+		// unique and foreign key violations come back as gorm.ErrDuplicatedKey and gorm.ErrForeignKeyViolated
+		TranslateError: true,
 	})
 
 	if err != nil {
@@ -101,6 +103,15 @@ func (s *GormDB) AddExtension() error {
 	return nil
 }
 
+// AddTrigramExtension enables pg_trgm, used to find books with similar titles
+func (s *GormDB) AddTrigramExtension() error {
+	if tx := s.DB.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm;"); tx.Error != nil {
+		return tx.Error
+	}
+
+	return nil
+}
+
 func (s *GormDB) Disconnect() error {
 	sqlDB, err := s.DB.DB()
 	if err != nil {
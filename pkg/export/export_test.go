@@ -0,0 +1,159 @@
+package export_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/jerpsp/go-fiber-beginner/pkg/export"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+// sheet mirrors the parts of the worksheet XML the tests look at
+type sheet struct {
+	Rows []struct {
+		Ref   string `xml:"r,attr"`
+		Cells []struct {
+			Ref  string `xml:"r,attr"`
+			Type string `xml:"t,attr"`
+			Text string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+type ExportSuite struct {
+	suite.Suite
+}
+
+func TestExportSuite(t *testing.T) {
+	suite.Run(t, new(ExportSuite))
+}
+
+func (s *ExportSuite) write(format string, rows ...[]string) []byte {
+	var buf bytes.Buffer
+	writer, err := export.NewRowWriter(&buf, format)
+	s.Require().NoError(err)
+	s.Require().NoError(writer.WriteHeader(rows[0]))
+	for _, row := range rows[1:] {
+		s.Require().NoError(writer.WriteRow(row))
+	}
+	s.Require().NoError(writer.Close())
+	return buf.Bytes()
+}
+
+func (s *ExportSuite) readXLSX(data []byte) (map[string]string, sheet) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	s.Require().NoError(err)
+
+	parts := map[string]string{}
+	for _, file := range archive.File {
+		f, err := file.Open()
+		s.Require().NoError(err)
+		content, err := io.ReadAll(f)
+		s.Require().NoError(err)
+		f.Close()
+		parts[file.Name] = string(content)
+	}
+
+	var worksheet sheet
+	s.Require().NoError(xml.Unmarshal([]byte(parts["xl/worksheets/sheet1.xml"]), &worksheet))
+	return parts, worksheet
+}
+
+func (s *ExportSuite) TestCSV1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: CSV Escapes Special Characters", 34)
+
+	rows := [][]string{
+		{"title", "author"},
+		{`Hello, "World"`, "line one\nline two"},
+		{" padded ", ""},
+	}
+	data := s.write(export.FormatCSV, rows...)
+
+	s.Equal("title,author\n\"Hello, \"\"World\"\"\",\"line one\nline two\"\n\" padded \",\n", string(data))
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	s.NoError(err)
+	s.Equal(rows, records)
+}
+
+func (s *ExportSuite) TestNDJSON1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: NDJSON Writes One Object Per Row", 34)
+
+	data := s.write(export.FormatNDJSON, []string{"title", "author"}, []string{"Dune", `Frank "H"`}, []string{"Emma"})
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	s.Len(lines, 2)
+	var first, second map[string]string
+	s.NoError(json.Unmarshal([]byte(lines[0]), &first))
+	s.NoError(json.Unmarshal([]byte(lines[1]), &second))
+	s.Equal(map[string]string{"title": "Dune", "author": `Frank "H"`}, first)
+	s.Equal(map[string]string{"title": "Emma"}, second)
+}
+
+func (s *ExportSuite) TestXLSX1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: XLSX Workbook Parts And Sheet Rows", 34)
+
+	data := s.write(export.FormatXLSX, []string{"title", "author"}, []string{"Dune", "Frank Herbert"})
+	parts, worksheet := s.readXLSX(data)
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"} {
+		s.Contains(parts, name)
+	}
+	s.Contains(parts["[Content_Types].xml"], `PartName="/xl/worksheets/sheet1.xml"`)
+
+	s.Len(worksheet.Rows, 2)
+	s.Equal("1", worksheet.Rows[0].Ref)
+	s.Equal("A2", worksheet.Rows[1].Cells[0].Ref)
+	s.Equal("B2", worksheet.Rows[1].Cells[1].Ref)
+	s.Equal("inlineStr", worksheet.Rows[1].Cells[0].Type)
+	s.Equal("Dune", worksheet.Rows[1].Cells[0].Text)
+	s.Equal("Frank Herbert", worksheet.Rows[1].Cells[1].Text)
+}
+
+func (s *ExportSuite) TestXLSX2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: XLSX Escapes Special Characters", 34)
+
+	value := `<b>Tom & "Jerry"</b> it's `
+	data := s.write(export.FormatXLSX, []string{"title"}, []string{value})
+	parts, worksheet := s.readXLSX(data)
+
+	s.NotContains(parts["xl/worksheets/sheet1.xml"], "<b>")
+	s.Contains(parts["xl/worksheets/sheet1.xml"], "&lt;b&gt;Tom &amp; ")
+	s.Equal(value, worksheet.Rows[1].Cells[0].Text)
+}
+
+func (s *ExportSuite) TestXLSX3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: XLSX Column Names Past Z", 34)
+
+	columns := make([]string, 28)
+	for i := range columns {
+		columns[i] = "c"
+	}
+	_, worksheet := s.readXLSX(s.write(export.FormatXLSX, columns))
+
+	cells := worksheet.Rows[0].Cells
+	s.Equal("Z1", cells[25].Ref)
+	s.Equal("AA1", cells[26].Ref)
+	s.Equal("AB1", cells[27].Ref)
+}
+
+func (s *ExportSuite) TestFormat1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Unsupported Export Format", 31)
+
+	_, err := export.NewRowWriter(io.Discard, "pdf")
+	s.ErrorIs(err, export.ErrUnsupportedFormat)
+	s.ErrorIs(export.ValidateFormat("pdf"), export.ErrUnsupportedFormat)
+	s.NoError(export.ValidateFormat(export.FormatXLSX))
+}
@@ -0,0 +1,277 @@
+package feed
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/jerpsp/go-fiber-beginner/pkg/database"
+	"github.com/redis/go-redis/v9"
+)
+
+// subscriberBuffer is how many events a subscriber may fall behind before it is dropped
+const subscriberBuffer = 64
+
+// minRetryDelay and maxRetryDelay bound the wait before Run subscribes again after Redis failed
+const (
+	minRetryDelay = 500 * time.Millisecond
+	maxRetryDelay = 30 * time.Second
+)
+
+type FeedConfig struct {
+	// ReplaySize is how many recent events are kept for clients that reconnect with Last-Event-ID
+	ReplaySize int64 `mapstructure:"FEED_REPLAY_SIZE"`
+	// Heartbeat is how often an idle stream gets a comment line so proxies do not close it
+	Heartbeat time.Duration `mapstructure:"FEED_HEARTBEAT"`
+}
+
+// Event is one message of a feed, IDs are Redis stream IDs and order the events across all instances
+type Event struct {
+	ID   string          `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// After reports whether the event was published after the event with the given ID
+func (e Event) After(id string) bool {
+	return CompareIDs(e.ID, id) > 0
+}
+
+// Feed broadcasts events to the subscribers of every instance. An event is appended to a capped Redis stream,
+// which numbers it and keeps it for replays, and announced on a pub/sub channel that each instance relays.
+type Feed interface {
+	Publish(ctx context.Context, eventType string, data interface{}) error
+	Since(ctx context.Context, lastID string) ([]Event, bool, error)
+	Subscribe() (<-chan Event, func())
+	Run(ctx context.Context)
+}
+
+func New(cfg *FeedConfig, redis *database.RedisDB, name string) Feed {
+	return &redisFeed{redis: redis, config: cfg, name: name, subscribers: make(map[chan Event]struct{})}
+}
+
+type redisFeed struct {
+	redis  *database.RedisDB
+	config *FeedConfig
+	name   string
+
+	mu          sync.Mutex
+	subscribers map[chan Event]struct{}
+	closed      bool
+}
+
+func (f *redisFeed) streamKey() string {
+	return fmt.Sprintf("feed:%s", f.name)
+}
+
+func (f *redisFeed) channel() string {
+	return fmt.Sprintf("feed:%s:events", f.name)
+}
+
+func (f *redisFeed) Publish(ctx context.Context, eventType string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	// trimming is approximate, the stream may briefly hold a few more events than ReplaySize
+	id, err := f.redis.Client.XAdd(ctx, &redis.XAddArgs{
+		Stream: f.streamKey(),
+		MaxLen: f.config.ReplaySize,
+		Approx: true,
+		Values: map[string]interface{}{"type": eventType, "data": string(payload)},
+	}).Result()
+	if err != nil {
+		return err
+	}
+
+	message, err := json.Marshal(Event{ID: id, Type: eventType, Data: payload})
+	if err != nil {
+		return err
+	}
+	return f.redis.Client.Publish(ctx, f.channel(), message).Err()
+}
+
+// Since returns the buffered events after lastID, oldest first. complete is false when lastID is no event ID
+// or the events right after it were already trimmed, the client then has to start over.
+func (f *redisFeed) Since(ctx context.Context, lastID string) ([]Event, bool, error) {
+	if !validID(lastID) {
+		return nil, false, nil
+	}
+
+	oldest, err := f.redis.Client.XRangeN(ctx, f.streamKey(), "-", "+", 1).Result()
+	if err != nil {
+		return nil, false, err
+	}
+	if len(oldest) == 0 {
+		return []Event{}, true, nil
+	}
+	complete := CompareIDs(lastID, oldest[0].ID) >= 0
+	if !complete {
+		// stream IDs are not consecutive, so an ID before the buffer is only safe when nothing after it was
+		// trimmed, which the highest deleted ID tells
+		info, err := f.redis.Client.XInfoStream(ctx, f.streamKey()).Result()
+		if err != nil {
+			return nil, false, err
+		}
+		complete = CompareIDs(lastID, info.MaxDeletedEntryID) >= 0
+	}
+
+	messages, err := f.redis.Client.XRange(ctx, f.streamKey(), "("+lastID, "+").Result()
+	if err != nil {
+		return nil, false, err
+	}
+	events := make([]Event, 0, len(messages))
+	for _, message := range messages {
+		eventType, _ := message.Values["type"].(string)
+		data, _ := message.Values["data"].(string)
+		events = append(events, Event{ID: message.ID, Type: eventType, Data: json.RawMessage(data)})
+	}
+	return events, complete, nil
+}
+
+// Subscribe registers a subscriber of this instance. The channel is closed when the subscriber falls behind
+// or the feed stops, cancel unregisters it.
+func (f *redisFeed) Subscribe() (<-chan Event, func()) {
+	events := make(chan Event, subscriberBuffer)
+	f.mu.Lock()
+	if f.closed {
+		close(events)
+	} else {
+		f.subscribers[events] = struct{}{}
+	}
+	f.mu.Unlock()
+
+	return events, func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if _, ok := f.subscribers[events]; ok {
+			delete(f.subscribers, events)
+			close(events)
+		}
+	}
+}
+
+// Run relays the events published by any instance to the subscribers of this one until ctx is done. A failed
+// subscription is retried with a growing delay, the subscribers of this instance stay connected meanwhile.
+func (f *redisFeed) Run(ctx context.Context) {
+	defer f.closeSubscribers()
+
+	delay := minRetryDelay
+	for {
+		err := f.relay(ctx, func() { delay = minRetryDelay })
+		if ctx.Err() != nil {
+			return
+		}
+		log.Errorf("%s feed subscription failed, retrying in %s: %v", f.name, delay, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(2*delay, maxRetryDelay)
+	}
+}
+
+// relay subscribes to the channel and broadcasts its events until the subscription fails or ctx is done.
+// subscribed is called once Redis confirmed the subscription.
+func (f *redisFeed) relay(ctx context.Context, subscribed func()) error {
+	pubsub := f.redis.Client.Subscribe(ctx, f.channel())
+	defer pubsub.Close()
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return err
+	}
+	subscribed()
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case message, ok := <-messages:
+			if !ok {
+				// events published while the subscription was down never reach the subscribers, they are
+				// dropped to resume from the replay buffer
+				f.dropSubscribers()
+				return errors.New("subscription closed")
+			}
+			var event Event
+			if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+				log.Errorf("failed to decode %s feed event: %v", f.name, err)
+				continue
+			}
+			f.broadcast(event)
+		}
+	}
+}
+
+// broadcast never blocks on a slow subscriber, it is dropped and resumes from the replay buffer when it reconnects
+func (f *redisFeed) broadcast(event Event) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for events := range f.subscribers {
+		select {
+		case events <- event:
+		default:
+			delete(f.subscribers, events)
+			close(events)
+		}
+	}
+}
+
+func (f *redisFeed) closeSubscribers() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	f.dropLocked()
+}
+
+func (f *redisFeed) dropSubscribers() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.dropLocked()
+}
+
+func (f *redisFeed) dropLocked() {
+	for events := range f.subscribers {
+		delete(f.subscribers, events)
+		close(events)
+	}
+}
+
+// CompareIDs orders two stream IDs of the form "<milliseconds>-<sequence>"
+func CompareIDs(a, b string) int {
+	aTime, aSeq := splitID(a)
+	bTime, bSeq := splitID(b)
+	if order := cmp.Compare(aTime, bTime); order != 0 {
+		return order
+	}
+	return cmp.Compare(aSeq, bSeq)
+}
+
+func splitID(id string) (uint64, uint64) {
+	timePart, seqPart, _ := strings.Cut(id, "-")
+	ms, _ := strconv.ParseUint(timePart, 10, 64)
+	seq, _ := strconv.ParseUint(seqPart, 10, 64)
+	return ms, seq
+}
+
+func validID(id string) bool {
+	timePart, seqPart, found := strings.Cut(id, "-")
+	if !found {
+		return false
+	}
+	if _, err := strconv.ParseUint(timePart, 10, 64); err != nil {
+		return false
+	}
+	_, err := strconv.ParseUint(seqPart, 10, 64)
+	return err == nil
+}
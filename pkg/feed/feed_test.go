@@ -0,0 +1,284 @@
+package feed_test
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jerpsp/go-fiber-beginner/pkg/database"
+	"github.com/jerpsp/go-fiber-beginner/pkg/feed"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+// memoryStream answers the stream commands a feed sends from a slice, so the replay buffer is tested without Redis
+type memoryStream struct {
+	mu         sync.Mutex
+	messages   []redis.XMessage
+	next       int
+	maxDeleted string
+	dials      int
+}
+
+func (m *memoryStream) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		m.mu.Lock()
+		m.dials++
+		m.mu.Unlock()
+		return nil, redis.ErrClosed
+	}
+}
+
+func (m *memoryStream) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		m.process(cmd)
+		return cmd.Err()
+	}
+}
+
+func (m *memoryStream) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		for _, cmd := range cmds {
+			m.process(cmd)
+		}
+		return nil
+	}
+}
+
+func (m *memoryStream) process(cmd redis.Cmder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	args := cmd.Args()
+	switch cmd.Name() {
+	case "xadd":
+		// xadd <stream> maxlen ~ <n> * <field> <value>...
+		maxLen := args[4].(int64)
+		m.next++
+		message := redis.XMessage{ID: fmt.Sprintf("%d-0", m.next), Values: map[string]interface{}{}}
+		for i := 6; i+1 < len(args); i += 2 {
+			message.Values[args[i].(string)] = args[i+1]
+		}
+		m.messages = append(m.messages, message)
+		if int64(len(m.messages)) > maxLen {
+			trimmed := int64(len(m.messages)) - maxLen
+			m.maxDeleted = m.messages[trimmed-1].ID
+			m.messages = m.messages[trimmed:]
+		}
+		cmd.(*redis.StringCmd).SetVal(message.ID)
+	case "xrange":
+		// xrange <stream> <start> + [count <n>], start is "-" or an exclusive "(<id>"
+		start := args[2].(string)
+		var messages []redis.XMessage
+		for _, message := range m.messages {
+			if start == "-" || feed.CompareIDs(message.ID, strings.TrimPrefix(start, "(")) > 0 {
+				messages = append(messages, message)
+			}
+		}
+		if len(args) > 5 && int64(len(messages)) > args[5].(int64) {
+			messages = messages[:args[5].(int64)]
+		}
+		cmd.(*redis.XMessageSliceCmd).SetVal(messages)
+	case "xinfo":
+		// xinfo stream <stream>, only the highest trimmed ID is answered
+		maxDeleted := cmp.Or(m.maxDeleted, "0-0")
+		cmd.(*redis.XInfoStreamCmd).SetVal(&redis.XInfoStream{Length: int64(len(m.messages)), MaxDeletedEntryID: maxDeleted})
+	case "publish":
+		cmd.(*redis.IntCmd).SetVal(0)
+	}
+}
+
+type FeedSuite struct {
+	suite.Suite
+	stream *memoryStream
+	feed   feed.Feed
+}
+
+func TestFeedSuite(t *testing.T) {
+	suite.Run(t, new(FeedSuite))
+}
+
+func (s *FeedSuite) SetupTest() {
+	s.stream = &memoryStream{}
+	client := redis.NewClient(&redis.Options{Addr: "memory:6379"})
+	client.AddHook(s.stream)
+	s.feed = feed.New(&feed.FeedConfig{ReplaySize: 3}, &database.RedisDB{Client: client}, "books")
+}
+
+func (s *FeedSuite) TestCompareIDs1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Compare Stream IDs", 34)
+
+	tests := []struct {
+		a     string
+		b     string
+		order int
+	}{
+		{"1-0", "1-0", 0},
+		{"1-0", "2-0", -1},
+		{"2-0", "1-9", 1},
+		{"5-1", "5-2", -1},
+		// the parts are numbers, not strings
+		{"10-0", "9-0", 1},
+		{"5-10", "5-9", 1},
+		{"1700000000000-0", "999999999999-5", 1},
+		{"7", "7-0", 0},
+	}
+
+	for _, test := range tests {
+		s.Equal(test.order, feed.CompareIDs(test.a, test.b), "%s vs %s", test.a, test.b)
+		s.Equal(-test.order, feed.CompareIDs(test.b, test.a), "%s vs %s", test.b, test.a)
+	}
+}
+
+func (s *FeedSuite) TestAfter1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Event After ID", 34)
+
+	event := feed.Event{ID: "10-2"}
+	s.True(event.After("10-1"))
+	s.True(event.After("9-5"))
+	s.False(event.After("10-2"))
+	s.False(event.After("11-0"))
+}
+
+func (s *FeedSuite) TestSince1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Since Replays Events After The ID", 34)
+
+	for _, title := range []string{"Dune", "Dune Messiah", "Children of Dune"} {
+		s.NoError(s.feed.Publish(context.Background(), "created", map[string]string{"title": title}))
+	}
+
+	tests := []struct {
+		lastID string
+		ids    []string
+	}{
+		{"1-0", []string{"2-0", "3-0"}},
+		{"2-0", []string{"3-0"}},
+		{"3-0", []string{}},
+	}
+
+	for _, test := range tests {
+		// Call the service method
+		events, complete, err := s.feed.Since(context.Background(), test.lastID)
+
+		// Assertions
+		s.NoError(err)
+		s.True(complete, test.lastID)
+		ids := []string{}
+		for _, event := range events {
+			ids = append(ids, event.ID)
+			s.Equal("created", event.Type)
+		}
+		s.Equal(test.ids, ids, test.lastID)
+	}
+
+	events, _, _ := s.feed.Since(context.Background(), "2-0")
+	var data map[string]string
+	s.NoError(json.Unmarshal(events[0].Data, &data))
+	s.Equal("Children of Dune", data["title"])
+}
+
+func (s *FeedSuite) TestSince2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Since After Trimmed Events", 31)
+
+	// five events in a buffer of three leave 3-0 to 5-0
+	for i := 0; i < 5; i++ {
+		s.NoError(s.feed.Publish(context.Background(), "updated", map[string]int{"n": i}))
+	}
+
+	// 2-0 was trimmed but the client already has it, so it missed nothing
+	tests := []struct {
+		lastID   string
+		complete bool
+		count    int
+	}{
+		{"1-0", false, 3},
+		{"2-0", true, 3},
+		{"3-0", true, 2},
+		{"4-0", true, 1},
+	}
+
+	for _, test := range tests {
+		// Call the service method
+		events, complete, err := s.feed.Since(context.Background(), test.lastID)
+
+		// Assertions
+		s.NoError(err)
+		s.Equal(test.complete, complete, test.lastID)
+		s.Len(events, test.count, test.lastID)
+	}
+}
+
+func (s *FeedSuite) TestSince3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Since Malformed Last-Event-ID", 31)
+
+	s.NoError(s.feed.Publish(context.Background(), "created", map[string]string{"title": "Dune"}))
+
+	for _, lastID := range []string{"", "abc", "1", "1-", "-1", "1-x", "x-1", "-1-0", "1-0-0", "18446744073709551616-0"} {
+		// Call the service method
+		events, complete, err := s.feed.Since(context.Background(), lastID)
+
+		// Assertions
+		s.NoError(err, lastID)
+		s.False(complete, lastID)
+		s.Empty(events, lastID)
+	}
+}
+
+func (s *FeedSuite) TestSince4() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Since On An Empty Stream", 34)
+
+	// Call the service method
+	events, complete, err := s.feed.Since(context.Background(), "5-0")
+
+	// Assertions
+	s.NoError(err)
+	s.True(complete)
+	s.Empty(events)
+}
+
+func (s *FeedSuite) TestRun1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Run Retries While Redis Is Unreachable", 31)
+
+	// Setup Mock
+	// every dial fails, Run keeps subscribing again instead of closing the feed
+	events, cancel := s.feed.Subscribe()
+	defer cancel()
+	ctx, stop := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.feed.Run(ctx)
+		close(done)
+	}()
+
+	// Assertions
+	s.Eventually(func() bool {
+		s.stream.mu.Lock()
+		defer s.stream.mu.Unlock()
+		// an attempt dials once to subscribe and once to receive, the third dial is the retry
+		return s.stream.dials > 2
+	}, 5*time.Second, 10*time.Millisecond)
+	select {
+	case _, ok := <-events:
+		s.Fail("subscriber closed while Run retries", "received: %v", ok)
+	default:
+	}
+
+	stop()
+	<-done
+	_, ok := <-events
+	s.False(ok)
+}
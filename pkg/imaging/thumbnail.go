@@ -0,0 +1,132 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+	"slices"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	FormatJPEG = "jpeg"
+	FormatWebP = "webp"
+
+	// maxPixels guards against decompression bombs: a tiny file that declares huge dimensions
+	maxPixels = 40_000_000
+)
+
+var (
+	ErrUnsupportedImage = errors.New("unsupported image format")
+	ErrImageTooLarge    = errors.New("image dimensions exceed limit")
+
+	allowedContentTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp"}
+)
+
+type Thumbnail struct {
+	Size        int
+	Format      string
+	ContentType string
+	Data        []byte
+}
+
+// DetectContentType sniffs the content type from the file bytes instead of trusting its extension
+func DetectContentType(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	if !slices.Contains(allowedContentTypes, contentType) {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedImage, contentType)
+	}
+	return contentType, nil
+}
+
+// Decode validates the sniffed content type and dimensions before decoding the full image
+func Decode(data []byte) (image.Image, error) {
+	if _, err := DetectContentType(data); err != nil {
+		return nil, err
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+	return img, nil
+}
+
+// Resize scales the image so its longest side fits maxSize, keeping the aspect ratio. Smaller images are not upscaled.
+func Resize(src image.Image, maxSize int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxSize && height <= maxSize {
+		return src
+	}
+
+	if width >= height {
+		height = max(1, height*maxSize/width)
+		width = maxSize
+	} else {
+		width = max(1, width*maxSize/height)
+		height = maxSize
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+	return dst
+}
+
+func Encode(img image.Image, format string) ([]byte, error) {
+	var buf bytes.Buffer
+	switch format {
+	case FormatJPEG:
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85}); err != nil {
+			return nil, err
+		}
+	case FormatWebP:
+		if err := nativewebp.Encode(&buf, img, nil); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedImage, format)
+	}
+	return buf.Bytes(), nil
+}
+
+func ContentType(format string) string {
+	return "image/" + format
+}
+
+// GenerateThumbnails renders one thumbnail per size and format combination
+func GenerateThumbnails(src image.Image, sizes []int, formats []string) ([]Thumbnail, error) {
+	thumbnails := make([]Thumbnail, 0, len(sizes)*len(formats))
+	for _, size := range sizes {
+		resized := Resize(src, size)
+		for _, format := range formats {
+			data, err := Encode(resized, format)
+			if err != nil {
+				return nil, err
+			}
+			thumbnails = append(thumbnails, Thumbnail{
+				Size:        size,
+				Format:      format,
+				ContentType: ContentType(format),
+				Data:        data,
+			})
+		}
+	}
+	return thumbnails, nil
+}
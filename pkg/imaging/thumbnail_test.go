@@ -0,0 +1,75 @@
+package imaging_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/jerpsp/go-fiber-beginner/pkg/imaging"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type ThumbnailSuite struct {
+	suite.Suite
+	pngData []byte
+}
+
+func TestThumbnailSuite(t *testing.T) {
+	suite.Run(t, new(ThumbnailSuite))
+}
+
+func (s *ThumbnailSuite) SetupTest() {
+	img := image.NewRGBA(image.Rect(0, 0, 400, 200))
+	for x := 0; x < 400; x++ {
+		for y := 0; y < 200; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+	s.pngData = buf.Bytes()
+}
+
+func (s *ThumbnailSuite) TestDecode1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Decode PNG Success", 34)
+
+	img, err := imaging.Decode(s.pngData)
+
+	s.NoError(err)
+	s.Equal(400, img.Bounds().Dx())
+}
+
+func (s *ThumbnailSuite) TestDecode2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Decode Non Image Fail", 31)
+
+	img, err := imaging.Decode([]byte("<html><body>not an image</body></html>"))
+
+	s.ErrorIs(err, imaging.ErrUnsupportedImage)
+	s.Nil(img)
+}
+
+func (s *ThumbnailSuite) TestGenerateThumbnails1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Generate Thumbnails Success", 34)
+
+	img, _ := imaging.Decode(s.pngData)
+	thumbnails, err := imaging.GenerateThumbnails(img, []int{64, 1024}, []string{imaging.FormatJPEG, imaging.FormatWebP})
+
+	s.NoError(err)
+	s.Len(thumbnails, 4)
+	for _, thumbnail := range thumbnails {
+		contentType, err := imaging.DetectContentType(thumbnail.Data)
+		s.NoError(err)
+		s.Equal(thumbnail.ContentType, contentType)
+
+		decoded, err := imaging.Decode(thumbnail.Data)
+		s.NoError(err)
+		// 1024 does not upscale the 400px source
+		s.Equal(min(thumbnail.Size, 400), decoded.Bounds().Dx())
+	}
+}
@@ -0,0 +1,97 @@
+package patch_test
+
+import (
+	"testing"
+
+	"github.com/jerpsp/go-fiber-beginner/pkg/patch"
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type document struct {
+	Title  string   `json:"title"`
+	Author string   `json:"author"`
+	Tags   []string `json:"tags"`
+}
+
+type PatchSuite struct {
+	suite.Suite
+	doc document
+}
+
+func TestPatchSuite(t *testing.T) {
+	suite.Run(t, new(PatchSuite))
+}
+
+func (s *PatchSuite) SetupTest() {
+	s.doc = document{Title: "Dune", Author: "Frank Herbert", Tags: []string{"sci-fi", "classic"}}
+}
+
+func (s *PatchSuite) TestApply1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Merge Patch Keeps Omitted Fields", 34)
+
+	err := patch.Apply(&s.doc, "application/merge-patch+json", []byte(`{"title": "Dune Messiah"}`))
+
+	s.NoError(err)
+	s.Equal(document{Title: "Dune Messiah", Author: "Frank Herbert", Tags: []string{"sci-fi", "classic"}}, s.doc)
+}
+
+func (s *PatchSuite) TestApply2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Merge Patch Null Clears Field", 34)
+
+	before := s.doc
+	err := patch.Apply(&s.doc, "application/json", []byte(`{"author": null}`))
+
+	s.NoError(err)
+	s.Equal("", s.doc.Author)
+	s.Equal(map[string]interface{}{"author": ""}, patch.Changes(before, s.doc))
+}
+
+func (s *PatchSuite) TestApply3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: JSON Patch Operations", 34)
+
+	body := `[
+		{"op": "test", "path": "/title", "value": "Dune"},
+		{"op": "replace", "path": "/title", "value": "Children of Dune"},
+		{"op": "add", "path": "/tags/1", "value": "desert"},
+		{"op": "remove", "path": "/tags/0"},
+		{"op": "copy", "from": "/tags/1", "path": "/tags/-"}
+	]`
+	err := patch.Apply(&s.doc, "application/json-patch+json", []byte(body))
+
+	s.NoError(err)
+	s.Equal(document{Title: "Children of Dune", Author: "Frank Herbert", Tags: []string{"desert", "classic", "classic"}}, s.doc)
+}
+
+func (s *PatchSuite) TestApply4() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: JSON Patch Failed Test Leaves Target Untouched", 31)
+
+	before := s.doc
+	body := `[{"op": "replace", "path": "/title", "value": "Other"}, {"op": "test", "path": "/author", "value": "Someone"}]`
+	err := patch.Apply(&s.doc, "application/json-patch+json", []byte(body))
+
+	s.ErrorIs(err, patch.ErrTestFailed)
+	s.Equal(before, s.doc)
+}
+
+func (s *PatchSuite) TestApply5() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Unknown Field Rejected", 31)
+
+	err := patch.Apply(&s.doc, "application/merge-patch+json", []byte(`{"version": 3}`))
+
+	s.ErrorIs(err, patch.ErrInvalidPatch)
+}
+
+func (s *PatchSuite) TestApply6() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Unsupported Media Type", 31)
+
+	err := patch.Apply(&s.doc, "text/plain", []byte(`title=x`))
+
+	s.ErrorIs(err, patch.ErrUnsupportedMediaType)
+}
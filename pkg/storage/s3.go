@@ -2,9 +2,12 @@ package storage
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"mime"
 	"mime/multipart"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -14,8 +17,13 @@ import (
 
 type Storage interface {
 	PutPublicFile(objFile multipart.File, objKey string) (*s3.PutObjectOutput, error)
+	PutPublicObject(body io.Reader, objKey string, contentType string) (*s3.PutObjectOutput, error)
+	PutPrivateObject(body io.Reader, objKey string, contentType string) (*s3.PutObjectOutput, error)
 	DeletePublicFile(objKey string) (*s3.DeleteObjectOutput, error)
+	DeletePrivateFile(objKey string) (*s3.DeleteObjectOutput, error)
 	GetPresignURL(objKey string) (string, error)
+	GetPresignDownloadURL(objKey string, fileName string, expires time.Duration) (string, error)
+	GetPublicURL(objKey string) string
 }
 
 type AWSConfig struct {
@@ -76,6 +84,37 @@ func (s *S3Client) PutPublicFile(objFile multipart.File, objKey string) (*s3.Put
 	return output, nil
 }
 
+func (s *S3Client) PutPublicObject(body io.Reader, objKey string, contentType string) (*s3.PutObjectOutput, error) {
+	output, err := s.s3.PutObject(context.Background(), &s3.PutObjectInput{
+		Bucket:      aws.String(s.cfg.PublicBucketName),
+		Key:         aws.String(objKey),
+		Body:        body,
+		ContentType: aws.String(contentType),
+		ACL:         "public-read",
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (s *S3Client) PutPrivateObject(body io.Reader, objKey string, contentType string) (*s3.PutObjectOutput, error) {
+	output, err := s.s3.PutObject(context.Background(), &s3.PutObjectInput{
+		Bucket:      aws.String(s.cfg.BucketName),
+		Key:         aws.String(objKey),
+		Body:        body,
+		ContentType: aws.String(contentType),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
 func (s *S3Client) DeletePublicFile(objKey string) (*s3.DeleteObjectOutput, error) {
 	result, err := s.s3.DeleteObject(context.Background(), &s3.DeleteObjectInput{
 		Bucket: aws.String(s.cfg.PublicBucketName),
@@ -87,6 +126,17 @@ func (s *S3Client) DeletePublicFile(objKey string) (*s3.DeleteObjectOutput, erro
 	return result, nil
 }
 
+func (s *S3Client) DeletePrivateFile(objKey string) (*s3.DeleteObjectOutput, error) {
+	result, err := s.s3.DeleteObject(context.Background(), &s3.DeleteObjectInput{
+		Bucket: aws.String(s.cfg.BucketName),
+		Key:    aws.String(objKey),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (s *S3Client) GetPresignURL(objKey string) (string, error) {
 	presignClient := s3.NewPresignClient(s.s3)
 	presignUrl, err := presignClient.PresignGetObject(context.Background(),
@@ -100,3 +150,24 @@ func (s *S3Client) GetPresignURL(objKey string) (string, error) {
 
 	return presignUrl.URL, nil
 }
+
+// GetPresignDownloadURL presigns a private object for the given time, the browser saves it under fileName
+func (s *S3Client) GetPresignDownloadURL(objKey string, fileName string, expires time.Duration) (string, error) {
+	presignClient := s3.NewPresignClient(s.s3)
+	presignUrl, err := presignClient.PresignGetObject(context.Background(),
+		&s3.GetObjectInput{
+			Bucket:                     aws.String(s.cfg.BucketName),
+			Key:                        aws.String(objKey),
+			ResponseContentDisposition: aws.String(mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))},
+		s3.WithPresignExpires(expires))
+	if err != nil {
+		return "", err
+	}
+
+	return presignUrl.URL, nil
+}
+
+// GetPublicURL builds the path-style URL of an object in the public bucket
+func (s *S3Client) GetPublicURL(objKey string) string {
+	return fmt.Sprintf("%s/%s/%s", strings.TrimRight(s.cfg.Endpoint, "/"), s.cfg.PublicBucketName, objKey)
+}
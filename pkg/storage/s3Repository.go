@@ -1,17 +1,25 @@
 package storage
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"mime/multipart"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type S3Repository interface {
 	UploadPublicFile(objFile *multipart.FileHeader) (string, error)
+	UploadPublicObject(body []byte, objKey string, contentType string) error
+	UploadPrivateFile(body io.Reader, objKey string, contentType string) error
 	DeletePublicFile(objKey string) error
+	DeletePrivateFile(objKey string) error
 	GetURLFile(objKey string) (string, error)
+	GetDownloadURLFile(objKey string, fileName string, expires time.Duration) (string, error)
+	GetPublicURLFile(objKey string) string
 }
 
 type s3Repository struct {
@@ -42,6 +50,26 @@ func (s *s3Repository) UploadPublicFile(objFile *multipart.FileHeader) (string,
 	return key, nil
 }
 
+func (s *s3Repository) UploadPublicObject(body []byte, objKey string, contentType string) error {
+	_, err := s.s3.PutPublicObject(bytes.NewReader(body), objKey, contentType)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *s3Repository) UploadPrivateFile(body io.Reader, objKey string, contentType string) error {
+	_, err := s.s3.PutPrivateObject(body, objKey, contentType)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (s *s3Repository) DeletePublicFile(objKey string) error {
 	_, err := s.s3.DeletePublicFile(objKey)
 
@@ -52,6 +80,16 @@ func (s *s3Repository) DeletePublicFile(objKey string) error {
 	return nil
 }
 
+func (s *s3Repository) DeletePrivateFile(objKey string) error {
+	_, err := s.s3.DeletePrivateFile(objKey)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (s *s3Repository) GetURLFile(objKey string) (string, error) {
 	url, err := s.s3.GetPresignURL(objKey)
 
@@ -61,3 +99,17 @@ func (s *s3Repository) GetURLFile(objKey string) (string, error) {
 
 	return url, nil
 }
+
+func (s *s3Repository) GetDownloadURLFile(objKey string, fileName string, expires time.Duration) (string, error) {
+	url, err := s.s3.GetPresignDownloadURL(objKey, fileName, expires)
+
+	if err != nil {
+		return "", err
+	}
+
+	return url, nil
+}
+
+func (s *s3Repository) GetPublicURLFile(objKey string) string {
+	return s.s3.GetPublicURL(objKey)
+}
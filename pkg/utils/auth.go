@@ -24,9 +24,10 @@ const (
 )
 
 type UserInfo struct {
-	ID    uuid.UUID `json:"id"`
-	Email string    `json:"email"`
-	Role  string    `json:"role"`
+	ID       uuid.UUID `json:"id"`
+	Email    string    `json:"email"`
+	Role     string    `json:"role"`
+	IssuedAt time.Time `json:"issued_at"`
 }
 
 // ValidateToken validates a JWT token and returns the user information
@@ -80,9 +81,18 @@ func ValidateToken(cfg *config.Config, tokenString string, tokenType TokenType)
 		}
 	}
 
+	// tokens without an issue time count as issued at the epoch, so any revocation ends them
+	var issuedAt time.Time
+	if iat, ok := claims["iat"].(float64); ok {
+		issuedAt = time.Unix(int64(iat), 0)
+	} else {
+		issuedAt = time.Unix(0, 0)
+	}
+
 	return &UserInfo{
-		ID:    userID,
-		Email: claims["email"].(string),
-		Role:  role,
+		ID:       userID,
+		Email:    claims["email"].(string),
+		Role:     role,
+		IssuedAt: issuedAt,
 	}, nil
 }
@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"strings"
+)
+
+// NormalizeISBN strips hyphens and spaces and upper-cases the ISBN-10 check digit
+func NormalizeISBN(isbn string) string {
+	replacer := strings.NewReplacer("-", "", " ", "")
+	return strings.ToUpper(replacer.Replace(strings.TrimSpace(isbn)))
+}
+
+// IsValidISBN checks the length and check digit of a normalized ISBN-10 or ISBN-13
+func IsValidISBN(isbn string) bool {
+	switch len(isbn) {
+	case 10:
+		sum := 0
+		for i, r := range isbn {
+			var digit int
+			switch {
+			case r >= '0' && r <= '9':
+				digit = int(r - '0')
+			case r == 'X' && i == 9:
+				digit = 10
+			default:
+				return false
+			}
+			sum += digit * (10 - i)
+		}
+		return sum%11 == 0
+	case 13:
+		sum := 0
+		for i, r := range isbn {
+			if r < '0' || r > '9' {
+				return false
+			}
+			weight := 1
+			if i%2 == 1 {
+				weight = 3
+			}
+			sum += int(r-'0') * weight
+		}
+		return sum%10 == 0
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/jerpsp/go-fiber-beginner/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type ISBNSuite struct {
+	suite.Suite
+}
+
+func TestISBNSuite(t *testing.T) {
+	suite.Run(t, new(ISBNSuite))
+}
+
+func (s *ISBNSuite) TestNormalizeISBN1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Normalize Hyphenated ISBN", 34)
+
+	s.Equal("9780306406157", utils.NormalizeISBN(" 978-0-306-40615-7 "))
+	s.Equal("080442957X", utils.NormalizeISBN("0 8044 2957 x"))
+}
+
+func (s *ISBNSuite) TestIsValidISBN1() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Valid ISBN-10 And ISBN-13", 34)
+
+	s.True(utils.IsValidISBN("0306406152"))
+	s.True(utils.IsValidISBN("080442957X"))
+	s.True(utils.IsValidISBN("9780306406157"))
+	s.True(utils.IsValidISBN(utils.NormalizeISBN("978-0-306-40615-7")))
+}
+
+func (s *ISBNSuite) TestIsValidISBN2() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Invalid ISBN Checksum", 31)
+
+	s.False(utils.IsValidISBN("0306406153"))
+	s.False(utils.IsValidISBN("9780306406158"))
+}
+
+func (s *ISBNSuite) TestIsValidISBN3() {
+	// Case Name Print In Test
+	utils.ConsolePrintColoredText("CASE: Invalid ISBN Format", 31)
+
+	// the ISBN-10 check digit may be X, no other position may
+	s.False(utils.IsValidISBN("08044295X7"))
+	s.False(utils.IsValidISBN("978030640615X"))
+	s.False(utils.IsValidISBN("978-0-306-40615-7"))
+	s.False(utils.IsValidISBN("123456789"))
+	s.False(utils.IsValidISBN(""))
+}